@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+action "microsoft365wp_cloud_pc_reprovision" "example" {
+  config {
+    id         = "00000000-0000-0000-0000-000000000000"
+    os_version = "windows11"
+  }
+}
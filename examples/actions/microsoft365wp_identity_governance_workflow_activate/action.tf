@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+resource "microsoft365wp_identity_governance_workflow" "onboarding" {
+  # ...
+}
+
+action "microsoft365wp_identity_governance_workflow_activate" "onboarding" {
+  config {
+    id       = microsoft365wp_identity_governance_workflow.onboarding.id
+    subjects = [{ id = "00000000-0000-0000-0000-000000000000" }]
+  }
+}
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+resource "microsoft365wp_device_configuration" "example" {
+  # ...
+
+  lifecycle {
+    action_trigger {
+      events  = [after_update]
+      actions = [action.microsoft365wp_managed_device_sync.example]
+    }
+  }
+}
+
+action "microsoft365wp_managed_device_sync" "example" {
+  config {
+    id = "00000000-0000-0000-0000-000000000000"
+  }
+}
@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+action "microsoft365wp_vpp_token_sync_licenses" "example" {
+  config {
+    id = "00000000-0000-0000-0000-000000000000"
+  }
+}
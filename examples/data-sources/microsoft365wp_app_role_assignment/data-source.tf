@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+data "microsoft365wp_app_role_assignment" "one" {
+  resource_id = "9c8d5d7a-4c1e-4d8b-8a3e-2f6b1c0d7e5a"
+
+  principal_id = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+}
+
+output "microsoft365wp_app_role_assignment" {
+  value = data.microsoft365wp_app_role_assignment.one
+}
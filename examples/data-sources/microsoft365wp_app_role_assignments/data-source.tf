@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+data "microsoft365wp_app_role_assignments" "all" {
+  resource_id = "9c8d5d7a-4c1e-4d8b-8a3e-2f6b1c0d7e5a"
+}
+
+output "microsoft365wp_app_role_assignments_principal_display_names" {
+  value = data.microsoft365wp_app_role_assignments.all.app_role_assignments[*].principal_display_name
+}
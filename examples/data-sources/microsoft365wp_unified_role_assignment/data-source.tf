@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+
+data "microsoft365wp_unified_role_assignment" "one" {
+  id = "lAPpYvVpN0KRkAEhdxReEJC2sEqbR_9Hr48lds9SGHI-1"
+}
+
+output "microsoft365wp_unified_role_assignment" {
+  value = data.microsoft365wp_unified_role_assignment.one
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+
+data "microsoft365wp_unified_role_assignments" "user" {
+  odata_filter = "principalId eq '0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d'"
+}
+
+output "microsoft365wp_unified_role_assignments" {
+  value = { for x in data.microsoft365wp_unified_role_assignments.user.unified_role_assignments : x.id => x.role_definition_id }
+}
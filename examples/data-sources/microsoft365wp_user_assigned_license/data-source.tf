@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+data "microsoft365wp_user_assigned_license" "one" {
+  user_id = "91ae5a52-67f6-4265-bbe5-b62268944675"
+
+  sku_id = "00ed1723-1992-4384-b7ce-1c3bf01eedc7"
+}
+
+output "microsoft365wp_user_assigned_license" {
+  value = data.microsoft365wp_user_assigned_license.one
+}
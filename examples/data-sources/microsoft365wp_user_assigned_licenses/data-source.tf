@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+data "microsoft365wp_user_assigned_licenses" "all" {
+  user_id = "91ae5a52-67f6-4265-bbe5-b62268944675"
+}
+
+output "microsoft365wp_user_assigned_licenses_sku_ids" {
+  value = data.microsoft365wp_user_assigned_licenses.all.user_assigned_licenses[*].sku_id
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+ephemeral "microsoft365wp_access_token" "graph" {
+}
+
+ephemeral "microsoft365wp_access_token" "arm" {
+  scopes = ["https://management.azure.com/.default"]
+}
+
+data "http" "organization" {
+  url = "https://graph.microsoft.com/v1.0/organization"
+  request_headers = {
+    Authorization = "Bearer ${ephemeral.microsoft365wp_access_token.graph.access_token}"
+  }
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+data "microsoft365wp_service_principal" "msgraph" {
+  app_id = "00000003-0000-0000-c000-000000000000"
+}
+
+# grant the application permission User.Read.All of Microsoft Graph to another service principal
+resource "microsoft365wp_app_role_assignment" "test" {
+  resource_id  = data.microsoft365wp_service_principal.msgraph.id
+  principal_id = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+  app_role_id  = "df021288-bdef-4463-88db-98f22de89214"
+}
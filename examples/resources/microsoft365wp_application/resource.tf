@@ -0,0 +1,58 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+resource "microsoft365wp_application" "test" {
+  display_name     = "TF Test App"
+  sign_in_audience = "AzureADMyOrg"
+
+  web = {
+    redirect_uris = ["https://contoso.com/signin-oidc"]
+    implicit_grant_settings = {
+      enable_id_token_issuance = true
+    }
+  }
+
+  app_roles = [
+    {
+      id                   = "d1c2ade8-98f8-45fd-aa4a-6d06b947c66f"
+      allowed_member_types = ["Application"]
+      display_name         = "Read all"
+      description          = "Allows to read all data"
+      value                = "Data.Read.All"
+    },
+  ]
+
+  required_resource_access = [
+    {
+      resource_app_id = "00000003-0000-0000-c000-000000000000" # Microsoft Graph
+      resource_access = [
+        {
+          id   = "e1fe6dd8-ba31-4d61-89e7-88639da4683d" # User.Read
+          type = "Scope"
+        },
+      ]
+    },
+  ]
+}
+
+resource "microsoft365wp_service_principal" "test" {
+  app_id = microsoft365wp_application.test.app_id
+}
+
+resource "microsoft365wp_application_password_credential" "test" {
+  application_id = microsoft365wp_application.test.id
+  display_name   = "terraform"
+}
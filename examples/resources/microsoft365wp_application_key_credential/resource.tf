@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+resource "microsoft365wp_application_key_credential" "test" {
+  application_id = "7e1d1b5c-5b1b-4b4e-8f0e-0c5a6c2a4f3d"
+  display_name   = "CN=terraform"
+  type           = "AsymmetricX509Cert"
+  usage          = "Verify"
+
+  # Base64 encoded DER certificate
+  key_base64 = filebase64("cert.der")
+}
@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+resource "time_rotating" "test" {
+  rotation_days = 180
+}
+
+resource "microsoft365wp_application_password_credential" "test" {
+  application_id = "7e1d1b5c-5b1b-4b4e-8f0e-0c5a6c2a4f3d"
+  display_name   = "terraform"
+  end_date_time  = timeadd(time_rotating.test.id, "4320h")
+
+  rotate_when_changed = {
+    rotation = time_rotating.test.id
+  }
+}
+
+output "microsoft365wp_application_password_credential_secret_text" {
+  value     = microsoft365wp_application_password_credential.test.secret_text
+  sensitive = true
+}
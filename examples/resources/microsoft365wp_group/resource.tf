@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+resource "microsoft365wp_group" "static" {
+  display_name     = "TF Test Static"
+  mail_enabled     = false
+  mail_nickname    = "tf-test-static"
+  security_enabled = true
+  owners           = [{ id = "00000000-0000-0000-0000-000000000001" }]
+  members          = [{ id = "00000000-0000-0000-0000-000000000002" }]
+}
+
+resource "microsoft365wp_group" "dynamic" {
+  display_name     = "TF Test Dynamic"
+  mail_enabled     = false
+  mail_nickname    = "tf-test-dynamic"
+  security_enabled = true
+  group_types      = ["DynamicMembership"]
+  membership_rule  = "(device.deviceOSType -eq \"Windows\") -and (device.accountEnabled -eq true)"
+}
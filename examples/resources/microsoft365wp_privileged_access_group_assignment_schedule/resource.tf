@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+
+resource "microsoft365wp_group" "test" {
+  display_name     = "TF Test PIM Group"
+  mail_enabled     = false
+  mail_nickname    = "tf-test-pim-group"
+  security_enabled = true
+}
+
+# active membership until the end of the year
+resource "microsoft365wp_privileged_access_group_assignment_schedule" "test" {
+  access_id     = "member"
+  group_id      = microsoft365wp_group.test.id
+  principal_id  = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+  justification = "Migration project"
+  schedule_info = {
+    start_date_time = "2025-01-01T00:00:00Z"
+    expiration = {
+      type          = "afterDateTime"
+      end_date_time = "2025-12-31T23:59:59Z"
+    }
+  }
+}
@@ -0,0 +1,45 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+
+resource "microsoft365wp_group" "test" {
+  display_name     = "TF Test PIM Group"
+  mail_enabled     = false
+  mail_nickname    = "tf-test-pim-group"
+  security_enabled = true
+}
+
+# eligible for membership for 180 days
+resource "microsoft365wp_privileged_access_group_eligibility_schedule" "member" {
+  access_id     = "member"
+  group_id      = microsoft365wp_group.test.id
+  principal_id  = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+  justification = "On-call rotation"
+  schedule_info = {
+    expiration = {
+      type     = "afterDuration"
+      duration = "P180D"
+    }
+  }
+}
+
+# permanently eligible for ownership
+resource "microsoft365wp_privileged_access_group_eligibility_schedule" "owner" {
+  access_id     = "owner"
+  group_id      = microsoft365wp_group.test.id
+  principal_id  = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+  justification = "Group owner"
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+resource "microsoft365wp_service_principal" "test" {
+  app_id = "12472e6c-34d1-4db9-9db0-f6649b14f5ba"
+
+  app_role_assignment_required = true
+  notes                        = "Managed by Terraform"
+}
@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+
+data "microsoft365wp_unified_role_definition" "helpdesk_administrator" {
+  display_name = "Helpdesk Administrator"
+}
+
+resource "microsoft365wp_administrative_unit" "test" {
+  display_name = "TF Test Administrative Unit"
+}
+
+# tenant-wide assignment
+resource "microsoft365wp_unified_role_assignment" "tenant" {
+  principal_id       = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+  role_definition_id = data.microsoft365wp_unified_role_definition.helpdesk_administrator.id
+}
+
+# assignment scoped to an administrative unit
+resource "microsoft365wp_unified_role_assignment" "administrative_unit" {
+  principal_id       = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+  role_definition_id = data.microsoft365wp_unified_role_definition.helpdesk_administrator.id
+  directory_scope_id = "/administrativeUnits/${microsoft365wp_administrative_unit.test.id}"
+}
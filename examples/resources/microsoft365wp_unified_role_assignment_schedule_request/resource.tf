@@ -0,0 +1,39 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+
+data "microsoft365wp_unified_role_definition" "groups_administrator" {
+  display_name = "Groups Administrator"
+}
+
+resource "microsoft365wp_administrative_unit" "test" {
+  display_name = "TF Test Administrative Unit"
+}
+
+# active until the end of the year, scoped to an administrative unit
+resource "microsoft365wp_unified_role_assignment_schedule_request" "test" {
+  principal_id       = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+  role_definition_id = data.microsoft365wp_unified_role_definition.groups_administrator.id
+  directory_scope_id = "/administrativeUnits/${microsoft365wp_administrative_unit.test.id}"
+  justification      = "Group cleanup project"
+  schedule_info = {
+    start_date_time = "2025-01-01T00:00:00Z"
+    expiration = {
+      type          = "afterDateTime"
+      end_date_time = "2025-12-31T23:59:59Z"
+    }
+  }
+}
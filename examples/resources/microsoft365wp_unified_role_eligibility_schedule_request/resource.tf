@@ -0,0 +1,49 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+
+data "microsoft365wp_unified_role_definition" "user_administrator" {
+  display_name = "User Administrator"
+}
+
+resource "microsoft365wp_administrative_unit" "test" {
+  display_name = "TF Test Administrative Unit"
+}
+
+# permanently eligible for the whole tenant
+resource "microsoft365wp_unified_role_eligibility_schedule_request" "permanent" {
+  principal_id       = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+  role_definition_id = data.microsoft365wp_unified_role_definition.user_administrator.id
+  justification      = "Permanently eligible for user administration"
+}
+
+# eligible for 180 days, scoped to an administrative unit
+resource "microsoft365wp_unified_role_eligibility_schedule_request" "administrative_unit" {
+  principal_id       = "0a5a1f4e-2a7c-4b7d-9a47-6e8f1f3b2c1d"
+  role_definition_id = data.microsoft365wp_unified_role_definition.user_administrator.id
+  directory_scope_id = "/administrativeUnits/${microsoft365wp_administrative_unit.test.id}"
+  justification      = "Project staff onboarding"
+  schedule_info = {
+    expiration = {
+      type     = "afterDuration"
+      duration = "P180D"
+    }
+  }
+  ticket_info = {
+    ticket_number = "CHG0012345"
+    ticket_system = "ServiceNow"
+  }
+}
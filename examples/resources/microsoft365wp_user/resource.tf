@@ -0,0 +1,41 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+variable "initial_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "microsoft365wp_user" "test" {
+  account_enabled     = true
+  display_name        = "TF Test User"
+  given_name          = "TF Test"
+  surname             = "User"
+  mail_nickname       = "tf-test-user"
+  user_principal_name = "tf-test-user@contoso.onmicrosoft.com"
+  usage_location      = "CH"
+  password_profile = {
+    force_change_password_next_sign_in = true
+    password_wo                        = var.initial_password
+  }
+  # increment to reset the password (and to apply changes of password_profile)
+  password_wo_version = 1
+}
+
+resource "microsoft365wp_user_assigned_license" "test" {
+  user_id = microsoft365wp_user.test.id
+  sku_id  = "05e9a617-0261-4cee-bb44-138d3ef5d965"
+}
@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    microsoft365wp = {
+      source = "terraprovider/microsoft365wp"
+    }
+  }
+}
+
+/*
+.env
+export ARM_TENANT_ID='...'
+export ARM_CLIENT_ID='...'
+export ARM_CLIENT_SECRET='...'
+*/
+
+
+resource "microsoft365wp_user_assigned_license" "test1" {
+  user_id = "91ae5a52-67f6-4265-bbe5-b62268944675"
+
+  sku_id = "00ed1723-1992-4384-b7ce-1c3bf01eedc7"
+}
+
+resource "microsoft365wp_user_assigned_license" "test2" {
+  user_id = "91ae5a52-67f6-4265-bbe5-b62268944675"
+
+  sku_id = "05e9a617-0261-4cee-bb44-138d3ef5d965"
+}
@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"flag"
+	"io"
 	"log"
+	"os"
+	"strings"
 	"terraform-provider-microsoft365wp/workplace"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -14,6 +17,11 @@ import (
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate --provider-name microsoft365wp
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		export(os.Args[2:])
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -27,3 +35,31 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// export runs the `export` subcommand writing the Terraform configuration of existing entities (see workplace.Export).
+func export(args []string) {
+	var resourceTypes, out string
+
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.StringVar(&resourceTypes, "resource-types", "", "comma separated list of resource types to export (all exportable ones if empty)")
+	flags.StringVar(&out, "out", "", "file to write the configuration to (stdout if empty)")
+	flags.Parse(args)
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		file, err := os.Create(out)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		defer file.Close()
+		w = file
+	}
+
+	var types []string
+	if resourceTypes != "" {
+		types = strings.Split(resourceTypes, ",")
+	}
+	if err := workplace.Export(context.Background(), w, types); err != nil {
+		log.Fatal(err.Error())
+	}
+}
@@ -0,0 +1,122 @@
+package workplace
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/services"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestActions(t *testing.T) {
+	ctx := t.Context()
+	p := &workplaceProvider{}
+
+	actions := map[string]*generic.GenericAction{}
+	for _, f := range p.Actions(ctx) {
+		a := f().(*generic.GenericAction)
+		metadataResp := action.MetadataResponse{}
+		a.Metadata(ctx, action.MetadataRequest{ProviderTypeName: "microsoft365wp"}, &metadataResp)
+		actions[metadataResp.TypeName] = a
+
+		schemaResp := action.SchemaResponse{}
+		a.Schema(ctx, action.SchemaRequest{}, &schemaResp)
+		if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+			t.Errorf("%s: invalid action schema: %s", metadataResp.TypeName, diagsError(diags))
+		}
+	}
+
+	s := generictest.Graph()
+	s.Reset()
+	es := s.AddEntitySet("/deviceManagement/managedDevices")
+	var wipeBody map[string]any
+	es.Actions = map[string]graphmock.ActionFunc{
+		"wipe": func(_ *graphmock.EntitySet, _ string, _ *http.Request, body map[string]any) (int, any) {
+			wipeBody = body
+			return http.StatusNoContent, nil
+		},
+	}
+	id := es.Put(map[string]any{"deviceName": "Device 1"})
+
+	a := actions["microsoft365wp_managed_device_wipe"]
+	a.Configure(ctx, action.ConfigureRequest{ProviderData: generictest.Client()}, &action.ConfigureResponse{})
+
+	invoke := func(id string) action.InvokeResponse {
+		return invokeAction(t, a, map[string]tftypes.Value{
+			"id":             tftypes.NewValue(tftypes.String, id),
+			"keep_user_data": tftypes.NewValue(tftypes.Bool, true),
+		})
+	}
+
+	if resp := invoke(id); resp.Diagnostics.HasError() {
+		t.Fatalf("Invoke: %s", diagsError(resp.Diagnostics))
+	}
+	got, _ := json.Marshal(wipeBody)
+	if string(got) != `{"keepUserData":true}` {
+		t.Errorf("unexpected request body %s", got)
+	}
+
+	if resp := invoke("unknown"); !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error when invoking the action on a missing entity")
+	}
+}
+
+func TestIdentityGovernanceWorkflowActivateAction(t *testing.T) {
+	ctx := t.Context()
+
+	s := generictest.Graph()
+	s.Reset()
+	es := s.AddEntitySet("/identityGovernance/lifecycleWorkflows/workflows")
+	var activateBody map[string]any
+	es.Actions = map[string]graphmock.ActionFunc{
+		"microsoft.graph.identityGovernance.activate": func(_ *graphmock.EntitySet, _ string, _ *http.Request, body map[string]any) (int, any) {
+			activateBody = body
+			return http.StatusNoContent, nil
+		},
+	}
+	id := es.Put(map[string]any{"displayName": "Workflow 1"})
+
+	a := &services.IdentityGovernanceWorkflowActivateAction
+	a.Configure(ctx, action.ConfigureRequest{ProviderData: generictest.Client()}, &action.ConfigureResponse{})
+
+	subjectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String}}
+	resp := invokeAction(t, a, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, id),
+		"subjects": tftypes.NewValue(tftypes.Set{ElementType: subjectType}, []tftypes.Value{
+			tftypes.NewValue(subjectType, map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.String, "user1")}),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Invoke: %s", diagsError(resp.Diagnostics))
+	}
+	got, _ := json.Marshal(activateBody)
+	if string(got) != `{"subjects":[{"id":"user1"}]}` {
+		t.Errorf("unexpected request body %s", got)
+	}
+}
+
+// invokeAction invokes the action with the given attribute values (all others are null).
+func invokeAction(t *testing.T, a *generic.GenericAction, attributeValues map[string]tftypes.Value) action.InvokeResponse {
+	ctx := t.Context()
+	schemaResp := action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value)
+	for name, typ := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, value := range attributeValues {
+		values[name] = value
+	}
+	req := action.InvokeRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}}
+	resp := action.InvokeResponse{}
+	a.Invoke(ctx, req, &resp)
+	return resp
+}
@@ -0,0 +1,185 @@
+package workplace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/oauth2"
+)
+
+// Ensure the implementation satisfies the desired interfaces.
+var _ ephemeral.EphemeralResourceWithConfigure = &AccessTokenEphemeralResource{}
+
+// accessTokenData is passed from the provider to AccessTokenEphemeralResource. It allows to create authorizers for
+// arbitrary APIs using the credentials configured for the provider.
+type accessTokenData struct {
+	// NewAuthorizer is nil if no credentials are available (e.g. when replaying a cassette).
+	NewAuthorizer func(ctx context.Context, api environments.Api) (auth.Authorizer, error)
+
+	// GraphScope is the default scope, i.e. the one of MS Graph in the configured environment.
+	GraphScope string
+
+	// AuxiliaryTenantIds are the tenants (besides the configured one) the authorizers return auxiliary tokens for.
+	AuxiliaryTenantIds []string
+}
+
+// AccessTokenEphemeralResource returns short-lived access tokens from the credentials configured for the provider
+// without storing them in Terraform state.
+type AccessTokenEphemeralResource struct {
+	data *accessTokenData
+}
+
+type accessTokenModel struct {
+	Scopes      types.List   `tfsdk:"scopes"`
+	TenantId    types.String `tfsdk:"tenant_id"`
+	AccessToken types.String `tfsdk:"access_token"`
+	TokenType   types.String `tfsdk:"token_type"`
+	ExpiresOn   types.String `tfsdk:"expires_on"`
+}
+
+func (r *AccessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *AccessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Short-lived access token obtained using the credentials configured for the provider, e.g. to " +
+			"call MS Graph or other APIs using the `http` data source or provisioners without authenticating separately. " +
+			"The token will never be persisted to Terraform state (requires Terraform 1.10 or later).",
+		Attributes: map[string]schema.Attribute{
+			"scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				MarkdownDescription: "Scopes to request the token for, e.g. `https://management.azure.com/.default`. As the " +
+					"provider authenticates as an application (or using the identity of Azure CLI), all scopes must be " +
+					"`.default` scopes of the same resource. <br/> The _provider_ default value is the `.default` scope of " +
+					"MS Graph in the configured environment.",
+			},
+			"tenant_id": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Tenant to request the token for, which must be one of the `auxiliary_tenant_ids` of the " +
+					"provider. <br/> The _provider_ default value is the tenant configured for the provider.",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The access token.",
+			},
+			"token_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Type of the access token, usually `Bearer`.",
+			},
+			"expires_on": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Expiry of the access token (RFC3339), if known.",
+			},
+		},
+	}
+}
+
+func (r *AccessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*accessTokenData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *accessTokenData, got: %T", req.ProviderData))
+		return
+	}
+	r.data = data
+}
+
+func (r *AccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model accessTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.data == nil || r.data.NewAuthorizer == nil {
+		resp.Diagnostics.AddError("No credentials available",
+			"The provider has not been configured with credentials (e.g. because a cassette is being replayed)")
+		return
+	}
+
+	scopes := []string{r.data.GraphScope}
+	if !model.Scopes.IsNull() {
+		resp.Diagnostics.Append(model.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resource, err := accessTokenResource(scopes)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid scopes", err.Error())
+		return
+	}
+
+	authorizer, err := r.data.NewAuthorizer(ctx, environments.NewApiEndpoint("AccessToken", resource, nil))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to build authorizer", err.Error())
+		return
+	}
+	token, err := accessTokenForTenant(ctx, authorizer, r.data.AuxiliaryTenantIds, model.TenantId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to obtain access token", err.Error())
+		return
+	}
+
+	model.Scopes, _ = types.ListValueFrom(ctx, types.StringType, scopes)
+	model.AccessToken = types.StringValue(token.AccessToken)
+	model.TokenType = types.StringValue(token.Type())
+	model.ExpiresOn = types.StringNull()
+	if !token.Expiry.IsZero() {
+		model.ExpiresOn = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, model)...)
+}
+
+// accessTokenForTenant returns the token of the authorizer, or the auxiliary token of the tenant if one is specified.
+func accessTokenForTenant(ctx context.Context, authorizer auth.Authorizer, auxiliaryTenantIds []string, tenantId string) (*oauth2.Token, error) {
+	if tenantId == "" {
+		return authorizer.Token(ctx, &http.Request{})
+	}
+	index := slices.Index(auxiliaryTenantIds, tenantId)
+	if index < 0 {
+		return nil, fmt.Errorf("tenant %s is not one of the auxiliary_tenant_ids configured for the provider", tenantId)
+	}
+	tokens, err := authorizer.AuxiliaryTokens(ctx, &http.Request{})
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(tokens) {
+		return nil, fmt.Errorf("no auxiliary token has been returned for tenant %s", tenantId)
+	}
+	return tokens[index], nil
+}
+
+// accessTokenResource returns the resource of the scopes, which must all be `.default` scopes of the same resource
+// (as this is the only kind of scope supported by the client credentials flow).
+func accessTokenResource(scopes []string) (string, error) {
+	if len(scopes) == 0 {
+		return "", fmt.Errorf("at least one scope must be specified")
+	}
+	var result string
+	for _, scope := range scopes {
+		resource, ok := strings.CutSuffix(scope, "/.default")
+		if !ok || resource == "" {
+			return "", fmt.Errorf("scope %q is not a .default scope (like https://graph.microsoft.com/.default)", scope)
+		}
+		if result != "" && resource != result {
+			return "", fmt.Errorf("scopes must all refer to the same resource, got %q and %q", result, resource)
+		}
+		result = resource
+	}
+	return result, nil
+}
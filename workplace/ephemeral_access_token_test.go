@@ -0,0 +1,105 @@
+package workplace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/oauth2"
+)
+
+type fakeAuthorizer struct {
+	scope        string
+	auxTenantIds []string
+}
+
+func (a *fakeAuthorizer) Token(context.Context, *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{AccessToken: "token for " + a.scope, TokenType: "Bearer", Expiry: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)}, nil
+}
+
+func (a *fakeAuthorizer) AuxiliaryTokens(context.Context, *http.Request) ([]*oauth2.Token, error) {
+	tokens := []*oauth2.Token{}
+	for _, tenantId := range a.auxTenantIds {
+		tokens = append(tokens, &oauth2.Token{AccessToken: "token for " + a.scope + " in " + tenantId, TokenType: "Bearer",
+			Expiry: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)})
+	}
+	return tokens, nil
+}
+
+func TestAccessTokenEphemeralResource(t *testing.T) {
+	ctx := context.Background()
+	r := &AccessTokenEphemeralResource{data: &accessTokenData{
+		NewAuthorizer: func(_ context.Context, api environments.Api) (auth.Authorizer, error) {
+			scope, err := environments.Scope(api)
+			if err != nil {
+				return nil, err
+			}
+			return &fakeAuthorizer{scope: *scope, auxTenantIds: []string{"tenant1", "tenant2"}}, nil
+		},
+		GraphScope:         "https://graph.microsoft.com/.default",
+		AuxiliaryTenantIds: []string{"tenant1", "tenant2"},
+	}}
+	var schemaResp ephemeral.SchemaResponse
+	r.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	listType := objectType.AttributeTypes["scopes"]
+
+	tests := []struct {
+		name     string
+		scopes   tftypes.Value
+		tenantId string
+		want     string
+		wantErr  bool
+	}{
+		{"default", tftypes.NewValue(listType, nil), "", "token for https://graph.microsoft.com/.default", false},
+		{"custom", tftypes.NewValue(listType, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "https://management.azure.com/.default"),
+		}), "", "token for https://management.azure.com/.default", false},
+		{"not default", tftypes.NewValue(listType, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "User.Read"),
+		}), "", "", true},
+		{"different resources", tftypes.NewValue(listType, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "https://graph.microsoft.com/.default"),
+			tftypes.NewValue(tftypes.String, "https://management.azure.com/.default"),
+		}), "", "", true},
+		{"auxiliary tenant", tftypes.NewValue(listType, nil), "tenant2", "token for https://graph.microsoft.com/.default in tenant2", false},
+		{"unknown tenant", tftypes.NewValue(listType, nil), "tenant3", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"scopes":       tt.scopes,
+				"tenant_id":    tftypes.NewValue(tftypes.String, tt.tenantId),
+				"access_token": tftypes.NewValue(tftypes.String, nil),
+				"token_type":   tftypes.NewValue(tftypes.String, nil),
+				"expires_on":   tftypes.NewValue(tftypes.String, nil),
+			})}
+			resp := ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: config.Raw}}
+			r.Open(ctx, ephemeral.OpenRequest{Config: config}, &resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var model accessTokenModel
+			resp.Diagnostics.Append(resp.Result.Get(ctx, &model)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if got := model.AccessToken.ValueString(); got != tt.want {
+				t.Errorf("access_token = %q, want %q", got, tt.want)
+			}
+			if got := model.ExpiresOn.ValueString(); got != "2030-01-02T03:04:05Z" {
+				t.Errorf("expires_on = %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package workplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+
+	"terraform-provider-microsoft365wp/workplace/external/strcase"
+	"terraform-provider-microsoft365wp/workplace/generic"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Export writes the Terraform configuration of all existing entities to w, i.e. a resource block along with a matching
+// import block for each entity of all exportable resource types (or only of the given resource types, e.g.
+// `microsoft365wp_device_configuration`). The provider gets configured from the environment (e.g. ARM_TENANT_ID) only.
+func Export(ctx context.Context, w io.Writer, resourceTypes []string) error {
+
+	p := &workplaceProvider{}
+	providerData, err := configureFromEnvironment(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	resources := make([]*generic.GenericResource, 0)
+	for _, f := range p.Resources(ctx) {
+		if r, ok := f().(*generic.GenericResource); ok {
+			resources = append(resources, r)
+		}
+	}
+
+	return exportResources(ctx, w, providerData, resources, resourceTypes)
+}
+
+// configureFromEnvironment configures the provider as if it had been declared without any attributes and returns the
+// data passed to resources.
+func configureFromEnvironment(ctx context.Context, p provider.Provider) (any, error) {
+
+	schemaResp := provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return nil, diagsError(schemaResp.Diagnostics)
+	}
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	nullValues := make(map[string]tftypes.Value)
+	for name, typ := range objectType.AttributeTypes {
+		nullValues[name] = tftypes.NewValue(typ, nil)
+	}
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nullValues)},
+	}
+	resp := provider.ConfigureResponse{}
+	p.Configure(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		return nil, diagsError(resp.Diagnostics)
+	}
+
+	return resp.ResourceData, nil
+}
+
+// exportResources exports the entities of the resources (or only of the ones with the given types). Resources that
+// fail to be exported (e.g. for missing permissions) get logged and skipped.
+func exportResources(ctx context.Context, w io.Writer, providerData any, resources []*generic.GenericResource, resourceTypes []string) error {
+
+	typeNames := make([]string, 0, len(resources))
+	for _, r := range resources {
+		r.Configure(ctx, resource.ConfigureRequest{ProviderData: providerData}, &resource.ConfigureResponse{})
+		metadataResp := resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "microsoft365wp"}, &metadataResp)
+		typeNames = append(typeNames, metadataResp.TypeName)
+	}
+	for _, t := range resourceTypes {
+		i := slices.Index(typeNames, t)
+		if i < 0 {
+			return fmt.Errorf("unknown resource type %q", t)
+		}
+		if !resources[i].IsExportable() {
+			return fmt.Errorf("resource type %q cannot be exported as its entities cannot be listed", t)
+		}
+	}
+
+	var failedTypeNames []string
+	for i, r := range resources {
+		typeName := typeNames[i]
+		if len(resourceTypes) > 0 && !slices.Contains(resourceTypes, typeName) || !r.IsExportable() {
+			continue
+		}
+
+		diags := diag.Diagnostics{}
+		entities := r.ExportEntities(ctx, &diags)
+		usedNames := make(map[string]bool)
+		var b strings.Builder
+		for _, entity := range entities {
+			name := exportResourceName(ctx, entity, usedNames)
+			b.WriteString(generic.ExportHcl(ctx, &diags, typeName, name, entity) + "\n")
+			if diags.HasError() {
+				break
+			}
+		}
+		if diags.HasError() {
+			log.Printf("[ERROR] Unable to export %s: %s", typeName, diagsError(diags))
+			failedTypeNames = append(failedTypeNames, typeName)
+			continue
+		}
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+
+	if len(failedTypeNames) > 0 {
+		return fmt.Errorf("unable to export resource types: %s", strings.Join(failedTypeNames, ", "))
+	}
+	return nil
+}
+
+var invalidResourceNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exportResourceName derives a unique resource name from the display name (or name) of the entity or from its id.
+func exportResourceName(ctx context.Context, entity generic.ExportedEntity, usedNames map[string]bool) string {
+
+	name := ""
+	for _, attributeName := range []string{"display_name", "name"} {
+		if _, ok := entity.State.Schema.GetAttributes()[attributeName]; !ok {
+			continue
+		}
+		var value types.String
+		if diags := entity.State.GetAttribute(ctx, path.Root(attributeName), &value); !diags.HasError() && value.ValueString() != "" {
+			name = value.ValueString()
+			break
+		}
+	}
+	if name == "" {
+		name = entity.ImportId
+	}
+
+	name = strings.Trim(invalidResourceNameChars.ReplaceAllString(strcase.ToSnake(name), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+
+	uniqueName := name
+	for i := 2; usedNames[uniqueName]; i++ {
+		uniqueName = fmt.Sprintf("%s_%d", name, i)
+	}
+	usedNames[uniqueName] = true
+	return uniqueName
+}
+
+// diagsError converts the errors of the diagnostics into a single error.
+func diagsError(diags diag.Diagnostics) error {
+	errs := make([]error, 0)
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
+}
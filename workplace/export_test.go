@@ -0,0 +1,113 @@
+package workplace
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/services"
+)
+
+func TestExportResources(t *testing.T) {
+	s := generictest.Graph()
+	s.Reset()
+	es := s.AddEntitySet("/deviceManagement/deviceManagementScripts")
+	scriptContent := base64.StdEncoding.EncodeToString([]byte("Write-Host \"${env:USERNAME}\"\n"))
+	id1 := es.Put(map[string]any{
+		"displayName":           "Test Script",
+		"description":           "",
+		"fileName":              "test.ps1",
+		"scriptContent":         scriptContent,
+		"runAsAccount":          "system",
+		"enforceSignatureCheck": false,
+		"runAs32Bit":            false,
+		"roleScopeTagIds":       []any{"0"},
+		"createdDateTime":       "2024-01-01T00:00:00Z",
+	})
+	es.SetNavigation(id1, "assignments", []any{
+		map[string]any{"target": map[string]any{
+			"@odata.type": "#microsoft.graph.groupAssignmentTarget",
+			"groupId":     "a3f4e0b6-3f47-4b5e-9c4d-1c0e5b6b8f11",
+		}},
+	})
+	id2 := es.Put(map[string]any{
+		"displayName":     "Test Script",
+		"fileName":        "other.ps1",
+		"scriptContent":   scriptContent,
+		"runAsAccount":    "user",
+		"roleScopeTagIds": []any{"0", "1"},
+	})
+	es.SetNavigation(id2, "assignments", []any{})
+
+	var b strings.Builder
+	err := exportResources(t.Context(), &b, generictest.Client(),
+		[]*generic.GenericResource{&services.DeviceManagementScriptResource, &services.AuthorizationPolicyResource},
+		[]string{"microsoft365wp_device_management_script"})
+	if err != nil {
+		t.Fatalf("exportResources: %s", err)
+	}
+
+	want := `import {
+  to = microsoft365wp_device_management_script.test_script
+  id = "` + id1 + `"
+}
+
+resource "microsoft365wp_device_management_script" "test_script" {
+  assignments = [
+    {
+      target = {
+        group = {
+          group_id = "a3f4e0b6-3f47-4b5e-9c4d-1c0e5b6b8f11"
+        }
+      }
+    },
+  ]
+  display_name = "Test Script"
+  file_name = "test.ps1"
+  run_as_account = "system"
+  script_content = "Write-Host \"$${env:USERNAME}\"\n"
+}
+
+import {
+  to = microsoft365wp_device_management_script.test_script_2
+  id = "` + id2 + `"
+}
+
+resource "microsoft365wp_device_management_script" "test_script_2" {
+  display_name = "Test Script"
+  file_name = "other.ps1"
+  role_scope_tag_ids = ["0", "1"]
+  script_content = "Write-Host \"$${env:USERNAME}\"\n"
+}
+
+`
+	if got := b.String(); got != want {
+		t.Errorf("exportResources returned:\n%s\nwant:\n%s", got, want)
+	}
+
+	err = exportResources(t.Context(), &b, generictest.Client(),
+		[]*generic.GenericResource{&services.AuthorizationPolicyResource}, []string{"microsoft365wp_authorization_policy"})
+	if err == nil || !strings.Contains(err.Error(), "cannot be exported") {
+		t.Errorf("exporting a singleton should fail, got error %v", err)
+	}
+}
+
+func TestConfigureFromEnvironment(t *testing.T) {
+	cassettePath := filepath.Join(t.TempDir(), "cassette.jsonl")
+	if err := os.WriteFile(cassettePath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ARM_REPLAY_PATH", cassettePath)
+
+	providerData, err := configureFromEnvironment(t.Context(), New())
+	if err != nil {
+		t.Fatalf("configureFromEnvironment: %s", err)
+	}
+	if _, ok := providerData.(*generic.ProviderData); !ok {
+		t.Errorf("expected *generic.ProviderData, got %T", providerData)
+	}
+}
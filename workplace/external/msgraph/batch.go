@@ -0,0 +1,582 @@
+package msgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+const (
+	// BatchMaxRequests is the maximum number of requests MS Graph accepts within a single JSON batch.
+	BatchMaxRequests = 20
+
+	// BatchDefaultWindow is the default time to wait for further requests before sending a (not yet full) batch.
+	BatchDefaultWindow = 50 * time.Millisecond
+
+	// BatchDefaultMaxThrottleRetries is the default number of times throttled batch items are retried.
+	BatchDefaultMaxThrottleRetries = 5
+)
+
+// BatchRequest is a single request to be sent as part of a JSON batch.
+type BatchRequest struct {
+	// Id identifies the request within the slice passed to Client.Batch, it will be assigned automatically if empty.
+	Id               string
+	Method           string
+	Uri              Uri
+	OData            odata.Query
+	Headers          http.Header
+	Body             []byte
+	DependsOn        []string
+	ValidStatusCodes []int
+}
+
+// GetConsistencyFailureFunc returns nil as batch items do not support eventual consistency retries.
+func (r BatchRequest) GetConsistencyFailureFunc() ConsistencyFailureFunc {
+	return nil
+}
+
+// GetContentType returns the content type for the request, currently only application/json is supported
+func (r BatchRequest) GetContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+// GetOData returns the OData request metadata
+func (r BatchRequest) GetOData() odata.Query {
+	return r.OData
+}
+
+// GetValidStatusCodes returns a []int of status codes considered valid for the batch item.
+func (r BatchRequest) GetValidStatusCodes() []int {
+	return r.ValidStatusCodes
+}
+
+// GetValidStatusFunc returns nil as batch items only support ValidStatusCodes.
+func (r BatchRequest) GetValidStatusFunc() ValidStatusFunc {
+	return nil
+}
+
+// BatchResponse is the response to a single BatchRequest.
+type BatchResponse struct {
+	Id      string
+	Status  int
+	Headers http.Header
+	Body    []byte
+
+	request BatchRequest
+}
+
+// Result returns the response in the same form as Get, Post etc. do (including the check for valid status codes).
+func (r *BatchResponse) Result() (*http.Response, int, *odata.OData, error) {
+	resp := &http.Response{
+		StatusCode: r.Status,
+		Status:     fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		Header:     r.Headers,
+		Body:       io.NopCloser(bytes.NewBuffer(r.Body)),
+	}
+	if resp.Header == nil {
+		resp.Header = http.Header{}
+	}
+	return checkResponse(resp, r.request)
+}
+
+// Batcher coalesces concurrent requests into JSON batches of up to BatchMaxRequests items.
+type Batcher struct {
+	// Window is the time to wait for further requests before sending a batch that is not yet full.
+	Window time.Duration
+
+	// MaxThrottleRetries is the number of times throttled (or otherwise temporarily failed) items are retried.
+	MaxThrottleRetries int
+
+	mu     sync.Mutex
+	queues map[string]*batchQueue
+}
+
+type batchQueue struct {
+	client Client
+	groups []*batchGroup
+	count  int
+	timer  *time.Timer
+}
+
+type batchGroup struct {
+	ctx       context.Context
+	requests  []BatchRequest
+	responses []*BatchResponse
+	err       error
+	done      chan struct{}
+}
+
+// NewBatcher returns a new Batcher using the default window and retry settings.
+func NewBatcher() *Batcher {
+	return &Batcher{
+		Window:             BatchDefaultWindow,
+		MaxThrottleRetries: BatchDefaultMaxThrottleRetries,
+		queues:             make(map[string]*batchQueue),
+	}
+}
+
+// Batch sends the requests as part of one or more JSON batches and returns the responses in the same order.
+// Requests referencing other requests using DependsOn will always be sent within the same batch, so there must not be
+// more than BatchMaxRequests of them. If the client has no Batcher, the requests will be sent individually instead.
+// An error will only be returned if the requests could not be sent at all, the status of each single request must be
+// checked by the caller (e.g. using BatchResponse.Result).
+func (c Client) Batch(ctx context.Context, requests []BatchRequest) ([]*BatchResponse, error) {
+	c = c.withContextApiVersion(ctx)
+	requests = append([]BatchRequest{}, requests...)
+	hasDependencies := false
+	for i := range requests {
+		if requests[i].Id == "" {
+			requests[i].Id = strconv.Itoa(i + 1)
+		}
+		if len(requests[i].ValidStatusCodes) == 0 {
+			requests[i].ValidStatusCodes = []int{http.StatusOK}
+		}
+		if len(requests[i].DependsOn) > 0 {
+			hasDependencies = true
+		}
+	}
+
+	if c.Batcher == nil {
+		return c.batchIndividually(ctx, requests)
+	}
+
+	if !hasDependencies {
+		// independent requests can be split up freely
+		responses := make([]*BatchResponse, 0, len(requests))
+		for start := 0; start < len(requests); start += BatchMaxRequests {
+			end := min(start+BatchMaxRequests, len(requests))
+			chunkResponses, err := c.Batcher.do(ctx, c, requests[start:end])
+			if err != nil {
+				return nil, err
+			}
+			responses = append(responses, chunkResponses...)
+		}
+		return responses, nil
+	}
+
+	if len(requests) > BatchMaxRequests {
+		return nil, fmt.Errorf("cannot send more than %d dependent requests within a single batch", BatchMaxRequests)
+	}
+	return c.Batcher.do(ctx, c, requests)
+}
+
+// batchIndividually is the fallback for clients without Batcher and sends all requests one after another.
+func (c Client) batchIndividually(ctx context.Context, requests []BatchRequest) ([]*BatchResponse, error) {
+	responses := make([]*BatchResponse, len(requests))
+	statusById := make(map[string]int)
+	for i, r := range requests {
+		failedDependency := false
+		for _, d := range r.DependsOn {
+			if s, ok := statusById[d]; !ok || s >= http.StatusBadRequest {
+				failedDependency = true
+			}
+		}
+		if failedDependency {
+			responses[i] = &BatchResponse{Id: r.Id, Status: http.StatusFailedDependency, request: r}
+			statusById[r.Id] = http.StatusFailedDependency
+			continue
+		}
+
+		uri := r.Uri
+		uri.Params = r.OData.AppendValues(uri.Params)
+		url, err := c.buildUri(uri)
+		if err != nil {
+			return nil, fmt.Errorf("unable to make request: %v", err)
+		}
+		req, err := http.NewRequestWithContext(ctx, r.Method, url, bytes.NewBuffer(r.Body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range r.Headers {
+			req.Header[k] = v
+		}
+
+		// accept any status here as the caller will check the status of each response
+		input := r
+		input.ValidStatusCodes = nil
+		resp, err := c.performRequestNoCheck(req, input)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading response body: %v", err)
+		}
+
+		responses[i] = &BatchResponse{Id: r.Id, Status: resp.StatusCode, Headers: resp.Header, Body: body, request: r}
+		statusById[r.Id] = resp.StatusCode
+	}
+
+	return c.batchCompletePaging(ctx, responses)
+}
+
+// do enqueues the requests as a single group (i.e. they will be sent within the same batch) and waits for the
+// responses. Items failing temporarily (throttling etc.) will be retried.
+func (b *Batcher) do(ctx context.Context, c Client, requests []BatchRequest) ([]*BatchResponse, error) {
+	responses := make([]*BatchResponse, len(requests))
+	indexById := make(map[string]int, len(requests))
+	for i, r := range requests {
+		indexById[r.Id] = i
+	}
+
+	pending := requests
+	for attempt := 0; ; attempt++ {
+		group := &batchGroup{
+			ctx:      ctx,
+			requests: pending,
+			done:     make(chan struct{}),
+		}
+		b.enqueue(c, group)
+
+		select {
+		case <-group.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if group.err != nil {
+			return nil, group.err
+		}
+
+		var retryAfter time.Duration
+		retryIds := make(map[string]bool)
+		for i, resp := range group.responses {
+			responses[indexById[pending[i].Id]] = resp
+			if attempt < b.MaxThrottleRetries && batchStatusIsRetryable(resp.Status) {
+				retryIds[resp.Id] = true
+				retryAfter = max(retryAfter, batchRetryAfter(resp.Headers, attempt))
+			}
+		}
+		if len(retryIds) == 0 {
+			break
+		}
+
+		// dependent items fail with 424 if one of their dependencies failed, so retry them along with it
+		retry := make([]BatchRequest, 0)
+		for i, r := range pending {
+			dependsOnRetry := false
+			dependsOn := make([]string, 0)
+			for _, d := range r.DependsOn {
+				if retryIds[d] {
+					dependsOnRetry = true
+					dependsOn = append(dependsOn, d)
+				}
+			}
+			if retryIds[r.Id] || (dependsOnRetry && group.responses[i].Status == http.StatusFailedDependency) {
+				r.DependsOn = dependsOn // only keep dependencies that are part of the retry
+				retry = append(retry, r)
+			}
+		}
+		pending = retry
+
+		select {
+		case <-time.After(retryAfter):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return c.batchCompletePaging(ctx, responses)
+}
+
+func (b *Batcher) enqueue(c Client, group *batchGroup) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.queues == nil {
+		b.queues = make(map[string]*batchQueue)
+	}
+
+	// different endpoints or API versions need separate batches
+	key := c.Endpoint + "|" + string(c.ApiVersion)
+	q, ok := b.queues[key]
+	if !ok {
+		q = &batchQueue{client: c}
+		b.queues[key] = q
+	}
+
+	if q.count+len(group.requests) > BatchMaxRequests {
+		b.flushLocked(key)
+		q = &batchQueue{client: c}
+		b.queues[key] = q
+	}
+
+	q.groups = append(q.groups, group)
+	q.count += len(group.requests)
+
+	if q.count >= BatchMaxRequests || b.Window <= 0 {
+		b.flushLocked(key)
+	} else if q.timer == nil {
+		q.timer = time.AfterFunc(b.Window, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if b.queues[key] == q {
+				b.flushLocked(key)
+			}
+		})
+	}
+}
+
+// flushLocked removes the queue for key and sends its groups in the background. b.mu must be held.
+func (b *Batcher) flushLocked(key string) {
+	q, ok := b.queues[key]
+	if !ok {
+		return
+	}
+	delete(b.queues, key)
+	if q.timer != nil {
+		q.timer.Stop()
+	}
+	if len(q.groups) > 0 {
+		go q.client.sendBatch(q.groups)
+	}
+}
+
+type batchRequestItemJson struct {
+	Id        string            `json:"id"`
+	Method    string            `json:"method"`
+	Url       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Body      json.RawMessage   `json:"body,omitempty"`
+	DependsOn []string          `json:"dependsOn,omitempty"`
+}
+
+type batchResponseItemJson struct {
+	Id      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// sendBatch sends all requests of the groups within one JSON batch and distributes the responses.
+func (c Client) sendBatch(groups []*batchGroup) {
+	finish := func(err error) {
+		for _, g := range groups {
+			if err != nil && g.err == nil {
+				g.err = err
+			}
+			close(g.done)
+		}
+	}
+
+	// ids must be unique within the whole batch, so they get replaced by a running number
+	type itemRef struct {
+		group *batchGroup
+		index int
+	}
+	refs := make(map[string]itemRef)
+	items := make([]batchRequestItemJson, 0, BatchMaxRequests)
+	for _, g := range groups {
+		g.responses = make([]*BatchResponse, len(g.requests))
+		batchIdById := make(map[string]string, len(g.requests))
+		for i, r := range g.requests {
+			batchId := strconv.Itoa(len(items) + 1)
+			batchIdById[r.Id] = batchId
+			refs[batchId] = itemRef{group: g, index: i}
+
+			url, err := c.buildBatchItemUrl(r.Uri, r.OData)
+			if err != nil {
+				g.err = fmt.Errorf("unable to make batch request: %v", err)
+				continue
+			}
+
+			headers := map[string]string{}
+			for k := range r.OData.Headers() {
+				headers[k] = r.OData.Headers().Get(k)
+			}
+			for k := range r.Headers {
+				headers[k] = r.Headers.Get(k)
+			}
+			item := batchRequestItemJson{
+				Id:      batchId,
+				Method:  r.Method,
+				Url:     url,
+				Headers: headers,
+			}
+			if len(r.Body) > 0 {
+				item.Body = r.Body
+				if _, ok := headers["Content-Type"]; !ok {
+					headers["Content-Type"] = r.GetContentType()
+				}
+			}
+			for _, d := range r.DependsOn {
+				item.DependsOn = append(item.DependsOn, batchIdById[d])
+			}
+			items = append(items, item)
+		}
+	}
+
+	body, err := json.Marshal(map[string]any{"requests": items})
+	if err != nil {
+		finish(fmt.Errorf("marshaling batch request: %v", err))
+		return
+	}
+
+	// the batch is shared by several callers, so do not let the first one cancel it for everyone
+	ctx := context.WithoutCancel(groups[0].ctx)
+	if c.RateLimiter != nil {
+		for _, item := range items {
+			if err := c.RateLimiter.Wait(ctx, Workload(item.Url)); err != nil {
+				finish(err)
+				return
+			}
+		}
+	}
+	resp, _, _, err := c.Post(ctx, PostHttpRequestInput{
+		Uri:              Uri{Entity: "/$batch"},
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusOK},
+	})
+	if err != nil {
+		finish(fmt.Errorf("sending batch request: %v", err))
+		return
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		finish(fmt.Errorf("reading batch response: %v", err))
+		return
+	}
+	var respJson struct {
+		Responses []batchResponseItemJson `json:"responses"`
+	}
+	if err := json.Unmarshal(respBody, &respJson); err != nil {
+		finish(fmt.Errorf("unmarshaling batch response: %v", err))
+		return
+	}
+
+	for _, item := range respJson.Responses {
+		ref, ok := refs[item.Id]
+		if !ok {
+			continue
+		}
+		r := ref.group.requests[ref.index]
+		if c.RateLimiter != nil {
+			c.RateLimiter.RecordStatus(ctx, Workload(r.Uri.Entity), item.Status)
+		}
+		headers := http.Header{}
+		for k, v := range item.Headers {
+			headers.Set(k, v)
+		}
+		ref.group.responses[ref.index] = &BatchResponse{
+			Id:      r.Id,
+			Status:  item.Status,
+			Headers: headers,
+			Body:    batchDecodeResponseBody(headers, item.Body),
+			request: r,
+		}
+	}
+
+	for _, g := range groups {
+		for i, r := range g.responses {
+			if r == nil && g.err == nil {
+				g.err = fmt.Errorf("no response received for batch item %q", g.requests[i].Id)
+			}
+		}
+	}
+	finish(nil)
+}
+
+// buildBatchItemUrl returns the URL for a batch item, which has to be relative to the API version.
+func (c Client) buildBatchItemUrl(uri Uri, query odata.Query) (string, error) {
+	uri.Params = query.AppendValues(uri.Params)
+	fullUrl, err := c.buildUri(uri)
+	if err != nil {
+		return "", err
+	}
+	base, err := c.buildUri(Uri{})
+	if err != nil {
+		return "", err
+	}
+	return "/" + strings.TrimLeft(strings.TrimPrefix(fullUrl, strings.TrimRight(base, "/")), "/"), nil
+}
+
+// batchCompletePaging follows any @odata.nextLink of successful collection responses and merges all pages.
+func (c Client) batchCompletePaging(ctx context.Context, responses []*BatchResponse) ([]*BatchResponse, error) {
+	for _, r := range responses {
+		if r == nil || r.request.Method != http.MethodGet || r.Status != http.StatusOK {
+			continue
+		}
+		var page odata.OData
+		if err := json.Unmarshal(r.Body, &page); err != nil || page.NextLink == nil {
+			continue
+		}
+		if _, ok := page.Value.([]any); !ok {
+			continue
+		}
+
+		nextResp, _, _, err := c.Get(ctx, GetHttpRequestInput{
+			OData:            r.request.OData,
+			ValidStatusCodes: []int{http.StatusOK},
+			rawUri:           string(*page.NextLink),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading next page of batch item %q: %v", r.Id, err)
+		}
+		nextBody, err := io.ReadAll(nextResp.Body)
+		nextResp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading next page of batch item %q: %v", r.Id, err)
+		}
+
+		var first, rest map[string]any
+		if err := json.Unmarshal(r.Body, &first); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(nextBody, &rest); err != nil {
+			return nil, err
+		}
+		firstValue, _ := first["value"].([]any)
+		restValue, _ := rest["value"].([]any)
+		rest["value"] = append(firstValue, restValue...)
+		merged, err := json.Marshal(rest)
+		if err != nil {
+			return nil, err
+		}
+		r.Body = merged
+	}
+	return responses, nil
+}
+
+// batchDecodeResponseBody returns the raw body of a batch item response. JSON bodies are embedded as is while all
+// other content is returned by MS Graph as base64 encoded string.
+func batchDecodeResponseBody(headers http.Header, body json.RawMessage) []byte {
+	if len(body) == 0 || string(body) == "null" {
+		return nil
+	}
+	if !strings.HasPrefix(strings.ToLower(headers.Get("Content-Type")), "application/json") {
+		var s string
+		if err := json.Unmarshal(body, &s); err == nil {
+			if decoded, err := base64.StdEncoding.DecodeString(s); err == nil {
+				return decoded
+			}
+			return []byte(s)
+		}
+	}
+	return body
+}
+
+func batchStatusIsRetryable(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// batchRetryAfter determines the delay before retrying a throttled item, preferring MS Graph's Retry-After value.
+func batchRetryAfter(headers http.Header, attempt int) time.Duration {
+	if s := headers.Get("Retry-After"); s != "" {
+		if seconds, err := strconv.Atoi(s); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return time.Duration(1<<attempt) * time.Second
+}
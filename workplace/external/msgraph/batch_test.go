@@ -0,0 +1,181 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testBatchServer struct {
+	batchCalls   atomic.Int32
+	itemCalls    atomic.Int32
+	throttleOnce sync.Map
+}
+
+func (s *testBatchServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/beta/$batch" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.batchCalls.Add(1)
+
+		var req struct {
+			Requests []batchRequestItemJson `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding batch request: %v", err)
+			return
+		}
+		if len(req.Requests) > BatchMaxRequests {
+			t.Errorf("batch contains %d requests", len(req.Requests))
+		}
+
+		statusById := map[string]int{}
+		responses := make([]map[string]any, 0)
+		for _, item := range req.Requests {
+			s.itemCalls.Add(1)
+			status := http.StatusOK
+			headers := map[string]string{"Content-Type": "application/json"}
+			body := any(map[string]any{"url": item.Url})
+			if _, throttled := s.throttleOnce.LoadOrStore(item.Url, true); !throttled && item.Url == "/throttled" {
+				status = http.StatusTooManyRequests
+				headers["Retry-After"] = "1"
+			}
+			for _, d := range item.DependsOn {
+				if statusById[d] != http.StatusOK {
+					status = http.StatusFailedDependency
+				}
+			}
+			statusById[item.Id] = status
+			responses = append(responses, map[string]any{"id": item.Id, "status": status, "headers": headers, "body": body})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"responses": responses})
+	}
+}
+
+func newTestBatchClient(t *testing.T, s *testBatchServer) Client {
+	server := httptest.NewServer(s.handler(t))
+	t.Cleanup(server.Close)
+
+	c := NewClient(VersionBeta)
+	c.Endpoint = server.URL
+	c.Batcher = NewBatcher()
+	return c
+}
+
+func TestBatchCoalescesConcurrentRequests(t *testing.T) {
+	s := &testBatchServer{}
+	c := newTestBatchClient(t, s)
+
+	const count = 5
+	var wg sync.WaitGroup
+	for i := range count {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses, err := c.Batch(context.Background(), []BatchRequest{
+				{Method: http.MethodGet, Uri: Uri{Entity: fmt.Sprintf("/items/%d", i)}},
+			})
+			if err != nil {
+				t.Errorf("Batch() returned error: %v", err)
+				return
+			}
+			var body map[string]string
+			if err := json.Unmarshal(responses[0].Body, &body); err != nil {
+				t.Errorf("unmarshaling response body: %v", err)
+			}
+			if want := fmt.Sprintf("/items/%d", i); body["url"] != want {
+				t.Errorf("got response for %q, want %q", body["url"], want)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := s.batchCalls.Load(); got != 1 {
+		t.Errorf("got %d batch calls, want 1", got)
+	}
+}
+
+func TestBatchSplitsIndependentRequests(t *testing.T) {
+	s := &testBatchServer{}
+	c := newTestBatchClient(t, s)
+
+	requests := make([]BatchRequest, BatchMaxRequests+1)
+	for i := range requests {
+		requests[i] = BatchRequest{Method: http.MethodGet, Uri: Uri{Entity: fmt.Sprintf("/items/%d", i)}}
+	}
+	responses, err := c.Batch(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("Batch() returned error: %v", err)
+	}
+	if len(responses) != len(requests) {
+		t.Fatalf("got %d responses, want %d", len(responses), len(requests))
+	}
+	if got := s.batchCalls.Load(); got != 2 {
+		t.Errorf("got %d batch calls, want 2", got)
+	}
+}
+
+func TestBatchRetriesThrottledItemsAndDependents(t *testing.T) {
+	s := &testBatchServer{}
+	c := newTestBatchClient(t, s)
+
+	start := time.Now()
+	responses, err := c.Batch(context.Background(), []BatchRequest{
+		{Id: "a", Method: http.MethodGet, Uri: Uri{Entity: "/throttled"}},
+		{Id: "b", Method: http.MethodGet, Uri: Uri{Entity: "/dependent"}, DependsOn: []string{"a"}},
+		{Id: "c", Method: http.MethodGet, Uri: Uri{Entity: "/independent"}},
+	})
+	if err != nil {
+		t.Fatalf("Batch() returned error: %v", err)
+	}
+
+	for _, r := range responses {
+		if _, status, _, err := r.Result(); err != nil || status != http.StatusOK {
+			t.Errorf("item %q: got status %d and error %v", r.Id, status, err)
+		}
+	}
+	if time.Since(start) < time.Second {
+		t.Errorf("Retry-After has not been honored")
+	}
+	// a, b and c within the first batch plus a and b within the retry
+	if got := s.itemCalls.Load(); got != 5 {
+		t.Errorf("got %d item calls, want 5", got)
+	}
+}
+
+func TestBatchWithoutBatcherSendsIndividually(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"path": %q}`, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(VersionBeta)
+	c.Endpoint = server.URL
+	responses, err := c.Batch(context.Background(), []BatchRequest{
+		{Method: http.MethodGet, Uri: Uri{Entity: "/one"}},
+		{Method: http.MethodGet, Uri: Uri{Entity: "/two"}},
+	})
+	if err != nil {
+		t.Fatalf("Batch() returned error: %v", err)
+	}
+	if len(responses) != 2 || calls.Load() != 2 {
+		t.Fatalf("got %d responses and %d calls, want 2 each", len(responses), calls.Load())
+	}
+	if _, status, _, err := responses[1].Result(); err != nil || status != http.StatusOK {
+		t.Errorf("got status %d and error %v", status, err)
+	}
+}
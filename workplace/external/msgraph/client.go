@@ -32,6 +32,28 @@ type RequestMiddleware func(*http.Request) (*http.Request, error)
 // ResponseMiddleware can manipulate or log a response before it is parsed and returned
 type ResponseMiddleware func(*http.Request, *http.Response) (*http.Response, error)
 
+type middlewareResponseKey struct{}
+
+// WithMiddlewareResponse can be used by a RequestMiddleware to answer a request itself, e.g. to replay a recorded
+// response. The returned request will not be sent, but the response middlewares will still be called.
+func WithMiddlewareResponse(req *http.Request, resp *http.Response) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), middlewareResponseKey{}, resp))
+}
+
+type apiVersionKey struct{}
+
+// WithApiVersion returns a context which makes all requests of a Client use the API version instead of the one of the
+// Client itself, e.g. for entities supporting a different API version than most others.
+func WithApiVersion(ctx context.Context, apiVersion ApiVersion) context.Context {
+	return context.WithValue(ctx, apiVersionKey{}, apiVersion)
+}
+
+// ApiVersionFromContext returns the API version set by WithApiVersion.
+func ApiVersionFromContext(ctx context.Context) (ApiVersion, bool) {
+	apiVersion, ok := ctx.Value(apiVersionKey{}).(ApiVersion)
+	return apiVersion, ok
+}
+
 // RetryOn404ConsistencyFailureFunc can be used to retry a request when a 404 response is received
 func RetryOn404ConsistencyFailureFunc(resp *http.Response, _ *odata.OData) bool {
 	return resp != nil && resp.StatusCode == http.StatusNotFound
@@ -74,12 +96,20 @@ type Client struct {
 	// ApiVersion is the Microsoft Graph API version to use.
 	ApiVersion ApiVersion
 
+	// PreferredApiVersion is the Microsoft Graph API version to use for entities supporting several ones (which then
+	// will be selected using WithApiVersion), ApiVersion is used for those if empty.
+	PreferredApiVersion ApiVersion
+
 	// UserAgent is the HTTP user agent string to send in requests.
 	UserAgent string
 
 	// Authorizer is anything that can provide an access token with which to authorize requests.
 	Authorizer auth.Authorizer
 
+	// UseAuxiliaryTokens sends the auxiliary tokens of the Authorizer (for the tenants it has been configured with) in
+	// the `x-ms-authorization-auxiliary` header of every request to allow for cross-tenant operations.
+	UseAuxiliaryTokens bool
+
 	// DisableRetries prevents the client from reattempting failed requests (which it does to work around eventual consistency issues).
 	// This does not impact handling of retries related to rate limiting, which are always performed.
 	DisableRetries bool
@@ -93,6 +123,12 @@ type Client struct {
 	// HttpClient is the underlying http.Client, which by default uses a retryable client
 	HttpClient      *http.Client
 	RetryableClient *retryablehttp.Client
+
+	// Batcher coalesces requests sent using Batch into JSON batches, requests will be sent individually if nil
+	Batcher *Batcher
+
+	// RateLimiter limits the requests on the client side, requests will not be limited if nil (see UseRateLimiter)
+	RateLimiter *RateLimiter
 }
 
 // NewClient returns a new Client configured with the specified API version and tenant ID.
@@ -115,6 +151,21 @@ func NewClient(apiVersion ApiVersion) Client {
 	}
 }
 
+// UseRateLimiter applies the RateLimiter to all requests sent by the client (and its copies). It must be called after
+// setting the Endpoint.
+func (c *Client) UseRateLimiter(l *RateLimiter) {
+	c.RateLimiter = l
+	c.RetryableClient.HTTPClient.Transport = l.Transport(c.Endpoint, c.RetryableClient.HTTPClient.Transport)
+}
+
+// withContextApiVersion returns a copy of the client using the API version of the context (see WithApiVersion).
+func (c Client) withContextApiVersion(ctx context.Context) Client {
+	if apiVersion, ok := ApiVersionFromContext(ctx); ok {
+		c.ApiVersion = apiVersion
+	}
+	return c
+}
+
 // buildUri is used by the package to build a complete URI string for API requests.
 func (c Client) buildUri(uri Uri) (string, error) {
 	newUrl, err := url.Parse(string(c.Endpoint))
@@ -131,7 +182,16 @@ func (c Client) buildUri(uri Uri) (string, error) {
 
 // performRequest is used by the package to send an HTTP request to the API.
 func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http.Response, int, *odata.OData, error) {
-	var status int
+	resp, err := c.performRequestNoCheck(req, input)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return checkResponse(resp, input)
+}
+
+// performRequestNoCheck sends an HTTP request to the API but leaves checking the response to the caller.
+func (c Client) performRequestNoCheck(req *http.Request, input HttpRequestInput) (*http.Response, error) {
 
 	query := input.GetOData()
 	req.Header = query.AppendHeaders(req.Header)
@@ -140,9 +200,23 @@ func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http
 	if c.Authorizer != nil {
 		token, err := c.Authorizer.Token(req.Context(), req)
 		if err != nil {
-			return nil, status, nil, err
+			return nil, err
 		}
 		token.SetAuthHeader(req)
+
+		if c.UseAuxiliaryTokens {
+			auxTokens, err := c.Authorizer.AuxiliaryTokens(req.Context(), req)
+			if err != nil {
+				return nil, err
+			}
+			auxTokenValues := make([]string, 0, len(auxTokens))
+			for _, auxToken := range auxTokens {
+				auxTokenValues = append(auxTokenValues, fmt.Sprintf("%s %s", auxToken.Type(), auxToken.AccessToken))
+			}
+			if len(auxTokenValues) > 0 {
+				req.Header.Set("X-Ms-Authorization-Auxiliary", strings.Join(auxTokenValues, ", "))
+			}
+		}
 	}
 
 	if c.UserAgent != "" {
@@ -157,7 +231,7 @@ func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http
 	if req.Body != nil {
 		reqBody, err = io.ReadAll(req.Body)
 		if err != nil {
-			return nil, status, nil, fmt.Errorf("reading request body: %v", err)
+			return nil, fmt.Errorf("reading request body: %v", err)
 		}
 	}
 
@@ -186,28 +260,40 @@ func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http
 		for _, m := range *c.RequestMiddlewares {
 			r, err := m(req)
 			if err != nil {
-				return nil, status, nil, err
+				return nil, err
 			}
 			req = r
 		}
 	}
 
-	resp, err = c.HttpClient.Do(req)
-	if err != nil {
-		return nil, status, nil, err
+	if r, ok := req.Context().Value(middlewareResponseKey{}).(*http.Response); ok {
+		resp = r
+		resp.Request = req
+	} else {
+		resp, err = c.HttpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if c.ResponseMiddlewares != nil {
 		for _, m := range *c.ResponseMiddlewares {
 			r, err := m(req, resp)
 			if err != nil {
-				return nil, status, nil, err
+				return nil, err
 			}
 			resp = r
 		}
 	}
 
-	o, err = odata.FromResponse(resp)
+	return resp, nil
+}
+
+// checkResponse parses the OData content of a response and tests whether its status is considered valid for the request.
+func checkResponse(resp *http.Response, input HttpRequestInput) (*http.Response, int, *odata.OData, error) {
+	var status int
+
+	o, err := odata.FromResponse(resp)
 	if err != nil {
 		return nil, status, o, err
 	}
@@ -257,6 +343,7 @@ func containsStatusCode(expected []int, actual int) bool {
 // DeleteHttpRequestInput configures a DELETE request.
 type DeleteHttpRequestInput struct {
 	ConsistencyFailureFunc ConsistencyFailureFunc
+	IfMatch                string
 	OData                  odata.Query
 	ValidStatusCodes       []int
 	ValidStatusFunc        ValidStatusFunc
@@ -290,6 +377,7 @@ func (i DeleteHttpRequestInput) GetValidStatusFunc() ValidStatusFunc {
 
 // Delete performs a DELETE request.
 func (c Client) Delete(ctx context.Context, input DeleteHttpRequestInput) (*http.Response, int, *odata.OData, error) {
+	c = c.withContextApiVersion(ctx)
 	var status int
 	url, err := c.buildUri(input.Uri)
 	if err != nil {
@@ -299,6 +387,9 @@ func (c Client) Delete(ctx context.Context, input DeleteHttpRequestInput) (*http
 	if err != nil {
 		return nil, status, nil, err
 	}
+	if input.IfMatch != "" {
+		req.Header.Set("If-Match", input.IfMatch)
+	}
 	resp, status, o, err := c.performRequest(req, input)
 	if err != nil {
 		return nil, status, o, err
@@ -344,6 +435,7 @@ func (i GetHttpRequestInput) GetValidStatusFunc() ValidStatusFunc {
 
 // Get performs a GET request.
 func (c Client) Get(ctx context.Context, input GetHttpRequestInput) (*http.Response, int, *odata.OData, error) {
+	c = c.withContextApiVersion(ctx)
 	var status int
 
 	// Check for a raw uri, else build one from the Uri field
@@ -438,6 +530,7 @@ func (c Client) Get(ctx context.Context, input GetHttpRequestInput) (*http.Respo
 type PatchHttpRequestInput struct {
 	ConsistencyFailureFunc ConsistencyFailureFunc
 	Body                   []byte
+	IfMatch                string
 	OData                  odata.Query
 	ValidStatusCodes       []int
 	ValidStatusFunc        ValidStatusFunc
@@ -471,6 +564,7 @@ func (i PatchHttpRequestInput) GetValidStatusFunc() ValidStatusFunc {
 
 // Patch performs a PATCH request.
 func (c Client) Patch(ctx context.Context, input PatchHttpRequestInput) (*http.Response, int, *odata.OData, error) {
+	c = c.withContextApiVersion(ctx)
 	var status int
 	url, err := c.buildUri(input.Uri)
 	if err != nil {
@@ -480,6 +574,9 @@ func (c Client) Patch(ctx context.Context, input PatchHttpRequestInput) (*http.R
 	if err != nil {
 		return nil, status, nil, err
 	}
+	if input.IfMatch != "" {
+		req.Header.Set("If-Match", input.IfMatch)
+	}
 	resp, status, o, err := c.performRequest(req, input)
 	if err != nil {
 		return nil, status, o, err
@@ -524,6 +621,7 @@ func (i PostHttpRequestInput) GetValidStatusFunc() ValidStatusFunc {
 
 // Post performs a POST request.
 func (c Client) Post(ctx context.Context, input PostHttpRequestInput) (*http.Response, int, *odata.OData, error) {
+	c = c.withContextApiVersion(ctx)
 	var status int
 	url, err := c.buildUri(input.Uri)
 	if err != nil {
@@ -545,6 +643,7 @@ type PutHttpRequestInput struct {
 	ConsistencyFailureFunc ConsistencyFailureFunc
 	ContentType            string
 	Body                   []byte
+	IfMatch                string
 	OData                  odata.Query
 	ValidStatusCodes       []int
 	ValidStatusFunc        ValidStatusFunc
@@ -581,6 +680,7 @@ func (i PutHttpRequestInput) GetValidStatusFunc() ValidStatusFunc {
 
 // Put performs a PUT request.
 func (c Client) Put(ctx context.Context, input PutHttpRequestInput) (*http.Response, int, *odata.OData, error) {
+	c = c.withContextApiVersion(ctx)
 	var status int
 	url, err := c.buildUri(input.Uri)
 	if err != nil {
@@ -590,6 +690,9 @@ func (c Client) Put(ctx context.Context, input PutHttpRequestInput) (*http.Respo
 	if err != nil {
 		return nil, status, nil, err
 	}
+	if input.IfMatch != "" {
+		req.Header.Set("If-Match", input.IfMatch)
+	}
 	resp, status, o, err := c.performRequest(req, input)
 	if err != nil {
 		return nil, status, o, err
@@ -0,0 +1,61 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+type testAuthorizer struct {
+	auxTenantIds []string
+}
+
+func (a testAuthorizer) Token(context.Context, *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{AccessToken: "token", TokenType: "Bearer"}, nil
+}
+
+func (a testAuthorizer) AuxiliaryTokens(context.Context, *http.Request) ([]*oauth2.Token, error) {
+	tokens := []*oauth2.Token{}
+	for _, tenantId := range a.auxTenantIds {
+		tokens = append(tokens, &oauth2.Token{AccessToken: "token-" + tenantId, TokenType: "Bearer"})
+	}
+	return tokens, nil
+}
+
+func TestClientSendsAuxiliaryTokens(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		useAuxiliaryTokens bool
+		want               string
+	}{
+		{"disabled", false, ""},
+		{"enabled", true, "Bearer token-tenant1, Bearer token-tenant2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var header string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header.Get("X-Ms-Authorization-Auxiliary")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("{}"))
+			}))
+			t.Cleanup(server.Close)
+
+			c := NewClient(VersionBeta)
+			c.Endpoint = server.URL
+			c.Authorizer = testAuthorizer{auxTenantIds: []string{"tenant1", "tenant2"}}
+			c.UseAuxiliaryTokens = tc.useAuxiliaryTokens
+			if _, _, _, err := c.Get(context.Background(), GetHttpRequestInput{
+				Uri:              Uri{Entity: "/users"},
+				ValidStatusCodes: []int{http.StatusOK},
+			}); err != nil {
+				t.Fatalf("Get() returned error: %v", err)
+			}
+			if header != tc.want {
+				t.Errorf("got auxiliary header %q, want %q", header, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package msgraph
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// RateLimiterDefaultWorkload is the key of RateLimiter limits applying to all workloads without a specific limit.
+	RateLimiterDefaultWorkload = "*"
+
+	// rateLimiterBatchWorkload is the workload of JSON batches, which are not limited themselves (only their items).
+	rateLimiterBatchWorkload = "$batch"
+)
+
+// RateLimiter limits the requests sent to MS Graph on the client side (before MS Graph starts throttling) using a
+// token bucket per workload and caps the number of requests in flight. It also collects throttling statistics, which
+// get logged (with the totals of the workload so far) whenever a request has been delayed or throttled.
+//
+// The workload of a request is the class of MS Graph services throttled together (see workloadClasses), e.g. `intune`
+// for `deviceManagement` and `deviceAppManagement` or `directory` for `users`, `groups` etc. Each item of a JSON batch
+// counts as a request of its own workload, as that is how MS Graph throttles batches as well.
+type RateLimiter struct {
+	mutex      sync.Mutex
+	limits     map[string]float64
+	buckets    map[string]*tokenBucket
+	inFlight   chan struct{}
+	statistics map[string]*RateLimiterStatistics
+}
+
+// RateLimiterStatistics are the throttling statistics of a single workload.
+type RateLimiterStatistics struct {
+	// Requests is the number of requests sent (including retries and items of JSON batches).
+	Requests int
+
+	// Throttled is the number of responses with status 429 (Too Many Requests) or 503 (Service Unavailable).
+	Throttled int
+
+	// Delayed is the number of requests that had to wait for the client-side rate limit.
+	Delayed int
+
+	// Delay is the total time requests had to wait for the client-side rate limit.
+	Delay time.Duration
+}
+
+type tokenBucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter with the maximum number of requests per second by workload (see
+// RateLimiterDefaultWorkload) and the maximum number of requests in flight. Limits <= 0 mean unlimited.
+func NewRateLimiter(requestsPerSecond map[string]float64, maxInFlight int) *RateLimiter {
+	l := &RateLimiter{
+		limits:     map[string]float64{},
+		buckets:    map[string]*tokenBucket{},
+		statistics: map[string]*RateLimiterStatistics{},
+	}
+	for workload, limit := range requestsPerSecond {
+		if limit > 0 {
+			l.limits[workload] = limit
+		}
+	}
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// workloadClasses maps path prefixes (relative to the API version) to the workloads they belong to, i.e. the MS Graph
+// services sharing the same throttling limits. The longest matching prefix wins and paths not matching any prefix
+// belong to the workload named after their first segment.
+var workloadClasses = map[string]string{
+	"deviceAppManagement":                  "intune",
+	"deviceManagement":                     "intune",
+	"deviceManagement/virtualEndpoint":     "cloudPc",
+	"administrativeUnits":                  "directory",
+	"applications":                         "directory",
+	"contacts":                             "directory",
+	"devices":                              "directory",
+	"directory":                            "directory",
+	"directoryObjects":                     "directory",
+	"directoryRoles":                       "directory",
+	"domains":                              "directory",
+	"groups":                               "directory",
+	"oauth2PermissionGrants":               "directory",
+	"organization":                         "directory",
+	"policies":                             "directory",
+	"roleManagement/directory":             "directory",
+	"servicePrincipals":                    "directory",
+	"users":                                "directory",
+	"identity":                             "identityAndAccess",
+	"identityGovernance":                   "identityGovernance",
+	"roleManagement/entitlementManagement": "identityGovernance",
+	"networkAccess":                        "networkAccess",
+}
+
+// Workload returns the workload of a request path, which may either be absolute (including the API version) or
+// relative to the API version (like the URLs of JSON batch items).
+func Workload(path string) string {
+	path = strings.TrimLeft(path, "/")
+	for _, v := range []ApiVersion{Version10, VersionBeta} {
+		if rest, ok := strings.CutPrefix(path, string(v)+"/"); ok {
+			path = rest
+			break
+		}
+	}
+	if i := strings.IndexAny(path, "(?"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.TrimRight(path, "/"), "/")
+	for i := len(segments); i > 0; i-- {
+		if workload, ok := workloadClasses[strings.Join(segments[:i], "/")]; ok {
+			return workload
+		}
+	}
+	return segments[0]
+}
+
+// Wait blocks until the rate limit of the workload allows to send another request.
+func (l *RateLimiter) Wait(ctx context.Context, workload string) error {
+	l.mutex.Lock()
+	statistics := l.statisticsLocked(workload)
+	statistics.Requests++
+	delay := l.reserveLocked(workload)
+	if delay > 0 {
+		statistics.Delayed++
+		statistics.Delay += delay
+	}
+	snapshot := *statistics
+	l.mutex.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	logStatistics(ctx, "MS Graph request delayed by client-side rate limit", workload, snapshot)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserveLocked takes a token from the bucket of the workload and returns the time to wait until it is available.
+func (l *RateLimiter) reserveLocked(workload string) time.Duration {
+	if workload == rateLimiterBatchWorkload {
+		return 0
+	}
+	key := workload
+	limit, ok := l.limits[key]
+	if !ok {
+		key = RateLimiterDefaultWorkload
+		if limit, ok = l.limits[key]; !ok {
+			return 0
+		}
+	}
+
+	now := time.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{rate: limit, burst: max(1, limit), tokens: max(1, limit), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens-- // might become negative, i.e. the token has been reserved for the future
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// RecordStatus adds the status of a response to the statistics of the workload.
+func (l *RateLimiter) RecordStatus(ctx context.Context, workload string, status int) {
+	if status != http.StatusTooManyRequests && status != http.StatusServiceUnavailable {
+		return
+	}
+	l.mutex.Lock()
+	statistics := l.statisticsLocked(workload)
+	statistics.Throttled++
+	snapshot := *statistics
+	l.mutex.Unlock()
+	logStatistics(ctx, "MS Graph request throttled", workload, snapshot)
+}
+
+// logStatistics logs the event along with the statistics of the workload so far. As the provider does not get notified
+// at the end of a Terraform run, the last of these entries contains the totals of the workload.
+func logStatistics(ctx context.Context, msg string, workload string, s RateLimiterStatistics) {
+	tflog.Info(ctx, msg, map[string]any{
+		"workload":  workload,
+		"requests":  s.Requests,
+		"throttled": s.Throttled,
+		"delayed":   s.Delayed,
+		"delay":     s.Delay.Round(time.Millisecond).String(),
+	})
+}
+
+func (l *RateLimiter) statisticsLocked(workload string) *RateLimiterStatistics {
+	s, ok := l.statistics[workload]
+	if !ok {
+		s = &RateLimiterStatistics{}
+		l.statistics[workload] = s
+	}
+	return s
+}
+
+// Statistics returns a copy of the throttling statistics by workload.
+func (l *RateLimiter) Statistics() map[string]RateLimiterStatistics {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	result := make(map[string]RateLimiterStatistics, len(l.statistics))
+	for workload, s := range l.statistics {
+		result[workload] = *s
+	}
+	return result
+}
+
+// Transport returns a RoundTripper which applies the limits to all requests (including retries) sent using next to the
+// MS Graph endpoint (e.g. `https://graph.microsoft.com` or `https://egress.example.com/graph`, whose path is not part
+// of the workload). JSON batches only count against the cap of requests in flight as their items are limited
+// individually. Requests stay in flight until their response body has been read completely or closed.
+func (l *RateLimiter) Transport(endpoint string, next http.RoundTripper) http.RoundTripper {
+	basePath := ""
+	if u, err := url.Parse(endpoint); err == nil {
+		basePath = strings.TrimRight(u.Path, "/")
+	}
+	return rateLimiterTransport{limiter: l, basePath: basePath, next: next}
+}
+
+type rateLimiterTransport struct {
+	limiter  *RateLimiter
+	basePath string
+	next     http.RoundTripper
+}
+
+func (t rateLimiterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	workload := Workload(strings.TrimPrefix(req.URL.Path, t.basePath))
+	if err := t.limiter.Wait(req.Context(), workload); err != nil {
+		return nil, err
+	}
+
+	release := func() {}
+	if t.limiter.inFlight != nil {
+		select {
+		case t.limiter.inFlight <- struct{}{}:
+			var once sync.Once
+			release = func() { once.Do(func() { <-t.limiter.inFlight }) }
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if resp == nil || resp.Body == nil || resp.Body == http.NoBody {
+		release()
+	} else {
+		resp.Body = &inFlightBody{ReadCloser: resp.Body, release: release}
+	}
+	if resp != nil {
+		t.limiter.RecordStatus(req.Context(), workload, resp.StatusCode)
+	}
+	return resp, err
+}
+
+// inFlightBody releases the slot of its request in flight once it has been read completely or closed.
+type inFlightBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *inFlightBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		b.release()
+	}
+	return n, err
+}
+
+func (b *inFlightBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
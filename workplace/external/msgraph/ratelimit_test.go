@@ -0,0 +1,145 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkload(t *testing.T) {
+	tests := map[string]string{
+		"/beta/deviceManagement/deviceConfigurations/1":               "intune",
+		"/v1.0/users?$filter=x":                                       "directory",
+		"/groups/1/members":                                           "directory",
+		"/beta/$batch":                                                "$batch",
+		"/deviceManagement/deviceConfigurations/1/assign":             "intune",
+		"/beta/deviceManagement/virtualEndpoint/provisioningPolicies": "cloudPc",
+		"/roleManagement/directory/roleAssignments('1')":              "directory",
+		"/roleManagement/entitlementManagement/roleAssignments":       "identityGovernance",
+		"/beta/deviceAppManagement/mobileApps(id='1')/assign":         "intune",
+		"/beta/identityGovernance/lifecycleWorkflows/workflows/":      "identityGovernance",
+		"/beta/security/alerts":                                       "security",
+	}
+	for path, want := range tests {
+		if got := Workload(path); got != want {
+			t.Errorf("Workload(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRateLimiterLimitsPerWorkload(t *testing.T) {
+	ctx := context.Background()
+	l := NewRateLimiter(map[string]float64{"intune": 10, RateLimiterDefaultWorkload: 1000}, 0)
+
+	start := time.Now()
+	for range 15 {
+		if err := l.Wait(ctx, "intune"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// burst of 10, then 5 more at 10 per second
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("15 requests at 10 per second took %s", elapsed)
+	}
+
+	start = time.Now()
+	for range 15 {
+		if err := l.Wait(ctx, "directory"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("15 requests limited by default workload took %s", elapsed)
+	}
+
+	s := l.Statistics()
+	if s["intune"].Requests != 15 || s["intune"].Delayed != 5 || s["intune"].Delay == 0 {
+		t.Errorf("unexpected statistics for intune: %+v", s["intune"])
+	}
+	if s["directory"].Requests != 15 || s["directory"].Delayed != 0 {
+		t.Errorf("unexpected statistics for directory: %+v", s["directory"])
+	}
+}
+
+func TestRateLimiterTransport(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if r.URL.Path == "/graph/beta/throttled" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	l := NewRateLimiter(nil, 2)
+	client := &http.Client{Transport: l.Transport(server.URL+"/graph", http.DefaultTransport)}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		path := "/graph/beta/users"
+		if i == 0 {
+			path = "/graph/beta/throttled"
+		}
+		wg.Go(func() {
+			resp, err := client.Get(server.URL + path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		})
+	}
+	wg.Wait()
+
+	if m := maxInFlight.Load(); m > 2 {
+		t.Errorf("%d requests were in flight at the same time", m)
+	}
+	s := l.Statistics()
+	if s["directory"].Requests != 9 || s["throttled"].Requests != 1 || s["throttled"].Throttled != 1 {
+		t.Errorf("unexpected statistics: %+v", s)
+	}
+}
+
+func TestRateLimiterTransportReleasesOnBodyClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"value":[]}`))
+	}))
+	defer server.Close()
+
+	l := NewRateLimiter(nil, 1)
+	client := &http.Client{Transport: l.Transport(server.URL, http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL + "/beta/users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the body of the first response has not been consumed yet, so the second request must wait
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/beta/users", http.NoBody)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("second request has been sent before the body of the first response has been closed")
+	}
+
+	resp.Body.Close()
+	resp, err = client.Get(server.URL + "/beta/users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
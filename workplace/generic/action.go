@@ -0,0 +1,87 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &GenericAction{}
+	_ action.ActionWithConfigure = &GenericAction{}
+)
+
+// GenericAction is the action implementation. Actions invoke an MS Graph action (i.e. a POST request) on a single
+// entity, e.g. to sync a device. The URI of the entity gets determined by AccessParams exactly like for resources (i.e.
+// using BaseUri, ParentEntities and EntityId) with AccessParams.UriSuffix being the name of the MS Graph action.
+//
+// SpecificSchema is a resource schema (to be able to translate it exactly like the one of resources), all attributes
+// but the Terraform only ones (like the id attributes) make up the body of the request.
+type GenericAction struct {
+	TypeNameSuffix string
+	SpecificSchema rsschema.Schema
+	AccessParams   AccessParams
+}
+
+// Metadata returns the action type name.
+func (a *GenericAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, a.TypeNameSuffix)
+}
+
+// Configure adds the provider configured MSGraph client to the action.
+func (a *GenericAction) Configure(_ context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	a.AccessParams.InitializeGuarded(req.ProviderData)
+}
+
+// Schema defines the schema for the action.
+func (a *GenericAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	attributes := map[string]actionschema.Attribute{}
+	for name, attribute := range a.SpecificSchema.Attributes {
+		attributes[name] = attribute
+	}
+	resp.Schema = actionschema.Schema{
+		Attributes:          attributes,
+		Description:         a.SpecificSchema.Description,
+		MarkdownDescription: a.SpecificSchema.MarkdownDescription,
+	}
+}
+
+// Invoke posts the config to the MS Graph action of the entity.
+func (a *GenericAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+
+	diags := &resp.Diagnostics
+
+	ctx = a.AccessParams.ApiVersionContext(ctx, diags, "")
+	uri := a.AccessParams.GetUriWithIdForUD(ctx, diags, "", "", req.Config)
+	if diags.HasError() {
+		return
+	}
+
+	rawVal := ConvertTerraformToOdataRaw(ctx, diags, a.SpecificSchema, false, req.Config.Raw, false, req.Config,
+		a.AccessParams.TerraformToGraphMiddleware, "Config")
+	if diags.HasError() {
+		return
+	}
+	jsonVal := ConvertOdataRawToJson(ctx, diags, rawVal, "Config")
+	if diags.HasError() {
+		return
+	}
+
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Invoking MS Graph action %s", uri.Entity)})
+	}
+
+	validStatusCodes := []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent}
+	_, _, _, err := a.AccessParams.graphClient.Post(ctx, msgraph.PostHttpRequestInput{Uri: uri, Body: jsonVal, ValidStatusCodes: validStatusCodes})
+	if err != nil {
+		diags.AddError("Error invoking MS Graph action", fmt.Sprintf("POST %s failed: %s", uri.Entity, err.Error()))
+		return
+	}
+}
@@ -0,0 +1,149 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+//
+// Entities only support the MS Graph beta API by default. Entities also available in other API versions (usually v1.0)
+// declare these in AccessParams.ApiVersions and the API version then gets selected by (in this order):
+//   - the (Terraform only) root attribute `api_version` of the resource
+//   - the API version preferred by the provider (i.e. msgraph.Client.PreferredApiVersion) if supported
+//   - the first supported API version
+//
+// Attributes only available in the beta API must be declared in AccessParams.BetaOnlyAttributes. These will neither be
+// sent to MS Graph nor be read from it when using another API version (see ToFromGraphTranslator.ExcludedAttributes).
+//
+
+// ApiVersionAttribute is the name of the root attribute to select the MS Graph API version.
+const ApiVersionAttribute = "api_version"
+
+type excludedAttributesKey struct{}
+
+// apiVersionAttribute returns the schema of the (Terraform only) attribute to select the MS Graph API version.
+func (ap *AccessParams) apiVersionAttribute() rsschema.StringAttribute {
+	apiVersions := make([]string, 0, len(ap.ApiVersions))
+	for _, v := range ap.ApiVersions {
+		apiVersions = append(apiVersions, string(v))
+	}
+	return rsschema.StringAttribute{
+		Optional:    true,
+		Description: TerraformOnlyAttribute,
+		Validators:  []validator.String{stringvalidator.OneOf(apiVersions...)},
+		MarkdownDescription: fmt.Sprintf("MS Graph API version to use for this resource. Attributes only available in the "+
+			"`beta` API cannot be set when using another API version. <br/> The _provider_ default value is the `api_version` "+
+			"of the provider if supported by this resource, otherwise `%s`. <br/> The _provider_ allowed values are: `%s`.",
+			apiVersions[0], strings.Join(apiVersions, "`, `")),
+	}
+}
+
+// initApiVersionSchema adds the attribute to select the MS Graph API version to the schema of resources supporting
+// more than one API version.
+func (ap *AccessParams) initApiVersionSchema(s *rsschema.Schema) {
+	if len(ap.ApiVersions) <= 1 {
+		return
+	}
+	if _, ok := s.Attributes[ApiVersionAttribute]; ok {
+		panic(fmt.Sprintf("attribute %s already exists in schema", ApiVersionAttribute))
+	}
+	s.Attributes[ApiVersionAttribute] = ap.apiVersionAttribute()
+}
+
+// supportsApiVersion returns true if the entity is available in the API version.
+func (ap *AccessParams) supportsApiVersion(apiVersion msgraph.ApiVersion) bool {
+	if len(ap.ApiVersions) == 0 {
+		return apiVersion == msgraph.VersionBeta
+	}
+	return slices.Contains(ap.ApiVersions, apiVersion)
+}
+
+// ApiVersionContext returns a context making all MS Graph requests use the API version selected for the entity. The
+// requested API version (usually the value of the `api_version` attribute) may be empty.
+func (ap *AccessParams) ApiVersionContext(ctx context.Context, diags *diag.Diagnostics, requested string) context.Context {
+	var apiVersion msgraph.ApiVersion
+	switch {
+	case requested != "":
+		apiVersion = msgraph.ApiVersion(requested)
+		if !ap.supportsApiVersion(apiVersion) {
+			diags.AddError("Unsupported MS Graph API version",
+				fmt.Sprintf("The entity is not available in MS Graph API version '%s'", requested))
+			return ctx
+		}
+	case ap.graphClient != nil && ap.graphClient.PreferredApiVersion != "" && ap.supportsApiVersion(ap.graphClient.PreferredApiVersion):
+		apiVersion = ap.graphClient.PreferredApiVersion
+	case len(ap.ApiVersions) > 0:
+		apiVersion = ap.ApiVersions[0]
+	default:
+		apiVersion = msgraph.VersionBeta
+	}
+
+	ctx = msgraph.WithApiVersion(ctx, apiVersion)
+	if apiVersion != msgraph.VersionBeta && len(ap.BetaOnlyAttributes) > 0 {
+		ctx = context.WithValue(ctx, excludedAttributesKey{}, ap.BetaOnlyAttributes)
+	}
+	return ctx
+}
+
+// apiVersionContextFromRequest is like ApiVersionContext but reads the requested API version from the `api_version`
+// attribute of the plan or state (if the schema has this attribute).
+func (ap *AccessParams) apiVersionContextFromRequest(ctx context.Context, diags *diag.Diagnostics, src GetAttributer) context.Context {
+	requested := types.StringNull()
+	if len(ap.ApiVersions) > 1 {
+		diags.Append(src.GetAttribute(ctx, path.Root(ApiVersionAttribute), &requested)...)
+		if diags.HasError() {
+			return ctx
+		}
+	}
+	return ap.ApiVersionContext(ctx, diags, requested.ValueString())
+}
+
+// excludedAttributesFromContext returns the attributes not available in the API version selected by ApiVersionContext.
+func excludedAttributesFromContext(ctx context.Context) []path.Path {
+	excludedAttributes, _ := ctx.Value(excludedAttributesKey{}).([]path.Path)
+	return excludedAttributes
+}
+
+// PopulateStateExcludedAttributesFromRequest copies the values of all attributes not available in the API version
+// selected by ApiVersionContext (which therefore cannot be read from MS Graph) to the new state.
+func PopulateStateExcludedAttributesFromRequest(ctx context.Context, diags *diag.Diagnostics, dst *tfsdk.State, src GetAttributer) {
+	for _, p := range excludedAttributesFromContext(ctx) {
+		err := CopyValueAtPath(ctx, dst, src, p)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("CopyValueAtPath(), excluded attribute: %s", p), err.Error())
+			return
+		}
+	}
+}
+
+// isExcludedAttribute returns true if the Terraform attribute path matches one of the excluded attributes (which only
+// consist of attribute names, i.e. cannot be nested inside lists, sets or maps).
+func isExcludedAttribute(excludedAttributes []path.Path, p *tftypes.AttributePath) bool {
+	if len(excludedAttributes) == 0 || p == nil {
+		return false
+	}
+	names := make([]string, 0)
+	for _, step := range p.Steps() {
+		if name, ok := step.(tftypes.AttributeName); ok {
+			names = append(names, string(name))
+		} else {
+			return false
+		}
+	}
+	return slices.ContainsFunc(excludedAttributes, func(excluded path.Path) bool {
+		return excluded.String() == strings.Join(names, ".")
+	})
+}
@@ -0,0 +1,40 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// BatchRaw sends all requests using msgraph.Client.Batch and adds an error for each request that did not succeed.
+// Deleting entities that do not exist (anymore) only results in a warning (just like DeleteRaw does).
+func BatchRaw(ctx context.Context, diags *diag.Diagnostics, graphClient *msgraph.Client,
+	requests []msgraph.BatchRequest, errorSummary string) []*msgraph.BatchResponse {
+
+	if len(requests) == 0 {
+		return nil
+	}
+
+	responses, err := graphClient.Batch(ctx, requests)
+	if err != nil {
+		diags.AddError(errorSummary, err.Error())
+		return nil
+	}
+
+	for i, r := range responses {
+		_, status, _, err := r.Result()
+		if err != nil {
+			if requests[i].Method == http.MethodDelete && status == http.StatusNotFound {
+				diags.AddWarning("Unable to delete from MS Graph", fmt.Sprintf("Item %q does not exist (anymore).", requests[i].Uri.Entity))
+			} else {
+				diags.AddError(errorSummary, fmt.Sprintf("%s %s: %s", requests[i].Method, requests[i].Uri.Entity, err.Error()))
+			}
+		}
+	}
+
+	return responses
+}
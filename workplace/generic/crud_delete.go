@@ -13,6 +13,13 @@ import (
 func (aps *AccessParams) DeleteRaw(ctx context.Context, diags *diag.Diagnostics,
 	baseUri string, id string, idAttributer GetAttributer) {
 
+	aps.DeleteRaw2(ctx, diags, baseUri, id, idAttributer, "")
+}
+
+// DeleteRaw2 will send ifMatch (if not empty) as If-Match header (also see UpdateRaw3).
+func (aps *AccessParams) DeleteRaw2(ctx context.Context, diags *diag.Diagnostics,
+	baseUri string, id string, idAttributer GetAttributer, ifMatch string) {
+
 	uri := aps.GetUriWithIdForUD(ctx, diags, baseUri, id, idAttributer)
 	if diags.HasError() {
 		return
@@ -20,11 +27,14 @@ func (aps *AccessParams) DeleteRaw(ctx context.Context, diags *diag.Diagnostics,
 
 	_, status, _, err := aps.graphClient.Delete(ctx, msgraph.DeleteHttpRequestInput{
 		Uri:              uri,
+		IfMatch:          ifMatch,
 		ValidStatusCodes: []int{http.StatusOK, http.StatusNoContent},
 	})
 	if err != nil {
 		if status == http.StatusNotFound {
 			diags.AddWarning("Unable to delete from MS Graph", fmt.Sprintf("Item %q does not exist (anymore).", uri.Entity))
+		} else if ifMatch != "" && status == http.StatusPreconditionFailed {
+			addPreconditionFailedError(diags, uri.Entity, ifMatch)
 		} else {
 			diags.AddError("Error deleting from MS Graph", fmt.Sprintf("Original Error: %s", err.Error()))
 		}
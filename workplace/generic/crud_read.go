@@ -45,11 +45,26 @@ func (aps *AccessParams) ReadSingleCompleteTf3(ctx context.Context, diags *diag.
 		return tftypes.Value{}
 	}
 
-	rawVal := aps.ReadRaw3(ctx, diags, uri, readOptions.ODataExpand, odataFilter2, readOptions.ODataSelect, odataOrderby, odataTop, tolerateNotFound)
+	// If the entity gets addressed directly, it can be read along with all extra requests within a single JSON batch
+	var rawVal map[string]any
+	var rawExtraVals []map[string]any
+	batchExtraRequests := len(readOptions.ExtraRequests) > 0 && odataFilter2 == "" && odataOrderby == "" && odataTop == 0
+	if batchExtraRequests {
+		rawVal, rawExtraVals = aps.readRawWithExtraRequestsBatched(ctx, diags, uri, readOptions, tolerateNotFound)
+	} else {
+		rawVal = aps.ReadRaw4(ctx, diags, uri, readOptions.ODataExpand, odataFilter2, readOptions.ODataSelect, odataOrderby, odataTop,
+			readOptions.ValidStatusCodesExtra, tolerateNotFound)
+	}
 	if rawVal == nil || diags.HasError() {
 		return tftypes.Value{}
 	}
 
+	// remember ETag (if any) to be able to detect changes made outside of TF on next update or delete
+	setPrivateETag(ctx, diags, respPrivate, getETagFromRaw(rawVal))
+	if diags.HasError() {
+		return tftypes.Value{}
+	}
+
 	if len(readOptions.ExtraRequests) > 0 || len(readOptions.ExtraRequestsCustom) > 0 {
 
 		// also see ConvertOdataRawToTerraform (but we need to do this up here for the extra requests to work)
@@ -71,15 +86,16 @@ func (aps *AccessParams) ReadSingleCompleteTf3(ctx context.Context, diags *diag.
 			uri.Entity = fmt.Sprintf("%s/%s", uri.Entity, id)
 		}
 
-		for _, r := range readOptions.ExtraRequests {
-			uriSuffix := r.UriSuffix
-			if uriSuffix == "" {
-				uriSuffix = r.Attribute
-			}
-			leafUri := fmt.Sprintf("%s/%s", uri.Entity, uriSuffix)
-			rawLeafVal := aps.ReadRaw(ctx, diags, leafUri, tolerateNotFound)
-			if diags.HasError() {
-				return tftypes.Value{}
+		for i, r := range readOptions.ExtraRequests {
+			var rawLeafVal map[string]any
+			if batchExtraRequests {
+				rawLeafVal = rawExtraVals[i]
+			} else {
+				rawLeafVal = aps.ReadRaw4(ctx, diags, msgraph.Uri{Entity: r.leafUri(uri)}, "", "", nil, "", 0,
+					readOptions.ValidStatusCodesExtra, tolerateNotFound)
+				if diags.HasError() {
+					return tftypes.Value{}
+				}
 			}
 
 			if rawLeafVal != nil {
@@ -126,6 +142,59 @@ func (aps *AccessParams) ReadSingleCompleteTf3(ctx context.Context, diags *diag.
 	return tfVal
 }
 
+// readRawWithExtraRequestsBatched reads an entity (which must be addressed directly, i.e. not by OData filter) and all
+// of its ReadOptions.ExtraRequests using a single JSON batch. The results of the extra requests are returned in the
+// same order as ReadOptions.ExtraRequests.
+func (aps *AccessParams) readRawWithExtraRequestsBatched(ctx context.Context, diags *diag.Diagnostics, uri msgraph.Uri,
+	readOptions ReadOptions, tolerateNotFound bool) (map[string]any, []map[string]any) {
+
+	validStatusCodes := append([]int{http.StatusOK}, readOptions.ValidStatusCodesExtra...)
+	mainQuery := odata.Query{Select: readOptions.ODataSelect}
+	if readOptions.ODataExpand != "" {
+		mainQuery.Expand = odata.Expand{Relationship: readOptions.ODataExpand}
+	}
+
+	requests := []msgraph.BatchRequest{
+		{Method: http.MethodGet, Uri: uri, OData: mainQuery, ValidStatusCodes: validStatusCodes},
+	}
+	for _, r := range readOptions.ExtraRequests {
+		requests = append(requests, msgraph.BatchRequest{
+			Method:           http.MethodGet,
+			Uri:              msgraph.Uri{Entity: r.leafUri(uri)},
+			ValidStatusCodes: validStatusCodes,
+		})
+	}
+
+	responses, err := aps.graphClient.Batch(ctx, requests)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading from MS Graph for query %q", uri.Entity), fmt.Sprintf("Original Error: %s", err.Error()))
+		return nil, nil
+	}
+
+	rawVals := make([]map[string]any, len(responses))
+	for i, r := range responses {
+		graphResp, status, odata, err := r.Result()
+		rawVals[i] = readRawProcessResponse(ctx, diags, requests[i].Uri, &requests[i].OData, graphResp, status, odata, err, tolerateNotFound)
+		if diags.HasError() {
+			return nil, nil
+		}
+		if i == 0 && rawVals[i] == nil {
+			// not found (and tolerated), no need to look at the extra requests
+			return nil, nil
+		}
+	}
+
+	return rawVals[0], rawVals[1:]
+}
+
+func (r ReadExtraRequest) leafUri(uri msgraph.Uri) string {
+	uriSuffix := r.UriSuffix
+	if uriSuffix == "" {
+		uriSuffix = r.Attribute
+	}
+	return fmt.Sprintf("%s/%s", uri.Entity, uriSuffix)
+}
+
 func (aps *AccessParams) ReadRaw(ctx context.Context, diags *diag.Diagnostics, uriEntity string, tolerateNotFound bool) map[string]any {
 	return aps.ReadRaw2(ctx, diags, msgraph.Uri{Entity: uriEntity}, "", "", nil, tolerateNotFound)
 }
@@ -136,6 +205,12 @@ func (aps *AccessParams) ReadRaw2(ctx context.Context, diags *diag.Diagnostics,
 }
 func (aps *AccessParams) ReadRaw3(ctx context.Context, diags *diag.Diagnostics, uri msgraph.Uri,
 	odataExpand string, odataFilter string, odataSelect []string, odataOrderby string, odataTop int, tolerateNotFound bool) map[string]any {
+	return aps.ReadRaw4(ctx, diags, uri, odataExpand, odataFilter, odataSelect, odataOrderby, odataTop, aps.ReadOptions.ValidStatusCodesExtra, tolerateNotFound)
+}
+
+func (aps *AccessParams) ReadRaw4(ctx context.Context, diags *diag.Diagnostics, uri msgraph.Uri,
+	odataExpand string, odataFilter string, odataSelect []string, odataOrderby string, odataTop int,
+	validStatusCodesExtra []int, tolerateNotFound bool) map[string]any {
 	odataQuery := odata.Query{
 		Filter: odataFilter,
 		Select: odataSelect,
@@ -151,7 +226,7 @@ func (aps *AccessParams) ReadRaw3(ctx context.Context, diags *diag.Diagnostics,
 		}
 		uri.Params.Set("$orderby", odataOrderby)
 	}
-	return ReadRaw2(ctx, diags, aps.graphClient, uri, &odataQuery, aps.ReadOptions.ValidStatusCodesExtra, tolerateNotFound)
+	return ReadRaw2(ctx, diags, aps.graphClient, uri, &odataQuery, validStatusCodesExtra, tolerateNotFound)
 }
 
 func (aps *AccessParams) ReadId(ctx context.Context, diags *diag.Diagnostics,
@@ -242,16 +317,26 @@ func ReadRaw2(ctx context.Context, diags *diag.Diagnostics, graphClient *msgraph
 	}
 
 	graphResp, status, odata, err := graphClient.Get(ctx, graphRequest)
+	return readRawProcessResponse(ctx, diags, uri, odataQuery, graphResp, status, odata, err, tolerateNotFound)
+}
+
+func readRawProcessResponse(ctx context.Context, diags *diag.Diagnostics, uri msgraph.Uri, odataQuery *odata.Query,
+	graphResp *http.Response, status int, odata *odata.OData, err error, tolerateNotFound bool) map[string]any {
+
 	if err != nil {
-		if status == http.StatusNotFound || (odata != nil && odata.Error != nil && *odata.Error.Code == "ResourceNotFound") {
+		odataFilter := ""
+		if odataQuery != nil {
+			odataFilter = odataQuery.Filter
+		}
+		if status == http.StatusNotFound || (odata != nil && odata.Error != nil && odata.Error.Code != nil && *odata.Error.Code == "ResourceNotFound") {
 			if tolerateNotFound {
-				tflog.Info(ctx, fmt.Sprintf("No entity found in MS Graph for query %q and OData filter %q", uri.Entity, odataQuery.Filter))
+				tflog.Info(ctx, fmt.Sprintf("No entity found in MS Graph for query %q and OData filter %q", uri.Entity, odataFilter))
 			} else {
-				diags.AddError(fmt.Sprintf("No entity found in MS Graph for query %q and OData filter %q", uri.Entity, odataQuery.Filter),
+				diags.AddError(fmt.Sprintf("No entity found in MS Graph for query %q and OData filter %q", uri.Entity, odataFilter),
 					fmt.Sprintf("MS Graph returned a resource not found error. Original Error: %s", err.Error()))
 			}
 		} else {
-			diags.AddError(fmt.Sprintf("Error reading from MS Graph for query %q and OData filter %q", uri.Entity, odataQuery.Filter),
+			diags.AddError(fmt.Sprintf("Error reading from MS Graph for query %q and OData filter %q", uri.Entity, odataFilter),
 				fmt.Sprintf("Original Error: %s", err.Error()))
 		}
 		return nil
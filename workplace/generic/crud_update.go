@@ -18,6 +18,14 @@ func (aps *AccessParams) UpdateRaw(ctx context.Context, diags *diag.Diagnostics,
 func (aps *AccessParams) UpdateRaw2(ctx context.Context, diags *diag.Diagnostics,
 	baseUri string, id string, idAttributer GetAttributer, rawVal map[string]any, usePut bool) {
 
+	aps.UpdateRaw3(ctx, diags, baseUri, id, idAttributer, rawVal, usePut, "")
+}
+
+// UpdateRaw3 will send ifMatch (if not empty) as If-Match header and add a corresponding error if MS Graph rejects the
+// update due to the entity having been changed in the meantime.
+func (aps *AccessParams) UpdateRaw3(ctx context.Context, diags *diag.Diagnostics,
+	baseUri string, id string, idAttributer GetAttributer, rawVal map[string]any, usePut bool, ifMatch string) {
+
 	uri := aps.GetUriWithIdForUD(ctx, diags, baseUri, id, idAttributer)
 	if diags.HasError() {
 		return
@@ -28,15 +36,20 @@ func (aps *AccessParams) UpdateRaw2(ctx context.Context, diags *diag.Diagnostics
 		return
 	}
 
+	var status int
 	var err error
 	// No need to read body when updating as id will stay the same
 	validStatusCodes := []int{http.StatusOK, http.StatusNoContent}
 	if !usePut {
-		_, _, _, err = aps.graphClient.Patch(ctx, msgraph.PatchHttpRequestInput{Uri: uri, Body: jsonVal, ValidStatusCodes: validStatusCodes})
+		_, status, _, err = aps.graphClient.Patch(ctx, msgraph.PatchHttpRequestInput{Uri: uri, Body: jsonVal, IfMatch: ifMatch, ValidStatusCodes: validStatusCodes})
 	} else {
-		_, _, _, err = aps.graphClient.Put(ctx, msgraph.PutHttpRequestInput{Uri: uri, Body: jsonVal, ValidStatusCodes: validStatusCodes})
+		_, status, _, err = aps.graphClient.Put(ctx, msgraph.PutHttpRequestInput{Uri: uri, Body: jsonVal, IfMatch: ifMatch, ValidStatusCodes: validStatusCodes})
 	}
 	if err != nil {
+		if ifMatch != "" && status == http.StatusPreconditionFailed {
+			addPreconditionFailedError(diags, uri.Entity, ifMatch)
+			return
+		}
 		diags.AddError("Unable to update with MS Graph", err.Error())
 		return
 	}
@@ -50,6 +50,9 @@ func convertResourceAttr2DataSourceAttr(rsAttribute rsschema.Attribute, required
 	case rsschema.SingleNestedAttribute:
 		dsAttributes := make(map[string]dsschema.Attribute)
 		for nKey, nValue := range typed.Attributes {
+			if isResourceOnlyAttribute(nValue) {
+				continue
+			}
 			dsAttributes[nKey] = convertResourceAttr2DataSourceAttr(nValue, false, odataDerivedTypeIsPlaceholderOnly, nKey)
 		}
 		return dsschema.SingleNestedAttribute{
@@ -69,6 +72,9 @@ func convertResourceAttr2DataSourceAttr(rsAttribute rsschema.Attribute, required
 		mdDescription := ""
 		if !odataDerivedTypeIsPlaceholderOnly {
 			for nKey, nValue := range typed.Attributes {
+				if isResourceOnlyAttribute(nValue) {
+					continue
+				}
 				dsAttributes[nKey] = convertResourceAttr2DataSourceAttr(nValue, false, odataDerivedTypeIsPlaceholderOnly, nKey)
 			}
 			mdDescription = descCleanupRegex.ReplaceAllLiteralString(typed.MarkdownDescription, "")
@@ -94,6 +100,9 @@ func convertResourceAttr2DataSourceAttr(rsAttribute rsschema.Attribute, required
 	case rsschema.SetNestedAttribute:
 		dsAttributes := make(map[string]dsschema.Attribute)
 		for nKey, nValue := range typed.NestedObject.Attributes {
+			if isResourceOnlyAttribute(nValue) {
+				continue
+			}
 			dsAttributes[nKey] = convertResourceAttr2DataSourceAttr(nValue, false, odataDerivedTypeIsPlaceholderOnly, nKey)
 		}
 		nestedObject := dsschema.NestedAttributeObject{
@@ -115,6 +124,9 @@ func convertResourceAttr2DataSourceAttr(rsAttribute rsschema.Attribute, required
 	case rsschema.ListNestedAttribute:
 		dsAttributes := make(map[string]dsschema.Attribute)
 		for nKey, nValue := range typed.NestedObject.Attributes {
+			if isResourceOnlyAttribute(nValue) {
+				continue
+			}
 			dsAttributes[nKey] = convertResourceAttr2DataSourceAttr(nValue, false, odataDerivedTypeIsPlaceholderOnly, nKey)
 		}
 		nestedObject := dsschema.NestedAttributeObject{
@@ -7,7 +7,10 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"golang.org/x/exp/slices"
 )
 
@@ -26,6 +29,7 @@ type GenericDataSourcePlural struct {
 
 	odataFilterAttrsWithGraphNames map[string]string
 	odataSelectGraphNames          []string
+	odataSelectGraphNamesBetaOnly  []string // must not be selected when using another API version than beta
 }
 
 func CreateGenericDataSourcePluralFromResource(genericResource *GenericResource, typeNameSuffix string) GenericDataSourcePlural {
@@ -96,6 +100,9 @@ func CreateGenericDataSourcePluralFromResource(genericResource *GenericResource,
 	}
 
 	for attrName, rsAttribute := range rsSchema.Attributes {
+		if isResourceOnlyAttribute(rsAttribute) {
+			continue
+		}
 
 		// Schema root
 		identifiesParent := accessParams.ParentEntities.ContainsFieldName(attrName)
@@ -119,6 +126,9 @@ func CreateGenericDataSourcePluralFromResource(genericResource *GenericResource,
 				if !slices.Contains(dsResult.odataSelectGraphNames, attrNameGraph) {
 					dsResult.odataSelectGraphNames = append(dsResult.odataSelectGraphNames, attrNameGraph)
 				}
+				if slices.ContainsFunc(accessParams.BetaOnlyAttributes, path.Root(attrName).Equal) {
+					dsResult.odataSelectGraphNamesBetaOnly = append(dsResult.odataSelectGraphNamesBetaOnly, attrNameGraph)
+				}
 			}
 		}
 	}
@@ -146,30 +156,46 @@ func (d *GenericDataSourcePlural) Schema(ctx context.Context, _ datasource.Schem
 // Read refreshes the Terraform state with the latest data.
 func (d *GenericDataSourcePlural) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
-	uri := d.AccessParams.GetBaseUri(ctx, &resp.Diagnostics, "", &req.Config)
+	val := d.readTf(ctx, &resp.Diagnostics, req.Config)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.State = tfsdk.State{
+		Schema: req.Config.Schema,
+		Raw:    val,
+	}
+	d.AccessParams.PopulateStateParentIdsFromRequest(ctx, &resp.Diagnostics, &resp.State, req.Config)
+}
 
-	odataFilter, odataOrderby, odataTop := dsGetFilterFromConfig(ctx, &resp.Diagnostics, &req.Config, &d.AccessParams, d.odataFilterAttrsWithGraphNames)
-	if resp.Diagnostics.HasError() {
-		return
+// readTf reads all entities matching the (parent id and filter) attributes of the config and returns them as value of
+// the data source schema (with only the result attribute being set). The config may also be the one of a list resource.
+func (d *GenericDataSourcePlural) readTf(ctx context.Context, diags *diag.Diagnostics, config tfsdk.Config) tftypes.Value {
+
+	ctx = d.AccessParams.ApiVersionContext(ctx, diags, "")
+
+	uri := d.AccessParams.GetBaseUri(ctx, diags, "", &config)
+	if diags.HasError() {
+		return tftypes.Value{}
 	}
 
-	// do not consider ODataExpand here as it would automatically include the attribute(s) in the result
-	rawVal := d.AccessParams.ReadRaw3(ctx, &resp.Diagnostics, uri, "", odataFilter, d.odataSelectGraphNames, odataOrderby, odataTop, false)
-	if resp.Diagnostics.HasError() {
-		return
+	odataFilter, odataOrderby, odataTop := dsGetFilterFromConfig(ctx, diags, &config, &d.AccessParams, d.odataFilterAttrsWithGraphNames)
+	if diags.HasError() {
+		return tftypes.Value{}
 	}
 
-	val := ConvertOdataRawToTerraform(ctx, &resp.Diagnostics, req.Config.Schema.(dsschema.Schema), rawVal, d.ResultAttributeName,
-		d.AccessParams.GraphToTerraformMiddleware, "", d.AccessParams.GraphToTerraformMiddlewareTargetSetRunOnRawVal)
-	if resp.Diagnostics.HasError() {
-		return
+	odataSelect := d.odataSelectGraphNames
+	if len(excludedAttributesFromContext(ctx)) > 0 {
+		odataSelect = slices.DeleteFunc(slices.Clone(odataSelect), func(s string) bool {
+			return slices.Contains(d.odataSelectGraphNamesBetaOnly, s)
+		})
 	}
-	resp.State = tfsdk.State{
-		Schema: req.Config.Schema,
-		Raw:    val,
+
+	// do not consider ODataExpand here as it would automatically include the attribute(s) in the result
+	rawVal := d.AccessParams.ReadRaw3(ctx, diags, uri, "", odataFilter, odataSelect, odataOrderby, odataTop, false)
+	if diags.HasError() {
+		return tftypes.Value{}
 	}
-	d.AccessParams.PopulateStateParentIdsFromRequest(ctx, &resp.Diagnostics, &resp.State, req.Config)
+
+	return ConvertOdataRawToTerraform(ctx, diags, d.SpecificSchema, rawVal, d.ResultAttributeName,
+		d.AccessParams.GraphToTerraformMiddleware, "", d.AccessParams.GraphToTerraformMiddlewareTargetSetRunOnRawVal)
 }
@@ -53,6 +53,9 @@ func CreateGenericDataSourceSingularFromResource(genericResource *GenericResourc
 
 	// Select and convert attributes
 	for attrName, rsAttribute := range rsSchema.Attributes {
+		if isResourceOnlyAttribute(rsAttribute) {
+			continue
+		}
 		identifiesParent := accessParams.ParentEntities.ContainsFieldName(attrName)
 		dsAttributesRoot[attrName] = convertResourceAttr2DataSourceAttr(rsAttribute, identifiesParent, false, attrName)
 	}
@@ -84,6 +87,8 @@ func (d *GenericDataSourceSingular) ConfigValidators(context.Context) []datasour
 // Read refreshes the Terraform state with the latest data.
 func (d *GenericDataSourceSingular) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
+	ctx = d.AccessParams.ApiVersionContext(ctx, &resp.Diagnostics, "")
+
 	odataFilter, odataOrderby, odataTop := dsGetFilterFromConfig(ctx, &resp.Diagnostics, &req.Config, &d.AccessParams, d.odataFilterAttrsWithGraphNames)
 	if resp.Diagnostics.HasError() {
 		return
@@ -0,0 +1,85 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ETags (as returned by MS Graph in @odata.etag for some entity types) get saved to private state on read and will then
+// be sent as If-Match on subsequent updates and deletes. This way MS Graph will reject writes with 412 (instead of
+// silently overwriting changes) if the entity has been changed outside of Terraform since it has been read for the plan.
+
+const privateKeyETag = "generic.ETag"
+
+const errSummaryPreconditionFailed = "Entity changed outside Terraform since plan"
+
+type etagPrivateData struct {
+	ETag string
+}
+
+func getETagFromRaw(rawVal map[string]any) string {
+	if rawVal == nil {
+		return ""
+	}
+	if etag, ok := rawVal["@odata.etag"].(string); ok {
+		return etag
+	}
+	// also see ConvertOdataRawToTerraform
+	if items, ok := rawVal["value"].([]any); ok && len(rawVal) <= 3 && len(items) == 1 {
+		if singleValue, ok := items[0].(map[string]any); ok {
+			return getETagFromRaw(singleValue)
+		}
+	}
+	return ""
+}
+
+// setPrivateETag saves the ETag to private state or removes it from there if the ETag is empty.
+func setPrivateETag(ctx context.Context, diags *diag.Diagnostics, private PrivateDataGetSetter, etag string) {
+
+	if private == nil {
+		return
+	}
+
+	var privateDataJson []byte
+	if etag != "" {
+		var err error
+		privateDataJson, err = json.Marshal(etagPrivateData{ETag: etag})
+		if err != nil {
+			diags.AddError("Error saving ETag to private state", err.Error())
+			return
+		}
+	}
+	tflog.Trace(ctx, "setPrivateETag", map[string]any{"etag": etag})
+	diags.Append(private.SetKey(ctx, privateKeyETag, privateDataJson)...)
+}
+
+func getPrivateETag(ctx context.Context, diags *diag.Diagnostics, private PrivateDataGetSetter) string {
+
+	if private == nil {
+		return ""
+	}
+
+	privateBytes, diags2 := private.GetKey(ctx, privateKeyETag)
+	diags.Append(diags2...)
+	if diags.HasError() || len(privateBytes) == 0 {
+		return ""
+	}
+
+	privateData := etagPrivateData{}
+	if err := json.Unmarshal(privateBytes, &privateData); err != nil {
+		// just do not use If-Match at all
+		diags.AddWarning("Error reading ETag from private state", err.Error())
+		return ""
+	}
+	return privateData.ETag
+}
+
+func addPreconditionFailedError(diags *diag.Diagnostics, uriEntity string, etag string) {
+	diags.AddError(errSummaryPreconditionFailed,
+		fmt.Sprintf("The entity %q has been changed in MS Graph since it has been read for the plan (ETag %s does not match anymore). "+
+			"To not overwrite these changes, nothing has been written. Please refresh and plan again.", uriEntity, etag))
+}
@@ -0,0 +1,310 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+//
+// Existing entities can be exported as Terraform configuration (see ExportHcl) along with import blocks. Entities get
+// listed using the (plural) base URI of the resource and then read one by one exactly like GenericResource.Read does,
+// so all of ReadOptions (including extra requests) and the middlewares apply.
+//
+
+// ExportedEntity is an entity read from MS Graph to be exported as Terraform configuration.
+type ExportedEntity struct {
+	ImportId string
+	State    tfsdk.State
+}
+
+// IsExportable returns true if all entities of the resource can be listed and imported by their id alone, i.e. if the
+// resource is neither a singleton nor a child of other entities. The resource must have been configured already.
+func (r *GenericResource) IsExportable() bool {
+	ap := &r.AccessParams
+	return len(ap.ParentEntities) == 0 && !ap.UriNoId && ap.UriSuffix == "" && !ap.ReadOptions.DataSource.Plural.NoDataSource
+}
+
+// ExportEntities reads all entities of the resource from MS Graph.
+func (r *GenericResource) ExportEntities(ctx context.Context, diags *diag.Diagnostics) []ExportedEntity {
+
+	if !r.IsExportable() {
+		diags.AddError("Resource cannot be exported", fmt.Sprintf("Entities of %s cannot be listed", r.TypeNameSuffix))
+		return nil
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	diags.Append(schemaResp.Diagnostics...)
+	if diags.HasError() {
+		return nil
+	}
+
+	ap := &r.AccessParams
+	ctx = ap.ApiVersionContext(ctx, diags, "")
+	uri := ap.GetBaseUri(ctx, diags, "", nil)
+	if diags.HasError() {
+		return nil
+	}
+
+	var odataSelect []string
+	if !ap.ReadOptions.DataSource.Plural.NoSelectSupport {
+		odataSelect = []string{ap.EntityId.AttrNameGraph}
+	}
+	rawVal := ap.ReadRaw3(ctx, diags, uri, "", ap.ReadOptions.ODataFilter, odataSelect, "", 0, false)
+	if diags.HasError() {
+		return nil
+	}
+	items, ok := rawVal["value"].([]any)
+	if !ok {
+		diags.AddError(fmt.Sprintf("Error listing entities of %q", uri.Entity), "MS Graph did not return a collection")
+		return nil
+	}
+
+	result := make([]ExportedEntity, 0, len(items))
+	for _, item := range items {
+		itemMap, _ := item.(map[string]any)
+		id, _ := itemMap[ap.EntityId.AttrNameGraph].(string)
+		if id == "" {
+			diags.AddError(fmt.Sprintf("Error listing entities of %q", uri.Entity), "MS Graph returned an entity without id")
+			return nil
+		}
+
+		tfVal := r.readEntityById(ctx, diags, schemaResp.Schema, id, nil)
+		if diags.HasError() {
+			return nil
+		}
+		if tfVal.IsNull() {
+			// deleted in the meantime
+			continue
+		}
+
+		result = append(result, ExportedEntity{
+			ImportId: id,
+			State:    tfsdk.State{Schema: schemaResp.Schema, Raw: tfVal},
+		})
+	}
+
+	return result
+}
+
+// readEntityById reads the entity with the given id (and the parent ids read from parentIdAttributer, if any) exactly
+// like GenericResource.Read does after an import. Returns a null value if the entity does not exist.
+func (r *GenericResource) readEntityById(ctx context.Context, diags *diag.Diagnostics, s rsschema.Schema, id string,
+	parentIdAttributer GetAttributer) tftypes.Value {
+
+	ap := &r.AccessParams
+
+	// the state only consists of the id (and parent ids) just like after an import
+	idState := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	diags.Append(idState.SetAttribute(ctx, path.Root(ap.EntityId.AttrNameTf), id)...)
+	if parentIdAttributer != nil {
+		ap.PopulateStateParentIdsFromRequest(ctx, diags, &idState, parentIdAttributer)
+	}
+	if diags.HasError() {
+		return tftypes.Value{}
+	}
+
+	tfVal := ap.ReadSingleCompleteTf2(ctx, diags, s, ap.ReadOptions, "", &idState, "", true, &idState, nil)
+	if diags.HasError() || tfVal.IsNull() {
+		return tfVal
+	}
+
+	state := tfsdk.State{Schema: s, Raw: tfVal}
+	ap.PopulateStateParentIdsFromRequest(ctx, diags, &state, idState)
+	return state.Raw
+}
+
+// Interfaces of rsschema attributes to access their default values, see attributeDefaultValue
+type (
+	withStringDefault interface {
+		StringDefaultValue() defaults.String
+		StringPlanModifiers() []planmodifier.String
+	}
+	withBoolDefault interface {
+		BoolDefaultValue() defaults.Bool
+		BoolPlanModifiers() []planmodifier.Bool
+	}
+	withInt64Default interface {
+		Int64DefaultValue() defaults.Int64
+		Int64PlanModifiers() []planmodifier.Int64
+	}
+	withFloat64Default interface {
+		Float64DefaultValue() defaults.Float64
+		Float64PlanModifiers() []planmodifier.Float64
+	}
+	withObjectDefault interface {
+		ObjectDefaultValue() defaults.Object
+		ObjectPlanModifiers() []planmodifier.Object
+	}
+	withListDefault interface {
+		ListDefaultValue() defaults.List
+		ListPlanModifiers() []planmodifier.List
+	}
+	withSetDefault interface {
+		SetDefaultValue() defaults.Set
+		SetPlanModifiers() []planmodifier.Set
+	}
+	withMapDefault interface {
+		MapDefaultValue() defaults.Map
+		MapPlanModifiers() []planmodifier.Map
+	}
+)
+
+// attributeDefaultValue returns the value the attribute gets if it has not been configured, i.e. the value of its
+// Default or of its plan modifier from package wpdefaultvaluemodifier. Returns false if there is no default value.
+func attributeDefaultValue(ctx context.Context, diags *diag.Diagnostics, s rsschema.Schema, p path.Path, attribute rsschema.Attribute) (tftypes.Value, bool) {
+
+	config := tfsdk.Config{Schema: s}
+	var defaultValue attr.Value
+
+	switch a := attribute.(type) {
+	case withStringDefault:
+		defaultValue = defaultValueOf(diags, a.StringDefaultValue(), a.StringPlanModifiers(),
+			func(d defaults.String) (attr.Value, diag.Diagnostics) {
+				resp := defaults.StringResponse{}
+				d.DefaultString(ctx, defaults.StringRequest{Path: p}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			},
+			func(m planmodifier.String) (attr.Value, diag.Diagnostics) {
+				resp := planmodifier.StringResponse{PlanValue: types.StringNull()}
+				m.PlanModifyString(ctx, planmodifier.StringRequest{Path: p, Config: config, ConfigValue: types.StringNull()}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			})
+	case withBoolDefault:
+		defaultValue = defaultValueOf(diags, a.BoolDefaultValue(), a.BoolPlanModifiers(),
+			func(d defaults.Bool) (attr.Value, diag.Diagnostics) {
+				resp := defaults.BoolResponse{}
+				d.DefaultBool(ctx, defaults.BoolRequest{Path: p}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			},
+			func(m planmodifier.Bool) (attr.Value, diag.Diagnostics) {
+				resp := planmodifier.BoolResponse{PlanValue: types.BoolNull()}
+				m.PlanModifyBool(ctx, planmodifier.BoolRequest{Path: p, Config: config, ConfigValue: types.BoolNull()}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			})
+	case withInt64Default:
+		defaultValue = defaultValueOf(diags, a.Int64DefaultValue(), a.Int64PlanModifiers(),
+			func(d defaults.Int64) (attr.Value, diag.Diagnostics) {
+				resp := defaults.Int64Response{}
+				d.DefaultInt64(ctx, defaults.Int64Request{Path: p}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			},
+			func(m planmodifier.Int64) (attr.Value, diag.Diagnostics) {
+				resp := planmodifier.Int64Response{PlanValue: types.Int64Null()}
+				m.PlanModifyInt64(ctx, planmodifier.Int64Request{Path: p, Config: config, ConfigValue: types.Int64Null()}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			})
+	case withFloat64Default:
+		defaultValue = defaultValueOf(diags, a.Float64DefaultValue(), a.Float64PlanModifiers(),
+			func(d defaults.Float64) (attr.Value, diag.Diagnostics) {
+				resp := defaults.Float64Response{}
+				d.DefaultFloat64(ctx, defaults.Float64Request{Path: p}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			},
+			func(m planmodifier.Float64) (attr.Value, diag.Diagnostics) {
+				resp := planmodifier.Float64Response{PlanValue: types.Float64Null()}
+				m.PlanModifyFloat64(ctx, planmodifier.Float64Request{Path: p, Config: config, ConfigValue: types.Float64Null()}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			})
+	case withObjectDefault:
+		attrTypes := a.(rsschema.Attribute).GetType().(attr.TypeWithAttributeTypes).AttributeTypes()
+		defaultValue = defaultValueOf(diags, a.ObjectDefaultValue(), a.ObjectPlanModifiers(),
+			func(d defaults.Object) (attr.Value, diag.Diagnostics) {
+				resp := defaults.ObjectResponse{}
+				d.DefaultObject(ctx, defaults.ObjectRequest{Path: p}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			},
+			func(m planmodifier.Object) (attr.Value, diag.Diagnostics) {
+				resp := planmodifier.ObjectResponse{PlanValue: types.ObjectNull(attrTypes)}
+				m.PlanModifyObject(ctx, planmodifier.ObjectRequest{Path: p, Config: config, ConfigValue: types.ObjectNull(attrTypes)}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			})
+	case withListDefault:
+		elemType := a.(rsschema.Attribute).GetType().(attr.TypeWithElementType).ElementType()
+		defaultValue = defaultValueOf(diags, a.ListDefaultValue(), a.ListPlanModifiers(),
+			func(d defaults.List) (attr.Value, diag.Diagnostics) {
+				resp := defaults.ListResponse{}
+				d.DefaultList(ctx, defaults.ListRequest{Path: p}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			},
+			func(m planmodifier.List) (attr.Value, diag.Diagnostics) {
+				resp := planmodifier.ListResponse{PlanValue: types.ListNull(elemType)}
+				m.PlanModifyList(ctx, planmodifier.ListRequest{Path: p, Config: config, ConfigValue: types.ListNull(elemType)}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			})
+	case withSetDefault:
+		elemType := a.(rsschema.Attribute).GetType().(attr.TypeWithElementType).ElementType()
+		defaultValue = defaultValueOf(diags, a.SetDefaultValue(), a.SetPlanModifiers(),
+			func(d defaults.Set) (attr.Value, diag.Diagnostics) {
+				resp := defaults.SetResponse{}
+				d.DefaultSet(ctx, defaults.SetRequest{Path: p}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			},
+			func(m planmodifier.Set) (attr.Value, diag.Diagnostics) {
+				resp := planmodifier.SetResponse{PlanValue: types.SetNull(elemType)}
+				m.PlanModifySet(ctx, planmodifier.SetRequest{Path: p, Config: config, ConfigValue: types.SetNull(elemType)}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			})
+	case withMapDefault:
+		elemType := a.(rsschema.Attribute).GetType().(attr.TypeWithElementType).ElementType()
+		defaultValue = defaultValueOf(diags, a.MapDefaultValue(), a.MapPlanModifiers(),
+			func(d defaults.Map) (attr.Value, diag.Diagnostics) {
+				resp := defaults.MapResponse{}
+				d.DefaultMap(ctx, defaults.MapRequest{Path: p}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			},
+			func(m planmodifier.Map) (attr.Value, diag.Diagnostics) {
+				resp := planmodifier.MapResponse{PlanValue: types.MapNull(elemType)}
+				m.PlanModifyMap(ctx, planmodifier.MapRequest{Path: p, Config: config, ConfigValue: types.MapNull(elemType)}, &resp)
+				return resp.PlanValue, resp.Diagnostics
+			})
+	}
+
+	if diags.HasError() || defaultValue == nil || defaultValue.IsNull() {
+		return tftypes.Value{}, false
+	}
+	tfVal, err := defaultValue.ToTerraformValue(ctx)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error converting default value of %s", p), err.Error())
+		return tftypes.Value{}, false
+	}
+	return tfVal, true
+}
+
+// defaultValueOf returns the value of the Default d (unless nil) or, taking precedence, of the last plan modifier from
+// package wpdefaultvaluemodifier of an attribute of any kind. runDefault and runModifier must run the Default or plan
+// modifier of the kind for a null config value.
+func defaultValueOf[D, M any](diags *diag.Diagnostics, d D, modifiers []M,
+	runDefault func(D) (attr.Value, diag.Diagnostics), runModifier func(M) (attr.Value, diag.Diagnostics)) attr.Value {
+
+	var result attr.Value
+	if any(d) != nil {
+		value, diags2 := runDefault(d)
+		diags.Append(diags2...)
+		result = value
+	}
+	for _, m := range modifiers {
+		if _, ok := any(m).(wpdefaultvaluemodifier.DefaultValuePlanModifier); ok {
+			value, diags2 := runModifier(m)
+			diags.Append(diags2...)
+			result = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,252 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const hclIndent = "  "
+
+// ExportHcl returns an import block and a resource block (with the given type and name) configuring the entity. All
+// attributes that do not need to be configured get omitted, i.e. computed ones, Terraform only ones, write-only ones
+// and the ones having their default value.
+func ExportHcl(ctx context.Context, diags *diag.Diagnostics, typeName string, name string, entity ExportedEntity) string {
+	s, ok := entity.State.Schema.(rsschema.Schema)
+	if !ok {
+		diags.AddError("Error exporting HCL", fmt.Sprintf("Schema is not a resource schema but %T", entity.State.Schema))
+		return ""
+	}
+	e := hclExporter{ctx: ctx, diags: diags, schema: s}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "import {\n%sto = %s.%s\n%sid = %s\n}\n\n", hclIndent, typeName, name, hclIndent, hclString(entity.ImportId))
+	fmt.Fprintf(&b, "resource %s %s {\n", hclString(typeName), hclString(name))
+	e.writeAttributes(&b, hclIndent, s.Attributes, entity.State.Raw, path.Empty())
+	b.WriteString("}\n")
+	return b.String()
+}
+
+type hclExporter struct {
+	ctx    context.Context
+	diags  *diag.Diagnostics
+	schema rsschema.Schema
+}
+
+// writeAttributes writes all attributes of the object value that need to be configured and returns their count.
+func (e *hclExporter) writeAttributes(b *strings.Builder, indent string, attributes map[string]rsschema.Attribute,
+	val tftypes.Value, p path.Path) int {
+
+	values := map[string]tftypes.Value{}
+	if err := val.As(&values); err != nil {
+		e.diags.AddError(fmt.Sprintf("Error exporting HCL of %s", p), err.Error())
+		return 0
+	}
+
+	count := 0
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		attribute := attributes[name]
+		v := values[name]
+		if isResourceOnlyAttribute(attribute) || (attribute.IsComputed() && !attribute.IsOptional() && !attribute.IsRequired()) ||
+			v.IsNull() || !v.IsKnown() {
+			continue
+		}
+
+		attributePath := p.AtName(name)
+		defaultValue, hasDefault := attributeDefaultValue(e.ctx, e.diags, e.schema, attributePath, attribute)
+		if e.diags.HasError() {
+			return count
+		}
+		if hasDefault && defaultValue.Equal(v) {
+			continue
+		}
+
+		hcl, empty := e.attributeValueHcl(indent, attribute, v, attributePath)
+		if e.diags.HasError() {
+			return count
+		}
+		// an object left empty will get its default value (including the defaults of its attributes) again
+		if empty && hasDefault {
+			continue
+		}
+
+		fmt.Fprintf(b, "%s%s = %s\n", indent, name, hcl)
+		count++
+	}
+	return count
+}
+
+// attributeValueHcl returns the HCL of the value of the attribute and whether it is an object without any attributes
+// that need to be configured.
+func (e *hclExporter) attributeValueHcl(indent string, attribute rsschema.Attribute, v tftypes.Value, p path.Path) (string, bool) {
+
+	nestedAttribute, ok := attribute.(rsschema.NestedAttribute)
+	if !ok {
+		return valueHcl(indent, v), false
+	}
+	attributes := make(map[string]rsschema.Attribute)
+	for name, a := range nestedAttribute.GetNestedObject().GetAttributes() {
+		attributes[name] = a.(rsschema.Attribute)
+	}
+
+	objectHcl := func(indent string, v tftypes.Value, p path.Path) (string, bool) {
+		var b strings.Builder
+		if e.writeAttributes(&b, indent+hclIndent, attributes, v, p) == 0 {
+			return "{}", true
+		}
+		return "{\n" + b.String() + indent + "}", false
+	}
+
+	var b strings.Builder
+	switch v.Type().(type) {
+	case tftypes.Object:
+		return objectHcl(indent, v, p)
+
+	case tftypes.List, tftypes.Set:
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			e.diags.AddError(fmt.Sprintf("Error exporting HCL of %s", p), err.Error())
+			return "", false
+		}
+		if len(elems) == 0 {
+			return "[]", false
+		}
+		b.WriteString("[\n")
+		for i, elem := range elems {
+			elemPath := p.AtListIndex(i)
+			if _, ok := v.Type().(tftypes.Set); ok {
+				elemPath = p.AtSetValue(e.setElementValue(attribute, elem, p))
+			}
+			elemHcl, _ := objectHcl(indent+hclIndent, elem, elemPath)
+			fmt.Fprintf(&b, "%s%s%s,\n", indent, hclIndent, elemHcl)
+		}
+		b.WriteString(indent + "]")
+		return b.String(), false
+
+	case tftypes.Map:
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			e.diags.AddError(fmt.Sprintf("Error exporting HCL of %s", p), err.Error())
+			return "", false
+		}
+		if len(elems) == 0 {
+			return "{}", false
+		}
+		b.WriteString("{\n")
+		for _, key := range slices.Sorted(maps.Keys(elems)) {
+			elemHcl, _ := objectHcl(indent+hclIndent, elems[key], p.AtMapKey(key))
+			fmt.Fprintf(&b, "%s%s%s = %s\n", indent, hclIndent, hclString(key), elemHcl)
+		}
+		b.WriteString(indent + "}")
+		return b.String(), false
+	}
+
+	e.diags.AddError(fmt.Sprintf("Error exporting HCL of %s", p), fmt.Sprintf("Unsupported type of nested attribute: %s", v.Type()))
+	return "", false
+}
+
+// setElementValue converts the element of a set to a framework value (as required to build a path to it).
+func (e *hclExporter) setElementValue(attribute rsschema.Attribute, elem tftypes.Value, p path.Path) attr.Value {
+	elemValue, err := attribute.GetType().(attr.TypeWithElementType).ElementType().ValueFromTerraform(e.ctx, elem)
+	if err != nil {
+		e.diags.AddError(fmt.Sprintf("Error exporting HCL of %s", p), err.Error())
+	}
+	return elemValue
+}
+
+// valueHcl returns the HCL of a value not described by any (nested) attributes.
+func valueHcl(indent string, v tftypes.Value) string {
+
+	if v.IsNull() {
+		return "null"
+	}
+
+	switch v.Type().(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elems []tftypes.Value
+		_ = v.As(&elems)
+		elemsHcl := make([]string, 0, len(elems))
+		for _, elem := range elems {
+			elemsHcl = append(elemsHcl, valueHcl(indent, elem))
+		}
+		return "[" + strings.Join(elemsHcl, ", ") + "]"
+
+	case tftypes.Map, tftypes.Object:
+		var elems map[string]tftypes.Value
+		_ = v.As(&elems)
+		if len(elems) == 0 {
+			return "{}"
+		}
+		_, isObject := v.Type().(tftypes.Object)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, key := range slices.Sorted(maps.Keys(elems)) {
+			keyHcl := key
+			if !isObject {
+				keyHcl = hclString(key)
+			}
+			fmt.Fprintf(&b, "%s%s%s = %s\n", indent, hclIndent, keyHcl, valueHcl(indent+hclIndent, elems[key]))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	}
+
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		_ = v.As(&s)
+		return hclString(s)
+	case v.Type().Is(tftypes.Bool):
+		var bv bool
+		_ = v.As(&bv)
+		return fmt.Sprint(bv)
+	case v.Type().Is(tftypes.Number):
+		var n big.Float
+		_ = v.As(&n)
+		return n.Text('f', -1)
+	}
+
+	return "null"
+}
+
+// hclString returns the string as quoted HCL template (with template sequences escaped).
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '$', '%':
+			b.WriteRune(r)
+			if strings.HasPrefix(s[i+1:], "{") {
+				b.WriteRune(r)
+			}
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,189 @@
+// Package generictest provides a harness to test resources based on generic.GenericResource against the in-process
+// fake MS Graph of package graphmock, i.e. without the need for any tenant. Test cases get run by terraform-plugin-testing
+// (using Terraform itself) with a provider serving only the resource under test.
+package generictest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// ProviderTypeName is the type name of the provider used for testing.
+const ProviderTypeName = "microsoft365wp"
+
+var (
+	graphOnce   sync.Once
+	graphServer *graphmock.Server
+	graphClient *msgraph.Client
+)
+
+// Graph returns the fake MS Graph server shared by all tests of a test binary. generic.AccessParams only picks up the
+// MS Graph client once, so all resources need to use the same client (and therefore the same server). Hence tests
+// using this package must not run in parallel.
+func Graph() *graphmock.Server {
+	graphOnce.Do(func() {
+		graphServer = graphmock.NewServer()
+		c := msgraph.NewClient(msgraph.VersionBeta)
+		c.Endpoint = graphServer.URL
+		c.RetryableClient.RetryWaitMin = 10 * time.Millisecond
+		c.RetryableClient.RetryWaitMax = 100 * time.Millisecond
+		c.RetryableClient.RetryMax = 2
+		c.Batcher = msgraph.NewBatcher()
+		graphClient = &c
+	})
+	return graphServer
+}
+
+// Client returns the MS Graph client pointing to the server returned by Graph.
+func Client() *msgraph.Client {
+	Graph()
+	return graphClient
+}
+
+type TestCase struct {
+	Resource *generic.GenericResource
+	// Setup gets called with the (already reset) fake MS Graph server to register the entity sets used by the test
+	Setup func(*graphmock.Server)
+	// Steps are run as usual. ResourceName defaults to the one of the resource under test and import steps without an
+	// explicit id use the parent entity ids and the entity id from the state (like the import of GenericResource) and
+	// verify the imported state using the entity id.
+	Steps []tfresource.TestStep
+	// CheckDestroy gets called after the resource has been destroyed
+	CheckDestroy func(*graphmock.Server) error
+}
+
+// Test runs the test case using Terraform against the fake MS Graph server.
+func Test(t *testing.T, tc TestCase) {
+	t.Helper()
+
+	server := Graph()
+	server.Reset()
+	if tc.Setup != nil {
+		tc.Setup(server)
+	}
+
+	tc.Resource.AccessParams.InitializeGuarded(Client())
+	resourceName := ResourceName(tc.Resource)
+	steps := make([]tfresource.TestStep, len(tc.Steps))
+	for i, step := range tc.Steps {
+		if step.ResourceName == "" {
+			step.ResourceName = resourceName
+		}
+		if step.ImportState && step.ImportStateKind == tfresource.ImportCommandWithID &&
+			step.ImportStateId == "" && step.ImportStateIdFunc == nil {
+			step.ImportStateIdFunc = importStateIdFunc(tc.Resource)
+		}
+		if step.ImportStateVerify && step.ImportStateVerifyIdentifierAttribute == "" {
+			step.ImportStateVerifyIdentifierAttribute = tc.Resource.AccessParams.EntityId.AttrNameTf
+		}
+		steps[i] = step
+	}
+
+	testCase := tfresource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(tc.Resource),
+		Steps:                    steps,
+	}
+	if tc.CheckDestroy != nil {
+		testCase.CheckDestroy = func(*terraform.State) error {
+			return tc.CheckDestroy(server)
+		}
+	}
+	tfresource.UnitTest(t, testCase)
+}
+
+// ProviderFactories returns the factories of a provider serving the resources using the fake MS Graph server.
+func ProviderFactories(resources ...*generic.GenericResource) map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		ProviderTypeName: providerserver.NewProtocol6WithError(&testProvider{resources: resources}),
+	}
+}
+
+// ResourceName returns the address of the resource in configs returned by Config, e.g. "microsoft365wp_group.test".
+func ResourceName(r *generic.GenericResource) string {
+	return fmt.Sprintf("%s_%s.test", ProviderTypeName, r.TypeNameSuffix)
+}
+
+// Config returns a config containing the resource with the attributes (in HCL syntax).
+func Config(r *generic.GenericResource, attributes string) string {
+	return fmt.Sprintf("resource \"%s_%s\" \"test\" {\n%s\n}\n", ProviderTypeName, r.TypeNameSuffix, attributes)
+}
+
+// Attributes returns the attributes (in flatmap format, e.g. "some_list.0.name") of the resource under test.
+func Attributes(s *terraform.State) map[string]string {
+	for name, rs := range s.RootModule().Resources {
+		if strings.HasSuffix(name, ".test") && rs.Primary != nil {
+			return rs.Primary.Attributes
+		}
+	}
+	return map[string]string{}
+}
+
+// PreApply returns a plan check that calls f after planning but before applying (e.g. to change entities between plan
+// and apply). It is meant to be used in TestStep.ConfigPlanChecks.PreApply.
+func PreApply(f func(*graphmock.Server)) plancheck.PlanCheck {
+	return preApplyCheck(f)
+}
+
+type preApplyCheck func(*graphmock.Server)
+
+func (c preApplyCheck) CheckPlan(context.Context, plancheck.CheckPlanRequest, *plancheck.CheckPlanResponse) {
+	c(Graph())
+}
+
+func importStateIdFunc(r *generic.GenericResource) tfresource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		attributes := Attributes(s)
+		idComponents := []string{}
+		for _, pe := range r.AccessParams.ParentEntities {
+			idComponents = append(idComponents, attributes[pe.ParentIdField.String()])
+		}
+		idComponents = append(idComponents, attributes[r.AccessParams.EntityId.AttrNameTf])
+		return strings.Join(idComponents, "/"), nil
+	}
+}
+
+// testProvider serves the resources under test using the MS Graph client returned by Client.
+type testProvider struct {
+	resources []*generic.GenericResource
+}
+
+var _ provider.Provider = &testProvider{}
+
+func (p *testProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = ProviderTypeName
+}
+
+func (p *testProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {}
+
+func (p *testProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.ResourceData = Client()
+}
+
+func (p *testProvider) Resources(context.Context) []func() resource.Resource {
+	result := []func() resource.Resource{}
+	for _, r := range p.resources {
+		result = append(result, func() resource.Resource { return r })
+	}
+	return result
+}
+
+func (p *testProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
@@ -0,0 +1,60 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+//
+// The identity of a resource consists of the same attributes as the id used for importing, i.e. the ids of all
+// ParentEntities followed by the id of the entity itself (all using the same names as in the resource schema).
+//
+
+// identityPaths returns the paths of all attributes making up the identity (in the resource schema as well as in the
+// identity schema).
+func (ap *AccessParams) identityPaths() []path.Path {
+	result := []path.Path{}
+	for _, pe := range ap.ParentEntities {
+		result = append(result, pe.ParentIdField)
+	}
+	return append(result, path.Root(ap.EntityId.AttrNameTf))
+}
+
+// Defines the identity schema for the resource.
+func (r *GenericResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	r.AccessParams.InitializeGuarded(nil)
+
+	attributes := map[string]identityschema.Attribute{}
+	for _, p := range r.AccessParams.identityPaths() {
+		attributes[p.String()] = identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       fmt.Sprintf("Value of the `%s` attribute of the resource.", p),
+		}
+	}
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// setIdentity sets the identity from the (parent) id attributes of src. The identity will be nil if Terraform does not
+// support identities (or e.g. when being called by tests).
+func (ap *AccessParams) setIdentity(ctx context.Context, diags *diag.Diagnostics, identity *tfsdk.ResourceIdentity, src GetAttributer) {
+	if identity == nil {
+		return
+	}
+	for _, p := range ap.identityPaths() {
+		var value types.String
+		diags.Append(src.GetAttribute(ctx, p, &value)...)
+		if diags.HasError() {
+			return
+		}
+		diags.Append(identity.SetAttribute(ctx, path.Root(p.String()), value)...)
+	}
+}
@@ -0,0 +1,97 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+//
+// Instead of the id itself, entities can also be imported by the value of an attribute uniquely identifying them,
+// using an id of the form `displayName=Some Name` (or `display_name=Some Name`). The id then gets looked up using an
+// OData $filter. Besides `display_name`, resources can declare other key attributes using ImportOptions.KeyAttributes.
+//
+
+// parseImportKey checks if the (last) component of the import id is of the form `name=value` with name being a key
+// attribute of the resource and returns the MS Graph name of the attribute along with the value.
+func (r *GenericResource) parseImportKey(state tfsdk.State, idComponent string) (attrNameGraph string, value string, ok bool) {
+
+	ap := &r.AccessParams
+	if ap.UriNoId || ap.ReadOptions.DataSource.NoFilterSupport {
+		return "", "", false
+	}
+
+	name, value, found := strings.Cut(idComponent, "=")
+	if !found {
+		return "", "", false
+	}
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+
+	keyAttributes := append([]string{"display_name"}, ap.ImportOptions.KeyAttributes...)
+	for _, attrName := range keyAttributes {
+		attribute, ok := state.Schema.GetAttributes()[attrName].(rsschema.StringAttribute)
+		if !ok {
+			continue
+		}
+		attrNameGraph := (*ToFromGraphTranslator).GraphAttributeNameFromTerraformNameImpl(nil, attrName, attribute)
+		if name == attrName || name == attrNameGraph {
+			return attrNameGraph, value, true
+		}
+	}
+
+	return "", "", false
+}
+
+// readIdByImportKey returns the id of the one entity having the value for the key attribute. It fails if there is no or
+// more than one such entity.
+func (r *GenericResource) readIdByImportKey(ctx context.Context, diags *diag.Diagnostics, parentIdAttributer GetAttributer,
+	attrNameGraph string, value string) string {
+
+	ap := &r.AccessParams
+	ctx = ap.ApiVersionContext(ctx, diags, "")
+	uri := ap.GetBaseUri(ctx, diags, "", parentIdAttributer)
+	if diags.HasError() {
+		return ""
+	}
+
+	odataFilter := ap.ReadOptions.ODataFilter
+	dsFilterAppend(&odataFilter, fmt.Sprintf("%s eq '%s'", attrNameGraph, strings.ReplaceAll(value, "'", "''")), false)
+	var odataSelect []string
+	if !ap.ReadOptions.DataSource.Plural.NoSelectSupport {
+		odataSelect = []string{ap.EntityId.AttrNameGraph}
+	}
+	rawVal := ap.ReadRaw2(ctx, diags, uri, "", odataFilter, odataSelect, false)
+	if diags.HasError() {
+		return ""
+	}
+
+	errorSummary := fmt.Sprintf("Unable to import entity with %s '%s'", attrNameGraph, value)
+	items, ok := rawVal["value"].([]any)
+	if !ok {
+		diags.AddError(errorSummary, "MS Graph did not return a collection")
+		return ""
+	}
+	ids := []string{}
+	for _, item := range items {
+		itemMap, _ := item.(map[string]any)
+		if id, ok := itemMap[ap.EntityId.AttrNameGraph].(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		diags.AddError(errorSummary, fmt.Sprintf("No entity with %s '%s' found", attrNameGraph, value))
+		return ""
+	case 1:
+		return ids[0]
+	default:
+		diags.AddError(errorSummary,
+			fmt.Sprintf("%d entities with %s '%s' found (ids: %s), please import using the id instead", len(ids), attrNameGraph, value, strings.Join(ids, ", ")))
+		return ""
+	}
+}
@@ -0,0 +1,145 @@
+package generic
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &GenericListResource{}
+	_ list.ListResourceWithConfigure = &GenericListResource{}
+)
+
+// GenericListResource is the list resource implementation (used by `terraform query`). It lists entities exactly like
+// the plural data source of the resource (i.e. accepting the same parent id and filter attributes) and returns their
+// identities along with the complete resources (if requested).
+type GenericListResource struct {
+	Resource *GenericResource
+
+	dataSource GenericDataSourcePlural
+}
+
+// SupportsList returns true if the entities of the resource can be listed, i.e. if a plural data source and a list
+// resource can be created for it.
+func (r *GenericResource) SupportsList() bool {
+	r.AccessParams.InitializeGuarded(nil)
+	return !r.AccessParams.ReadOptions.DataSource.Plural.NoDataSource
+}
+
+func CreateGenericListResourceFromResource(genericResource *GenericResource) *GenericListResource {
+	return &GenericListResource{
+		Resource:   genericResource,
+		dataSource: CreateGenericDataSourcePluralFromResource(genericResource, ""),
+	}
+}
+
+// Metadata returns the list resource type name (which is the same as the one of the resource).
+func (l *GenericListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	l.Resource.Metadata(ctx, req, resp)
+}
+
+// Configure adds the provider configured MSGraph client to the list resource.
+func (l *GenericListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	l.Resource.Configure(ctx, req, resp)
+	l.dataSource.AccessParams.InitializeGuarded(req.ProviderData)
+}
+
+// ListResourceConfigSchema defines the schema for the list block, i.e. all attributes of the plural data source but
+// its result attribute.
+func (l *GenericListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := map[string]listschema.Attribute{}
+	for name, attribute := range l.dataSource.SpecificSchema.Attributes {
+		if name != l.dataSource.ResultAttributeName {
+			attributes[name] = attribute
+		}
+	}
+	resp.Schema = listschema.Schema{
+		Attributes:          attributes,
+		Description:         l.dataSource.SpecificSchema.Description,
+		MarkdownDescription: l.dataSource.SpecificSchema.MarkdownDescription,
+	}
+}
+
+// List returns the identities (and optionally the complete resources) of all entities matching the config.
+func (l *GenericListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+
+	diags := diag.Diagnostics{}
+
+	val := l.dataSource.readTf(ctx, &diags, req.Config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	var items types.List
+	diags.Append(tfsdk.State{Schema: l.dataSource.SpecificSchema, Raw: val}.GetAttribute(ctx, path.Root(l.dataSource.ResultAttributeName), &items)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	if req.IncludeResource {
+		l.Resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		if schemaResp.Diagnostics.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(schemaResp.Diagnostics)
+			return
+		}
+	}
+
+	ap := &l.Resource.AccessParams
+	ctx = ap.ApiVersionContext(ctx, &diags, "")
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		count := int64(0)
+		for _, item := range items.Elements() {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			attributes := item.(types.Object).Attributes()
+			id, _ := attributes[ap.EntityId.AttrNameTf].(types.String)
+
+			result.DisplayName = id.ValueString()
+			for _, name := range []string{"display_name", "name"} {
+				if value, ok := attributes[name].(types.String); ok && value.ValueString() != "" {
+					result.DisplayName = value.ValueString()
+					break
+				}
+			}
+
+			for _, pe := range ap.ParentEntities {
+				var parentId types.String
+				result.Diagnostics.Append(req.Config.GetAttribute(ctx, pe.ParentIdField, &parentId)...)
+				result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(pe.ParentIdField.String()), parentId)...)
+			}
+			result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(ap.EntityId.AttrNameTf), id)...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				tfVal := l.Resource.readEntityById(ctx, &result.Diagnostics, schemaResp.Schema, id.ValueString(), req.Config)
+				if !result.Diagnostics.HasError() && tfVal.IsNull() {
+					// deleted in the meantime
+					continue
+				}
+				result.Resource.Raw = tfVal
+			}
+
+			if !push(result) {
+				return
+			}
+			count++
+		}
+	}
+}
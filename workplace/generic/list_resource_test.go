@@ -0,0 +1,115 @@
+package generic_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestListResource(t *testing.T) {
+	ctx := t.Context()
+
+	newResource := func(typeNameSuffix string, isSingleton bool) *generic.GenericResource {
+		return &generic.GenericResource{
+			TypeNameSuffix: typeNameSuffix,
+			SpecificSchema: schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":           schema.StringAttribute{Computed: true},
+					"display_name": schema.StringAttribute{Required: true},
+					"file_name":    schema.StringAttribute{Optional: true},
+				},
+			},
+			AccessParams: generic.AccessParams{BaseUri: "/testScripts", IsSingleton: isSingleton},
+		}
+	}
+
+	if newResource("test_singleton", true).SupportsList() {
+		t.Errorf("singletons must not have list resources")
+	}
+	r := newResource("test_script", false)
+	if !r.SupportsList() {
+		t.Fatalf("resource does not support list")
+	}
+	l := generic.CreateGenericListResourceFromResource(r)
+
+	schemaResp := list.ListResourceSchemaResponse{}
+	l.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &schemaResp)
+	if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Errorf("invalid list resource schema: %s", generic.DiagsError(diags))
+	}
+
+	s := generictest.Graph()
+	s.Reset()
+	es := s.AddEntitySet("/testScripts")
+	id1 := es.Put(map[string]any{"displayName": "Script 1", "fileName": "one.ps1"})
+	id2 := es.Put(map[string]any{"displayName": "Script 2", "fileName": "two.ps1"})
+
+	l.Configure(ctx, resource.ConfigureRequest{ProviderData: generictest.Client()}, &resource.ConfigureResponse{})
+
+	results := listEntities(ctx, t, l, true, 0)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for i, want := range []struct{ id, displayName, fileName string }{{id1, "Script 1", "one.ps1"}, {id2, "Script 2", "two.ps1"}} {
+		result := results[i]
+		if result.DisplayName != want.displayName {
+			t.Errorf("result %d: expected display name %q, got %q", i, want.displayName, result.DisplayName)
+		}
+		var id, fileName types.String
+		result.Identity.GetAttribute(ctx, path.Root("id"), &id)
+		result.Resource.GetAttribute(ctx, path.Root("file_name"), &fileName)
+		if id.ValueString() != want.id || fileName.ValueString() != want.fileName {
+			t.Errorf("result %d: expected identity id %q and file_name %q, got %s and %s", i, want.id, want.fileName, id, fileName)
+		}
+	}
+
+	results = listEntities(ctx, t, l, false, 1)
+	if len(results) != 1 || !results[0].Resource.Raw.IsNull() {
+		t.Errorf("expected a single result without resource, got %v", results)
+	}
+}
+
+func listEntities(ctx context.Context, t *testing.T, l *generic.GenericListResource, includeResource bool, limit int64) []list.ListResult {
+	t.Helper()
+
+	schemaResp := list.ListResourceSchemaResponse{}
+	l.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &schemaResp)
+	rsSchemaResp := resource.SchemaResponse{}
+	l.Resource.Schema(ctx, resource.SchemaRequest{}, &rsSchemaResp)
+	identitySchemaResp := resource.IdentitySchemaResponse{}
+	l.Resource.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	nullValues := make(map[string]tftypes.Value)
+	for name, typ := range objectType.AttributeTypes {
+		nullValues[name] = tftypes.NewValue(typ, nil)
+	}
+	req := list.ListRequest{
+		Config:                 tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nullValues)},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         rsSchemaResp.Schema,
+		ResourceIdentitySchema: identitySchemaResp.IdentitySchema,
+	}
+	stream := list.ListResultsStream{}
+	l.List(ctx, req, &stream)
+
+	results := slices.Collect(stream.Results)
+	for _, result := range results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("List: %s", generic.DiagsError(result.Diagnostics))
+		}
+	}
+	return results
+}
@@ -0,0 +1,121 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+//
+// Some resources manage the entities of the same entity set in different ways (e.g.
+// device_management_configuration_policy and device_management_configuration_policy_json), so the state of any
+// resource of this provider can be moved (using a `moved` block) to any other resource sharing the same BaseUri, entity
+// id and parent entities. Only the (parent) ids get taken from the source state, all other attributes get read from MS
+// Graph again (just like after an import).
+//
+
+var (
+	resourcesByTypeName      = map[string]*GenericResource{}
+	resourcesByTypeNameMutex sync.Mutex
+)
+
+// registerResourceType remembers the resource under its type name so it can be found as source of a moved state.
+func (r *GenericResource) registerResourceType(providerTypeName string, typeName string) {
+	resourcesByTypeNameMutex.Lock()
+	defer resourcesByTypeNameMutex.Unlock()
+	r.providerTypeName = providerTypeName
+	resourcesByTypeName[typeName] = r
+}
+
+// isMoveStateSourceOf checks if the state of source can be moved to r, i.e. if both resources address the same entities.
+func (r *GenericResource) isMoveStateSourceOf(source *GenericResource) bool {
+	if source == r {
+		return false
+	}
+	source.AccessParams.InitializeGuarded(nil)
+	r.AccessParams.InitializeGuarded(nil)
+	if source.AccessParams.BaseUri != r.AccessParams.BaseUri || source.AccessParams.IsSingleton || r.AccessParams.IsSingleton {
+		return false
+	}
+	pathStrings := func(paths []path.Path) []string {
+		result := []string{}
+		for _, p := range paths {
+			result = append(result, p.String())
+		}
+		return result
+	}
+	return slices.Equal(pathStrings(source.AccessParams.identityPaths()), pathStrings(r.AccessParams.identityPaths()))
+}
+
+// MoveState returns a single state mover accepting the state of all sibling resources (see isMoveStateSourceOf).
+func (r *GenericResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: r.moveState},
+	}
+}
+
+func (r *GenericResource) moveState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+
+	resourcesByTypeNameMutex.Lock()
+	source := resourcesByTypeName[req.SourceTypeName]
+	resourcesByTypeNameMutex.Unlock()
+
+	// leaving the target state unset tells the framework that the source is not supported
+	if source == nil || !strings.HasSuffix(req.SourceProviderAddress, "/"+r.providerTypeName) || !r.isMoveStateSourceOf(source) {
+		return
+	}
+
+	errorSummary := fmt.Sprintf("Unable to move state from %s", req.SourceTypeName)
+	if req.SourceRawState == nil || req.SourceRawState.JSON == nil {
+		resp.Diagnostics.AddError(errorSummary, "Source state is not available as JSON")
+		return
+	}
+
+	// only read the (parent) ids from the source state, so this works for any of its schema versions
+	idPaths := r.AccessParams.identityPaths()
+	idAttributes := map[string]rsschema.Attribute{}
+	for _, p := range idPaths {
+		idAttributes[p.String()] = rsschema.StringAttribute{Required: true}
+	}
+	idSchema := rsschema.Schema{Attributes: idAttributes}
+	idRaw, err := req.SourceRawState.UnmarshalWithOpts(idSchema.Type().TerraformType(ctx),
+		tfprotov6.UnmarshalOpts{ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true}})
+	if err != nil {
+		resp.Diagnostics.AddError(errorSummary, fmt.Sprintf("Unable to read the ids from the source state: %s", err.Error()))
+		return
+	}
+	idState := tfsdk.State{Schema: idSchema, Raw: idRaw}
+	var id string
+	resp.Diagnostics.Append(idState.GetAttribute(ctx, path.Root(r.AccessParams.EntityId.AttrNameTf), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.AccessParams.contextWithTimeout(ctx, &resp.Diagnostics, resp.TargetState, "read")
+	defer cancel()
+	ctx = r.AccessParams.ApiVersionContext(ctx, &resp.Diagnostics, "")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tfVal := r.readEntityById(ctx, &resp.Diagnostics, resp.TargetState.Schema.(rsschema.Schema), id, idState)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if tfVal.IsNull() {
+		resp.Diagnostics.AddError(errorSummary, fmt.Sprintf("Entity with id '%s' does not exist (anymore)", id))
+		return
+	}
+
+	resp.TargetState.Raw = tfVal
+	r.AccessParams.setIdentity(ctx, &resp.Diagnostics, resp.TargetIdentity, resp.TargetState)
+}
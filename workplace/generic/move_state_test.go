@@ -0,0 +1,95 @@
+package generic_test
+
+import (
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMoveState(t *testing.T) {
+	ctx := t.Context()
+
+	newResource := func(typeNameSuffix string, baseUri string) *generic.GenericResource {
+		r := &generic.GenericResource{
+			TypeNameSuffix: typeNameSuffix,
+			SpecificSchema: schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":           schema.StringAttribute{Computed: true},
+					"display_name": schema.StringAttribute{Required: true},
+				},
+			},
+			AccessParams: generic.AccessParams{BaseUri: baseUri},
+		}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "microsoft365wp"}, &resource.MetadataResponse{})
+		return r
+	}
+	newResource("test_move_source", "/testMoves")
+	newResource("test_move_other", "/testOthers")
+	r := newResource("test_move_target", "/testMoves")
+
+	s := generictest.Graph()
+	s.Reset()
+	id := s.AddEntitySet("/testMoves").Put(map[string]any{"displayName": "Current"})
+
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: generictest.Client()}, &resource.ConfigureResponse{})
+
+	move := func(sourceTypeName string, sourceState string) resource.MoveStateResponse {
+		schemaResp := resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		identitySchemaResp := resource.IdentitySchemaResponse{}
+		r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+		req := resource.MoveStateRequest{
+			SourceProviderAddress: "registry.terraform.io/terraprovider/microsoft365wp",
+			SourceTypeName:        sourceTypeName,
+			SourceRawState:        &tfprotov6.RawState{JSON: []byte(sourceState)},
+		}
+		resp := resource.MoveStateResponse{
+			TargetState: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+			TargetIdentity: &tfsdk.ResourceIdentity{
+				Schema: identitySchemaResp.IdentitySchema,
+				Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+			},
+		}
+		for _, mover := range r.MoveState(ctx) {
+			mover.StateMover(ctx, req, &resp)
+			if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+				break
+			}
+		}
+		return resp
+	}
+
+	resp := move("microsoft365wp_test_move_source", `{"id":"`+id+`","display_name":"Old"}`)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("MoveState: %s", generic.DiagsError(resp.Diagnostics))
+	}
+	var displayName, identityId types.String
+	resp.TargetState.GetAttribute(ctx, path.Root("display_name"), &displayName)
+	resp.TargetIdentity.GetAttribute(ctx, path.Root("id"), &identityId)
+	if displayName.ValueString() != "Current" || identityId.ValueString() != id {
+		t.Errorf("expected display_name %q and identity id %q after move, got %s and %s", "Current", id, displayName, identityId)
+	}
+
+	resp = move("microsoft365wp_test_move_other", `{"id":"`+id+`"}`)
+	if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+		t.Errorf("expected state of a resource with a different BaseUri not to be moved")
+	}
+
+	resp = move("microsoft365wp_test_move_source", `{"id":"unknown"}`)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error when moving the state of a missing entity")
+	}
+}
@@ -2,6 +2,7 @@ package generic
 
 import (
 	"context"
+	"net/http"
 	"slices"
 	"sync"
 	"terraform-provider-microsoft365wp/workplace/external/strcase"
@@ -22,9 +23,12 @@ type AccessParams struct {
 	UriSuffix      string // append this to URI after the id (e.g. for singular childs)
 	EntityId       EntityIdOptions
 
-	IsSingleton  bool // just a shortcut (will be read and applied to other fields inside InitializeGuarded)
-	ReadOptions  ReadOptions
-	WriteOptions WriteOptions
+	IsSingleton        bool                 // just a shortcut (will be read and applied to other fields inside InitializeGuarded)
+	ApiVersions        []msgraph.ApiVersion // MS Graph API versions the entity is available in (beta only if empty), the first one is the default (see api_version.go)
+	BetaOnlyAttributes []path.Path          // attributes not available in other API versions than beta (attribute names only)
+	ReadOptions        ReadOptions
+	WriteOptions       WriteOptions
+	ImportOptions      ImportOptions
 
 	GraphToTerraformMiddleware                     GraphToTerraformMiddlewareFunc
 	GraphToTerraformMiddlewareTargetSetRunOnRawVal bool
@@ -40,7 +44,9 @@ type AccessParams struct {
 
 	initializeOnce sync.Once
 
-	graphClient *msgraph.Client
+	graphClient     *msgraph.Client
+	defaultTimeouts Timeouts
+	httpTransport   http.RoundTripper
 }
 
 type ParentEntities []ParentEntity
@@ -115,11 +121,16 @@ type WriteOptions struct {
 	UsePutForCreate        bool // will most likely only work if id gets provided in TF config or by FallbackIdGetterFunc (since PUT requests usually do not return any content and hence no id gets provided by MS Graph)
 	UsePutForUpdate        bool
 	SkipDelete             bool // just remove from TF state but do not try to delete from graph (e.g. for singletons)
+	SkipIfMatch            bool // do not send the ETag read from MS Graph as If-Match on update and delete (e.g. if MS Graph does not support this for the entity)
 	SubActions             []WriteSubAction
 	SerializeWrites        bool
 	SerialWritesDelay      time.Duration
 }
 
+type ImportOptions struct {
+	KeyAttributes []string // attributes (besides display_name) uniquely identifying entities that can be used to import them instead of the id (e.g. `name=Some Name`)
+}
+
 type GraphToTerraformMiddlewareFunc func(context.Context, *diag.Diagnostics, *GraphToTerraformMiddlewareParams) GraphToTerraformMiddlewareReturns
 type GraphToTerraformMiddlewareParams struct {
 	ExpectedId          string
@@ -131,6 +142,7 @@ type GraphToTerraformMiddlewareReturns error
 type TerraformToGraphMiddlewareFunc func(context.Context, *diag.Diagnostics, *TerraformToGraphMiddlewareParams) TerraformToGraphMiddlewareReturns
 type TerraformToGraphMiddlewareParams struct {
 	Config   tfsdk.Config
+	State    *tfsdk.State // prior state on updates (if available)
 	RawVal   map[string]any
 	IsUpdate bool
 }
@@ -196,12 +208,23 @@ type PrivateDataGetSetter interface {
 	SetKey(context.Context, string, []byte) diag.Diagnostics
 }
 
+// HttpTransport returns the transport configured for the provider to use for requests to other services than MS Graph
+// (e.g. to upload files to Azure Storage), nil means the default transport.
+func (ap *AccessParams) HttpTransport() http.RoundTripper {
+	return ap.httpTransport
+}
+
 func (ap *AccessParams) InitializeGuarded(providerData any) {
 
 	// providerData may be empty on early calls, so we always check this here
 	if ap.graphClient == nil && providerData != nil {
-		if graphClient, ok := providerData.(*msgraph.Client); ok {
-			ap.graphClient = graphClient
+		switch providerData := providerData.(type) {
+		case *msgraph.Client:
+			ap.graphClient = providerData
+		case *ProviderData:
+			ap.graphClient = providerData.GraphClient
+			ap.defaultTimeouts = providerData.DefaultTimeouts
+			ap.httpTransport = providerData.HttpTransport
 		}
 	}
 
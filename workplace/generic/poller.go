@@ -0,0 +1,113 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+//
+// Poller waits for long-running (asynchronous) operations of MS Graph to finish by reading an entity (or operation)
+// again and again until its state attribute indicates that the operation has completed. The delay between reads increases exponentially (with jitter) and polling stops as soon
+// as the context is done (e.g. due to the timeouts of the resource).
+//
+
+const (
+	pollerDefaultInitialDelay = 500 * time.Millisecond
+	pollerDefaultMaxDelay     = 30 * time.Second
+	pollerBackoffFactor       = 1.5
+	pollerJitter              = 0.2
+)
+
+// Poller configures how to poll an entity or operation (see above).
+type Poller struct {
+	// StateAttribute is the MS Graph name of the attribute containing the state, e.g. "status"
+	StateAttribute string
+
+	// PendingStates are the states indicating that the operation is still running. If empty, all states but the
+	// success and failure states are considered pending.
+	PendingStates []string
+
+	// SuccessStates are the states indicating that the operation succeeded. If empty, all states but the pending and
+	// failure states are considered successful. If both PendingStates and SuccessStates are set, any other state is
+	// considered a failure. At least one of PendingStates and SuccessStates must be set.
+	SuccessStates []string
+
+	// FailureStates are the states indicating that the operation failed.
+	FailureStates []string
+
+	// Select restricts the attributes to read (the state attribute is always included)
+	Select []string
+
+	// InitialDelay (default 500ms) and MaxDelay (default 30s) are the bounds of the delay between reads
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+
+	// ErrorSummary is used for all errors, defaults to "Error waiting for operation to complete"
+	ErrorSummary string
+}
+
+// Poll reads the entity addressed by uri until its state is not pending anymore and returns its last representation.
+// An error gets added if the final state does not indicate success.
+func (p Poller) Poll(ctx context.Context, diags *diag.Diagnostics, ap *AccessParams, uri msgraph.Uri) map[string]any {
+
+	errorSummary := p.ErrorSummary
+	if errorSummary == "" {
+		errorSummary = "Error waiting for operation to complete"
+	}
+	if len(p.PendingStates) == 0 && len(p.SuccessStates) == 0 {
+		// every state would be pending, i.e. polling would only stop due to the context
+		diags.AddError(errorSummary, fmt.Sprintf("Poller of %s has neither pending nor success states", uri.Entity))
+		return nil
+	}
+	odataSelect := p.Select
+	if len(odataSelect) > 0 && !slices.Contains(odataSelect, p.StateAttribute) {
+		odataSelect = append(odataSelect[:len(odataSelect):len(odataSelect)], p.StateAttribute)
+	}
+
+	delay := p.InitialDelay
+	if delay <= 0 {
+		delay = pollerDefaultInitialDelay
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = pollerDefaultMaxDelay
+	}
+
+	for {
+		body := ap.ReadRaw2(ctx, diags, uri, "", "", odataSelect, false)
+		if diags.HasError() {
+			return nil
+		}
+
+		state, _ := body[p.StateAttribute].(string)
+		tflog.Trace(ctx, fmt.Sprintf("Poller: %s of %s: %s", p.StateAttribute, uri.Entity, state))
+
+		switch {
+		case slices.Contains(p.FailureStates, state):
+			diags.AddError(errorSummary, fmt.Sprintf("%s of %s indicates failure: %s", p.StateAttribute, uri.Entity, state))
+			return body
+		case slices.Contains(p.SuccessStates, state):
+			return body
+		case len(p.PendingStates) > 0 && !slices.Contains(p.PendingStates, state):
+			if len(p.SuccessStates) > 0 {
+				diags.AddError(errorSummary, fmt.Sprintf("%s of %s does not indicate success: %s", p.StateAttribute, uri.Entity, state))
+			}
+			return body
+		}
+
+		// jitter avoids polling in lockstep when waiting for many entities in parallel
+		Sleep(ctx, diags, time.Duration(float64(delay)*(1+pollerJitter*(2*rand.Float64()-1))))
+		if diags.HasError() {
+			return nil
+		}
+		delay = min(time.Duration(float64(delay)*pollerBackoffFactor), maxDelay)
+	}
+}
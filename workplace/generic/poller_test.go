@@ -0,0 +1,86 @@
+package generic_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestPoller(t *testing.T) {
+	ctx := t.Context()
+
+	// operations return their states one after another (repeating the last one)
+	var mu sync.Mutex
+	states := map[string][]string{
+		"op1": {"notStarted", "running", "running", "succeeded"},
+		"op2": {"running", "failed"},
+		"op3": {"running", "cancelled"},
+		"op4": {"running"},
+	}
+	s := generictest.Graph()
+	s.Reset()
+	s.Handle("/operations", graphmock.HandlerFunc(func(w http.ResponseWriter, r *http.Request, p graphmock.Path) {
+		mu.Lock()
+		defer mu.Unlock()
+		id := p.Rest[0]
+		state := states[id][0]
+		if len(states[id]) > 1 {
+			states[id] = states[id][1:]
+		}
+		graphmock.WriteJson(w, http.StatusOK, map[string]any{"id": id, "status": state})
+	}))
+
+	ap := &generic.AccessParams{BaseUri: "/operations"}
+	ap.InitializeGuarded(generictest.Client())
+
+	poller := generic.Poller{
+		StateAttribute: "status",
+		SuccessStates:  []string{"succeeded"},
+		FailureStates:  []string{"failed"},
+		InitialDelay:   time.Millisecond,
+		MaxDelay:       5 * time.Millisecond,
+	}
+
+	diags := diag.Diagnostics{}
+	if body := poller.Poll(ctx, &diags, ap, msgraph.Uri{Entity: "/operations/op1"}); diags.HasError() || body["status"] != "succeeded" {
+		t.Errorf("expected op1 to succeed, got %v (%s)", body, generic.DiagsError(diags))
+	}
+
+	diags = diag.Diagnostics{}
+	poller.Poll(ctx, &diags, ap, msgraph.Uri{Entity: "/operations/op2"})
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "indicates failure: failed") {
+		t.Errorf("expected op2 to fail, got %v", diags)
+	}
+
+	diags = diag.Diagnostics{}
+	poller.PendingStates = []string{"notStarted", "running"}
+	poller.Poll(ctx, &diags, ap, msgraph.Uri{Entity: "/operations/op3"})
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "does not indicate success: cancelled") {
+		t.Errorf("expected op3 to fail with an unexpected state, got %v", diags)
+	}
+
+	diags = diag.Diagnostics{}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	poller.Poll(timeoutCtx, &diags, ap, msgraph.Uri{Entity: "/operations/op4"})
+	if !diags.HasError() {
+		t.Errorf("expected polling op4 to be aborted by the context deadline")
+	}
+
+	diags = diag.Diagnostics{}
+	poller.PendingStates, poller.SuccessStates = nil, nil
+	poller.Poll(ctx, &diags, ap, msgraph.Uri{Entity: "/operations/op1"})
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "neither pending nor success states") {
+		t.Errorf("expected an error without pending and success states, got %v", diags)
+	}
+}
@@ -6,13 +6,13 @@ import (
 	"strings"
 	"sync"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvalue"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -22,6 +22,9 @@ var (
 	_ resource.ResourceWithConfigure        = &GenericResource{}
 	_ resource.ResourceWithConfigValidators = &GenericResource{}
 	_ resource.ResourceWithImportState      = &GenericResource{}
+	_ resource.ResourceWithIdentity         = &GenericResource{}
+	_ resource.ResourceWithUpgradeState     = &GenericResource{}
+	_ resource.ResourceWithMoveState        = &GenericResource{}
 )
 
 // Resource implementation.
@@ -30,21 +33,30 @@ type GenericResource struct {
 	SpecificSchema           schema.Schema
 	SpecificConfigValidators []resource.ConfigValidator
 	AccessParams             AccessParams
+	StateUpgrades            []StateUpgrade // see state_upgrade.go
 
-	initSchemaOnce sync.Once
+	initSchemaOnce   sync.Once
+	providerTypeName string // see registerResourceType
 
-	serializationMutex sync.Mutex
+	// serializationLock is used as a mutex (see checkAndLockMutex) that can be given up waiting for (e.g. when the timeout
+	// of the operation has been exceeded) contrary to sync.Mutex
+	serializationLock     chan struct{}
+	serializationLockOnce sync.Once
 }
 
 // Returns the resource type name.
 func (r *GenericResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, r.TypeNameSuffix)
+	r.registerResourceType(req.ProviderTypeName, resp.TypeName)
 }
 
 // Defines the schema for the resource.
 func (r *GenericResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	r.initSchemaOnce.Do(func() {
+		r.AccessParams.initApiVersionSchema(&r.SpecificSchema)
+		initTimeoutsSchema(ctx, &r.SpecificSchema)
 		wpdefaultvalue.Init(ctx, &resp.Diagnostics, &r.SpecificSchema)
+		r.SpecificSchema.Version = int64(len(r.StateUpgrades))
 	})
 	resp.Schema = r.SpecificSchema
 }
@@ -64,18 +76,30 @@ func (r *GenericResource) Create(ctx context.Context, req resource.CreateRequest
 
 func (r *GenericResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 
+	ctx, cancel := r.AccessParams.contextWithTimeout(ctx, &resp.Diagnostics, req.State, "read")
+	defer cancel()
+	ctx = r.AccessParams.apiVersionContextFromRequest(ctx, &resp.Diagnostics, req.State)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tfVal := r.AccessParams.ReadSingleCompleteTf2(ctx, &resp.Diagnostics, req.State.Schema,
 		r.AccessParams.ReadOptions, "", &req.State, "", true, &req.State, resp.Private)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// also set identity if item has been removed as Terraform requires one even then
+	r.AccessParams.setIdentity(ctx, &resp.Diagnostics, resp.Identity, req.State)
+
 	if !tfVal.IsNull() {
 		newState := tfsdk.State{
 			Schema: req.State.Schema,
 			Raw:    tfVal,
 		}
 		r.AccessParams.PopulateStateParentIdsFromRequest(ctx, &resp.Diagnostics, &newState, req.State)
+		PopulateStateTerraformOnlyAttributesFromRequest(ctx, &resp.Diagnostics, &newState, req.State)
+		PopulateStateExcludedAttributesFromRequest(ctx, &resp.Diagnostics, &newState, req.State)
 		resp.State = newState
 	} else {
 		// item not found (anymore), remove from state
@@ -89,13 +113,19 @@ func (r *GenericResource) Update(ctx context.Context, req resource.UpdateRequest
 
 func (r *GenericResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 
-	if r.checkAndLockMutex() {
-		defer r.serializationMutex.Unlock()
-	}
-
 	thisIdAttributer := req.State
 	diags := &resp.Diagnostics
 
+	ctx, cancel := r.AccessParams.contextWithTimeout(ctx, diags, req.State, "delete")
+	defer cancel()
+	ctx = r.AccessParams.apiVersionContextFromRequest(ctx, diags, req.State)
+	if r.checkAndLockMutex(ctx, diags) {
+		defer r.unlockMutex()
+	}
+	if diags.HasError() {
+		return
+	}
+
 	baseUri := ""
 	id := ""
 	skipDelete := r.AccessParams.WriteOptions.SkipDelete
@@ -131,7 +161,11 @@ func (r *GenericResource) Delete(ctx context.Context, req resource.DeleteRequest
 		diags.AddWarning("Skipping deletion of entity from MS Graph, just removing resource from state", "Cannot delete entities that are singletons and/or have been created by MS Graph itself.")
 	} else {
 		if r.AccessParams.DeleteReplaceFunc == nil {
-			r.AccessParams.DeleteRaw(ctx, diags, baseUri, id, thisIdAttributer)
+			ifMatch := ""
+			if !r.AccessParams.WriteOptions.SkipIfMatch && !r.writeSubActionsWritePre(OperationDelete) {
+				ifMatch = getPrivateETag(ctx, diags, req.Private)
+			}
+			r.AccessParams.DeleteRaw2(ctx, diags, baseUri, id, thisIdAttributer, ifMatch)
 		} else {
 			params := DeleteReplaceFuncParams{
 				R:            r,
@@ -157,16 +191,37 @@ func (r *GenericResource) ImportState(ctx context.Context, req resource.ImportSt
 
 	// do not use resource.ImportStatePassthroughID here to be able to import entities with parents
 
-	importPaths := []path.Path{}
+	importPaths := r.AccessParams.identityPaths()
 	importAttributeNames := []string{}
-	for _, v := range r.AccessParams.ParentEntities {
-		importPaths = append(importPaths, v.ParentIdField)
-		importAttributeNames = append(importAttributeNames, v.ParentIdField.String())
+	for _, p := range importPaths {
+		importAttributeNames = append(importAttributeNames, p.String())
+	}
+
+	if req.ID == "" && req.Identity != nil {
+		// import block using `identity` instead of `id` (the response identity already is a copy of it)
+		for _, p := range importPaths {
+			var value types.String
+			resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root(p.String()), &value)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			if value.ValueString() == "" {
+				resp.Diagnostics.AddError("Identity for import is incomplete",
+					fmt.Sprintf("To import this resource, an identity with the following attribute(s) must be specified: '%s'", strings.Join(importAttributeNames, "', '")))
+				return
+			}
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, p, value)...)
+		}
+		return
 	}
-	importPaths = append(importPaths, path.Root(r.AccessParams.EntityId.AttrNameTf))
-	importAttributeNames = append(importAttributeNames, r.AccessParams.EntityId.AttrNameTf)
 
 	idComponents := strings.Split(req.ID, "/")
+	// the last component may also be a key attribute value (e.g. `displayName=Some Name`), which may contain slashes
+	keyComponents := strings.SplitN(req.ID, "/", len(importPaths))
+	keyAttrNameGraph, keyValue, isKey := r.parseImportKey(resp.State, keyComponents[len(keyComponents)-1])
+	if isKey {
+		idComponents = keyComponents
+	}
 	if len(idComponents) != len(importPaths) {
 		resp.Diagnostics.AddError("Id for import does not have the correct format",
 			fmt.Sprintf("To import this resource, an id with the following component(s) must be specified: '%s' (separated by forward slashes in case of multiple components)", strings.Join(importAttributeNames, "/")))
@@ -174,18 +229,25 @@ func (r *GenericResource) ImportState(ctx context.Context, req resource.ImportSt
 	}
 
 	for i, v := range idComponents {
+		if i == len(idComponents)-1 && isKey {
+			// parent ids have already been set and are required to look up the id
+			v = r.readIdByImportKey(ctx, &resp.Diagnostics, resp.State, keyAttrNameGraph, keyValue)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
 		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, importPaths[i], strings.Trim(v, " "))...)
 	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.AccessParams.setIdentity(ctx, &resp.Diagnostics, resp.Identity, resp.State)
 }
 
 func (r *GenericResource) createUpdate(ctx context.Context, operationType OperationType,
 	createRequest *resource.CreateRequest, createResponse *resource.CreateResponse,
 	updateRequest *resource.UpdateRequest, updateResponse *resource.UpdateResponse) {
 
-	if r.checkAndLockMutex() {
-		defer r.serializationMutex.Unlock()
-	}
-
 	// do this in here since we anyway need the original request and response for CreateModifyFuncParams and UpdateModifyFuncParams
 	var diags *diag.Diagnostics
 	var requestConfig *tfsdk.Config
@@ -193,8 +255,10 @@ func (r *GenericResource) createUpdate(ctx context.Context, operationType Operat
 	var requestState *tfsdk.State
 	var responseState *tfsdk.State
 	var responsePrivate PrivateDataGetSetter
+	var responseIdentity *tfsdk.ResourceIdentity
 	var parentIdAttributer GetAttributer = nil
 	var thisIdAttributer GetAttributer = nil
+	ifMatch := ""
 	switch operationType {
 	case OperationCreate:
 		diags = &createResponse.Diagnostics
@@ -203,6 +267,7 @@ func (r *GenericResource) createUpdate(ctx context.Context, operationType Operat
 		parentIdAttributer = createRequest.Config
 		responseState = &createResponse.State
 		responsePrivate = createResponse.Private
+		responseIdentity = createResponse.Identity
 	case OperationUpdate:
 		diags = &updateResponse.Diagnostics
 		requestConfig = &updateRequest.Config
@@ -211,10 +276,28 @@ func (r *GenericResource) createUpdate(ctx context.Context, operationType Operat
 		thisIdAttributer = updateRequest.State
 		responseState = &updateResponse.State
 		responsePrivate = updateResponse.Private
+		responseIdentity = updateResponse.Identity
+		if !r.AccessParams.WriteOptions.SkipIfMatch && !r.writeSubActionsWritePre(OperationUpdate) {
+			ifMatch = getPrivateETag(ctx, diags, updateRequest.Private)
+		}
 	default:
 		panic(fmt.Sprintf("Invalid operation type %d", operationType))
 	}
 
+	timeoutOperation := "create"
+	if operationType == OperationUpdate {
+		timeoutOperation = "update"
+	}
+	ctx, cancel := r.AccessParams.contextWithTimeout(ctx, diags, requestPlan, timeoutOperation)
+	defer cancel()
+	ctx = r.AccessParams.apiVersionContextFromRequest(ctx, diags, requestPlan)
+	if r.checkAndLockMutex(ctx, diags) {
+		defer r.unlockMutex()
+	}
+	if diags.HasError() {
+		return
+	}
+
 	baseUri := ""
 	id := ""
 	updateExisting := operationType == OperationUpdate
@@ -297,8 +380,8 @@ func (r *GenericResource) createUpdate(ctx context.Context, operationType Operat
 	}
 
 	includeNullObjects := updateExisting && !r.AccessParams.WriteOptions.UsePutForUpdate
-	rawVal := ConvertTerraformToOdataRaw(ctx, diags, requestPlan.Schema, includeNullObjects,
-		requestPlan.Raw, updateExisting, *requestConfig, r.AccessParams.TerraformToGraphMiddleware, "Plan")
+	rawVal := ConvertTerraformToOdataRaw2(ctx, diags, requestPlan.Schema, includeNullObjects,
+		requestPlan.Raw, updateExisting, *requestConfig, requestState, r.AccessParams.TerraformToGraphMiddleware, "Plan")
 	if diags.HasError() {
 		return
 	}
@@ -311,7 +394,7 @@ func (r *GenericResource) createUpdate(ctx context.Context, operationType Operat
 	var createUpdateResultRaw map[string]any
 	if updateExisting {
 		if r.AccessParams.UpdateReplaceFunc == nil {
-			r.AccessParams.UpdateRaw(ctx, diags, baseUri, id, thisIdAttributer, rawVal)
+			r.AccessParams.UpdateRaw3(ctx, diags, baseUri, id, thisIdAttributer, rawVal, r.AccessParams.WriteOptions.UsePutForUpdate, ifMatch)
 		} else {
 			params := UpdateReplaceFuncParams{
 				R:            r,
@@ -391,10 +474,12 @@ func (r *GenericResource) createUpdate(ctx context.Context, operationType Operat
 		}
 		// Save plan with new id added
 		responseState.Raw = responseStateTemp.Raw
+		r.AccessParams.setIdentity(ctx, diags, responseIdentity, responseState)
 
 	case OperationUpdate:
 		// Just copy plan
 		responseState.Raw = requestPlan.Raw
+		r.AccessParams.setIdentity(ctx, diags, responseIdentity, responseState)
 
 	}
 
@@ -402,7 +487,16 @@ func (r *GenericResource) createUpdate(ctx context.Context, operationType Operat
 	// But do not check for errors here yet as we want to ensure that populating runs even in case of errors here to get
 	// the state as complete as possible. Terraform will still bring up the errors as they will still be there after
 	// populating has run.
-	r.executeWriteSubActionsPost(ctx, diags, operationType, id, thisIdAttributer, subActionsData, requestState, requestPlan, responseState, responsePrivate)
+	wrote := r.executeWriteSubActionsPost(ctx, diags, operationType, id, thisIdAttributer, subActionsData, requestState, requestPlan, responseState, responsePrivate)
+
+	// Any ETag read before is outdated now (and MS Graph usually does not return a new one on update). The ETag returned
+	// by the operation itself is outdated as well if WriteSubActions have written afterwards, so do not keep any ETag
+	// then (the next read will provide the current one).
+	etag := getETagFromRaw(createUpdateResultRaw)
+	if wrote {
+		etag = ""
+	}
+	setPrivateETag(ctx, diags, responsePrivate, etag)
 
 	// Produce a wholly-known new state by determining the final values for any attributes left unknown in the planned state.
 	diagsPopulateUnknowns := diag.Diagnostics{} // need separate diags here as diags might already contain error(s) from WriteSubActions
@@ -423,14 +517,43 @@ func (r *GenericResource) createUpdate(ctx context.Context, operationType Operat
 
 }
 
-func (r *GenericResource) checkAndLockMutex() bool {
-	if r.AccessParams.WriteOptions.SerializeWrites {
-		if !r.serializationMutex.TryLock() {
-			r.serializationMutex.Lock()
-			time.Sleep(r.AccessParams.WriteOptions.SerialWritesDelay)
+// checkAndLockMutex locks the mutex serializing writes (if configured) unless the context is done before, which will be
+// reported as an error. It returns whether the mutex has been locked, i.e. whether unlockMutex must be called.
+func (r *GenericResource) checkAndLockMutex(ctx context.Context, diags *diag.Diagnostics) bool {
+	if !r.AccessParams.WriteOptions.SerializeWrites {
+		return false
+	}
+	r.serializationLockOnce.Do(func() {
+		r.serializationLock = make(chan struct{}, 1)
+	})
+	select {
+	case r.serializationLock <- struct{}{}:
+		return true
+	default:
+	}
+	select {
+	case r.serializationLock <- struct{}{}:
+		Sleep(ctx, diags, r.AccessParams.WriteOptions.SerialWritesDelay)
+		return true
+	case <-ctx.Done():
+		diags.AddError("Operation aborted", fmt.Sprintf("The operation has been aborted while waiting for other operations of this resource type to finish: %s", context.Cause(ctx)))
+		return false
+	}
+}
+
+func (r *GenericResource) unlockMutex() {
+	<-r.serializationLock
+}
+
+// writeSubActionsWritePre checks whether any WriteSubActions write to MS Graph before the operation itself. These
+// might change the entity (and therefore its ETag), so the ETag read before must not be sent as If-Match anymore.
+func (r *GenericResource) writeSubActionsWritePre(wsaOperation OperationType) bool {
+	for _, a := range r.AccessParams.WriteOptions.SubActions {
+		if a.CheckRunAction(wsaOperation) && a.CheckWritePre(wsaOperation) {
+			return true
 		}
 	}
-	return r.AccessParams.WriteOptions.SerializeWrites
+	return false
 }
 
 func (r *GenericResource) executeWriteSubActionsPre(ctx context.Context, diags *diag.Diagnostics, wsaOperation OperationType,
@@ -461,10 +584,13 @@ func (r *GenericResource) executeWriteSubActionsPre(ctx context.Context, diags *
 	return subActionsData
 }
 
+// executeWriteSubActionsPost executes the WriteSubActions after the operation and returns whether any of them has
+// written to MS Graph.
 func (r *GenericResource) executeWriteSubActionsPost(ctx context.Context, diags *diag.Diagnostics, wsaOperation OperationType,
 	id string, idAttributer GetAttributer, subActionsData map[string]any, reqState *tfsdk.State, reqPlan *tfsdk.Plan, respState *tfsdk.State,
-	respPrivate PrivateDataGetSetter) {
+	respPrivate PrivateDataGetSetter) bool {
 
+	wrote := false
 	for _, a := range r.AccessParams.WriteOptions.SubActions {
 		if a.CheckRunAction(wsaOperation) {
 			wsaRequest := WriteSubActionRequest{
@@ -479,9 +605,11 @@ func (r *GenericResource) executeWriteSubActionsPost(ctx context.Context, diags
 				RespPrivate:    respPrivate,
 			}
 			a.ExecutePost(ctx, diags, &wsaRequest)
+			wrote = wrote || wsaRequest.Wrote
 			if diags.HasError() {
-				return
+				return wrote
 			}
 		}
 	}
+	return wrote
 }
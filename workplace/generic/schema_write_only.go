@@ -0,0 +1,63 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+//
+// Write-only attributes (i.e. attributes with `WriteOnly: true`) are never persisted to Terraform state. When writing
+// to MS Graph, ToFromGraphTranslator takes their values from the config. When reading from MS Graph, they will
+// always be null.
+//
+// As the values of write-only attributes cannot be compared with MS Graph or previous runs, a version attribute (see
+// WriteOnlyVersionAttribute) should be added which has to be changed to trigger an update, e.g. to rotate a secret.
+//
+
+// TerraformOnlyAttribute can be used as the Description (i.e. custom MS Graph attribute name) of attributes that only
+// exist in Terraform and will neither be sent to nor be read from MS Graph. Root attributes of this kind keep their
+// value from the prior state on read.
+const TerraformOnlyAttribute = "-"
+
+// WriteOnlyVersionAttribute returns the schema of a (Terraform only) version attribute for the write-only attributes.
+func WriteOnlyVersionAttribute(writeOnlyAttributeNames ...string) rsschema.Int64Attribute {
+	return rsschema.Int64Attribute{
+		Optional:    true,
+		Description: TerraformOnlyAttribute,
+		MarkdownDescription: fmt.Sprintf("Version of the write-only value(s) of `%s`. As write-only values never get persisted "+
+			"and therefore cannot be compared, this version must be changed to trigger an update (e.g. to rotate a secret).",
+			strings.Join(writeOnlyAttributeNames, "`, `")),
+	}
+}
+
+// isResourceOnlyAttribute returns true for attributes that must not be part of data sources.
+func isResourceOnlyAttribute(attribute rsschema.Attribute) bool {
+	return attribute.IsWriteOnly() || attribute.GetDescription() == TerraformOnlyAttribute
+}
+
+// PopulateStateTerraformOnlyAttributesFromRequest copies the values of all Terraform only root attributes and of all
+// root blocks (which cannot be read from MS Graph) to the new state.
+func PopulateStateTerraformOnlyAttributesFromRequest(ctx context.Context, diags *diag.Diagnostics, dst *tfsdk.State, src tfsdk.State) {
+	names := []string{}
+	for name, attribute := range src.Schema.GetAttributes() {
+		if attribute.GetDescription() == TerraformOnlyAttribute {
+			names = append(names, name)
+		}
+	}
+	for name := range src.Schema.GetBlocks() {
+		names = append(names, name)
+	}
+	for _, name := range names {
+		err := CopyValueAtPath(ctx, dst, src, path.Root(name))
+		if err != nil {
+			diags.AddError(fmt.Sprintf("CopyValueAtPath(), Terraform only attribute: %s", name), err.Error())
+			return
+		}
+	}
+}
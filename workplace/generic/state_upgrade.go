@@ -0,0 +1,265 @@
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+//
+// Schemas change over time (e.g. attributes get renamed or moved into the nested attribute of an OData derived type),
+// so resources can declare GenericResource.StateUpgrades: StateUpgrades[i] upgrades the state of schema version i to
+// version i+1 and the schema version of the resource is len(StateUpgrades). State of any older version gets upgraded by
+// applying all subsequent upgrades in order.
+//
+// The steps of an upgrade operate on the JSON representation of the state (i.e. on maps for attributes of objects
+// and maps, on slices for lists and sets and on json.Number for numbers) and address attributes using path expressions,
+// e.g. path.MatchRoot("assignments").AtAnyListIndex().AtName("target"). Attributes that are not part of the current
+// schema anymore get dropped and new attributes get initialized with null after all steps have been applied.
+//
+
+// StateUpgrade contains the steps to upgrade the state from the previous schema version.
+type StateUpgrade []StateUpgradeStep
+
+// StateUpgradeStep modifies the (JSON) state during an upgrade.
+type StateUpgradeStep func(state map[string]any) error
+
+// RenameAttribute renames all attributes matching the expression to newName (keeping them in the same object).
+func RenameAttribute(expr path.Expression, newName string) StateUpgradeStep {
+	return func(state map[string]any) error {
+		return walkStateExpression(state, expr.Resolve().Steps(), nil, func(parent any, key any, _ []any) error {
+			obj, ok := parent.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s does not address attributes", expr)
+			}
+			if value, exists := obj[key.(string)]; exists {
+				delete(obj, key.(string))
+				obj[newName] = value
+			}
+			return nil
+		})
+	}
+}
+
+// MoveAttribute moves all attributes matching from to the location addressed by to, e.g. into the nested attribute of
+// an OData derived type. Intermediate objects get created as needed. Wildcards of to (e.g. AtAnyListIndex) get replaced
+// with the keys matched by the respective wildcards of from, i.e. attributes can be moved within the same list element.
+func MoveAttribute(from path.Expression, to path.Expression) StateUpgradeStep {
+	return func(state map[string]any) error {
+		type match struct {
+			keys  []any
+			value any
+		}
+		matches := []match{}
+		err := walkStateExpression(state, from.Resolve().Steps(), nil, func(parent any, key any, keys []any) error {
+			obj, ok := parent.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s does not address attributes", from)
+			}
+			if value, exists := obj[key.(string)]; exists {
+				delete(obj, key.(string))
+				matches = append(matches, match{keys, value})
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, m := range matches {
+			if err := setStateExpression(state, to.Resolve().Steps(), m.keys, m.value); err != nil {
+				return fmt.Errorf("moving %s to %s: %w", from, to, err)
+			}
+		}
+		return nil
+	}
+}
+
+// ConvertAttribute replaces the (non-null) values of all attributes or elements matching the expression with the result
+// of convert, e.g. to change the type of an attribute.
+func ConvertAttribute(expr path.Expression, convert func(value any) (any, error)) StateUpgradeStep {
+	return func(state map[string]any) error {
+		return walkStateExpression(state, expr.Resolve().Steps(), nil, func(parent any, key any, _ []any) error {
+			switch typedParent := parent.(type) {
+			case map[string]any:
+				if value := typedParent[key.(string)]; value != nil {
+					converted, err := convert(value)
+					if err != nil {
+						return fmt.Errorf("converting %s: %w", expr, err)
+					}
+					typedParent[key.(string)] = converted
+				}
+			case []any:
+				if value := typedParent[key.(int)]; value != nil {
+					converted, err := convert(value)
+					if err != nil {
+						return fmt.Errorf("converting %s: %w", expr, err)
+					}
+					typedParent[key.(int)] = converted
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// walkStateExpression calls fn with the parent and the key (i.e. attribute name or element index) of every location
+// in the JSON state matching steps, along with the keys matched by any wildcards. Locations below null values get
+// skipped.
+func walkStateExpression(value any, steps path.ExpressionSteps, keys []any, fn func(parent any, key any, keys []any) error) error {
+
+	if len(steps) == 0 || value == nil {
+		return nil
+	}
+	step, rest := steps[0], steps[1:]
+
+	visit := func(parent any, key any, child any, keys []any) error {
+		if len(rest) == 0 {
+			return fn(parent, key, keys)
+		}
+		return walkStateExpression(child, rest, keys, fn)
+	}
+
+	switch typedStep := step.(type) {
+	case path.ExpressionStepAttributeNameExact:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected object for attribute %q but got %T", string(typedStep), value)
+		}
+		return visit(obj, string(typedStep), obj[string(typedStep)], keys)
+	case path.ExpressionStepElementKeyStringAny:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected map but got %T", value)
+		}
+		for k, child := range obj {
+			if err := visit(obj, k, child, append(keys[:len(keys):len(keys)], k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case path.ExpressionStepElementKeyIntAny, path.ExpressionStepElementKeyValueAny:
+		arr, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("expected list or set but got %T", value)
+		}
+		for i, child := range arr {
+			if err := visit(arr, i, child, append(keys[:len(keys):len(keys)], i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported expression step %s", step)
+	}
+}
+
+// setStateExpression sets the value at the location in the JSON state addressed by steps, using keys for the
+// wildcards. Missing or null objects get created for attribute steps.
+func setStateExpression(state map[string]any, steps path.ExpressionSteps, keys []any, value any) error {
+
+	var current any = state
+	for i, step := range steps {
+		last := i == len(steps)-1
+
+		var key any
+		switch typedStep := step.(type) {
+		case path.ExpressionStepAttributeNameExact:
+			key = string(typedStep)
+		case path.ExpressionStepElementKeyStringAny, path.ExpressionStepElementKeyIntAny, path.ExpressionStepElementKeyValueAny:
+			if len(keys) == 0 {
+				return fmt.Errorf("more wildcards in target than in source")
+			}
+			key, keys = keys[0], keys[1:]
+		default:
+			return fmt.Errorf("unsupported expression step %s", step)
+		}
+
+		switch typedCurrent := current.(type) {
+		case map[string]any:
+			name, ok := key.(string)
+			if !ok {
+				return fmt.Errorf("expected list or set but got object")
+			}
+			if last {
+				typedCurrent[name] = value
+				return nil
+			}
+			if typedCurrent[name] == nil {
+				typedCurrent[name] = map[string]any{}
+			}
+			current = typedCurrent[name]
+		case []any:
+			index, ok := key.(int)
+			if !ok || index >= len(typedCurrent) {
+				return fmt.Errorf("element %v does not exist", key)
+			}
+			if last {
+				typedCurrent[index] = value
+				return nil
+			}
+			current = typedCurrent[index]
+		default:
+			return fmt.Errorf("expected object, map, list or set but got %T", current)
+		}
+	}
+	return nil
+}
+
+// UpgradeState returns a state upgrader for each prior schema version (i.e. one for each of StateUpgrades).
+func (r *GenericResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	result := make(map[int64]resource.StateUpgrader, len(r.StateUpgrades))
+	for version := range r.StateUpgrades {
+		result[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				r.upgradeState(ctx, version, req, resp)
+			},
+		}
+	}
+	return result
+}
+
+func (r *GenericResource) upgradeState(ctx context.Context, priorVersion int, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+
+	errorSummary := fmt.Sprintf("Unable to upgrade state from schema version %d to %d", priorVersion, len(r.StateUpgrades))
+
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError(errorSummary, "Prior state is not available as JSON")
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(req.RawState.JSON))
+	decoder.UseNumber()
+	var state map[string]any
+	if err := decoder.Decode(&state); err != nil {
+		resp.Diagnostics.AddError(errorSummary, fmt.Sprintf("Unable to parse prior state: %s", err.Error()))
+		return
+	}
+
+	for version := priorVersion; version < len(r.StateUpgrades); version++ {
+		for _, step := range r.StateUpgrades[version] {
+			if err := step(state); err != nil {
+				resp.Diagnostics.AddError(errorSummary, fmt.Sprintf("Upgrade to version %d failed: %s", version+1, err.Error()))
+				return
+			}
+		}
+	}
+
+	stateJson, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError(errorSummary, err.Error())
+		return
+	}
+	rawState := tfprotov6.RawState{JSON: stateJson}
+	resp.State.Raw, err = rawState.UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx),
+		tfprotov6.UnmarshalOpts{ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true}})
+	if err != nil {
+		resp.Diagnostics.AddError(errorSummary, fmt.Sprintf("Upgraded state does not match the current schema: %s", err.Error()))
+		return
+	}
+}
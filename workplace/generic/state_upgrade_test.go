@@ -0,0 +1,154 @@
+package generic_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestStateUpgrades(t *testing.T) {
+	ctx := t.Context()
+
+	r := &generic.GenericResource{
+		TypeNameSuffix: "test",
+		SpecificSchema: schema.Schema{
+			Attributes: map[string]schema.Attribute{
+				"id":           schema.StringAttribute{Computed: true},
+				"display_name": schema.StringAttribute{Required: true},
+				"windows": schema.SingleNestedAttribute{
+					Optional: true,
+					Attributes: map[string]schema.Attribute{
+						"max_count": schema.Int64Attribute{Optional: true},
+					},
+				},
+				"rules": schema.ListNestedAttribute{
+					Optional: true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"target": schema.SingleNestedAttribute{
+								Optional: true,
+								Attributes: map[string]schema.Attribute{
+									"group_id": schema.StringAttribute{Optional: true},
+								},
+							},
+						},
+					},
+				},
+				"new_attribute": schema.StringAttribute{Optional: true},
+			},
+		},
+		AccessParams: generic.AccessParams{BaseUri: "/test"},
+		StateUpgrades: []generic.StateUpgrade{
+			{ // version 0 to 1
+				generic.RenameAttribute(path.MatchRoot("name"), "display_name"),
+				generic.ConvertAttribute(path.MatchRoot("max_count"), func(value any) (any, error) {
+					n, err := strconv.ParseInt(value.(string), 10, 64)
+					return n, err
+				}),
+			},
+			{ // version 1 to 2
+				generic.MoveAttribute(path.MatchRoot("max_count"), path.MatchRoot("windows").AtName("max_count")),
+				generic.MoveAttribute(path.MatchRoot("rules").AtAnyListIndex().AtName("group_id"),
+					path.MatchRoot("rules").AtAnyListIndex().AtName("target").AtName("group_id")),
+			},
+		},
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Schema.Version != 2 {
+		t.Fatalf("expected schema version 2, got %d", schemaResp.Schema.Version)
+	}
+
+	tests := []struct {
+		version int64
+		state   string
+	}{
+		{0, `{"id":"1","name":"Test","max_count":"5","rules":[{"group_id":"g1"},{"group_id":"g2"}],"removed":true}`},
+		{1, `{"id":"1","display_name":"Test","max_count":5,"rules":[{"group_id":"g1"},{"group_id":"g2"}]}`},
+	}
+	want, err := (&tfprotov6.RawState{JSON: []byte(
+		`{"id":"1","display_name":"Test","windows":{"max_count":5},"rules":[{"target":{"group_id":"g1"}},{"target":{"group_id":"g2"}}]}`,
+	)}).Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		upgrader, ok := r.UpgradeState(ctx)[tt.version]
+		if !ok {
+			t.Fatalf("version %d: state upgrader is missing", tt.version)
+		}
+		req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(tt.state)}}
+		resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		upgrader.StateUpgrader(ctx, req, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("version %d: %s", tt.version, generic.DiagsError(resp.Diagnostics))
+		}
+
+		if !resp.State.Raw.Equal(want) {
+			t.Errorf("version %d: expected upgraded state\n%s\ngot\n%s", tt.version, want, resp.State.Raw)
+		}
+	}
+
+	upgrader := r.UpgradeState(ctx)[1]
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(`{"display_name":"Test","rules":"invalid"}`)}}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error when upgrading invalid state")
+	}
+}
+
+// A resource that renamed "name" to "display_name" and moved "group_id" into the nested "target" attribute declares
+// one StateUpgrade per schema version, so Terraform upgrades existing state instead of failing to decode it.
+func ExampleGenericResource_stateUpgrades() {
+	ctx := context.Background()
+
+	r := &generic.GenericResource{
+		TypeNameSuffix: "example",
+		SpecificSchema: schema.Schema{
+			Attributes: map[string]schema.Attribute{
+				"id":           schema.StringAttribute{Computed: true},
+				"display_name": schema.StringAttribute{Required: true},
+				"target": schema.SingleNestedAttribute{
+					Optional: true,
+					Attributes: map[string]schema.Attribute{
+						"group_id": schema.StringAttribute{Optional: true},
+					},
+				},
+			},
+		},
+		AccessParams: generic.AccessParams{BaseUri: "/example"},
+		StateUpgrades: []generic.StateUpgrade{
+			{ // version 0 to 1
+				generic.RenameAttribute(path.MatchRoot("name"), "display_name"),
+			},
+			{ // version 1 to 2
+				generic.MoveAttribute(path.MatchRoot("group_id"), path.MatchRoot("target").AtName("group_id")),
+			},
+		},
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(`{"id":"1","name":"Example","group_id":"g1"}`)}}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.UpgradeState(ctx)[0].StateUpgrader(ctx, req, &resp)
+
+	var displayName, groupId string
+	resp.State.GetAttribute(ctx, path.Root("display_name"), &displayName)
+	resp.State.GetAttribute(ctx, path.Root("target").AtName("group_id"), &groupId)
+	fmt.Printf("schema version %d: display_name=%s target.group_id=%s\n", schemaResp.Schema.Version, displayName, groupId)
+	// Output: schema version 2: display_name=Example target.group_id=g1
+}
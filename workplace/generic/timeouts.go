@@ -0,0 +1,116 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+//
+// Each resource has a standard `timeouts` block to limit the duration of its operations (including all polling of
+// write sub-actions) with defaults configured for the provider (see ProviderData). Timeouts are applied as context
+// deadlines, so everything taking longer must use the context (e.g. using Sleep instead of time.Sleep).
+//
+
+// TimeoutsAttribute is the name of the root block to set the timeouts of resource operations.
+const TimeoutsAttribute = "timeouts"
+
+// Timeouts are the maximum durations of resource operations, zero means no timeout.
+type Timeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// ProviderData is passed from the provider to resources (data sources only get the msgraph.Client).
+type ProviderData struct {
+	GraphClient     *msgraph.Client
+	DefaultTimeouts Timeouts
+	HttpTransport   http.RoundTripper // base transport for requests to other services than MS Graph (e.g. Azure Storage)
+}
+
+// initTimeoutsSchema adds the standard `timeouts` block to the schema.
+func initTimeoutsSchema(ctx context.Context, s *rsschema.Schema) {
+	if _, ok := s.Attributes[TimeoutsAttribute]; ok {
+		panic(fmt.Sprintf("attribute %s already exists in schema", TimeoutsAttribute))
+	}
+	if _, ok := s.Blocks[TimeoutsAttribute]; ok {
+		panic(fmt.Sprintf("block %s already exists in schema", TimeoutsAttribute))
+	}
+	description := func(operation string) string {
+		return fmt.Sprintf("Maximum duration of the %s operation, consisting of numbers and units (`s`, `m` or `h`), e.g. "+
+			"`30s` or `2h45m`. <br/> The _provider_ default value is the one of `default_timeouts` of the provider (no "+
+			"timeout if not set).", operation)
+	}
+	block := timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: description("create"),
+		ReadDescription:   description("read"),
+		UpdateDescription: description("update"),
+		DeleteDescription: description("delete"),
+	}).(rsschema.SingleNestedBlock)
+	block.MarkdownDescription = "Timeouts of the operations of this resource, including any polling for MS Graph to finish processing."
+	if s.Blocks == nil {
+		s.Blocks = make(map[string]rsschema.Block)
+	}
+	s.Blocks[TimeoutsAttribute] = block
+}
+
+// contextWithTimeout returns a context with the timeout of the operation (i.e. `create`, `read`, `update` or `delete`)
+// from the `timeouts` block of the plan or state or otherwise the provider default.
+func (ap *AccessParams) contextWithTimeout(ctx context.Context, diags *diag.Diagnostics, src GetAttributer, operation string) (context.Context, context.CancelFunc) {
+
+	var value timeouts.Value
+	diags.Append(src.GetAttribute(ctx, path.Root(TimeoutsAttribute), &value)...)
+	if diags.HasError() {
+		return ctx, func() {}
+	}
+
+	var timeout time.Duration
+	var timeoutDiags diag.Diagnostics
+	switch operation {
+	case "create":
+		timeout, timeoutDiags = value.Create(ctx, ap.defaultTimeouts.Create)
+	case "read":
+		timeout, timeoutDiags = value.Read(ctx, ap.defaultTimeouts.Read)
+	case "update":
+		timeout, timeoutDiags = value.Update(ctx, ap.defaultTimeouts.Update)
+	case "delete":
+		timeout, timeoutDiags = value.Delete(ctx, ap.defaultTimeouts.Delete)
+	default:
+		panic(fmt.Sprintf("Invalid timeout operation %s", operation))
+	}
+	diags.Append(timeoutDiags...)
+	if diags.HasError() {
+		return ctx, func() {}
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Sleep pauses for the duration (e.g. when polling) unless the context is done (e.g. as the timeout of the operation
+// has been exceeded), which will be reported as an error.
+func Sleep(ctx context.Context, diags *diag.Diagnostics, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		diags.AddError("Operation aborted", fmt.Sprintf("The operation has been aborted while waiting: %s", context.Cause(ctx)))
+	}
+}
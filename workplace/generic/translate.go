@@ -6,6 +6,7 @@ import (
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpvalidator"
 
 	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
@@ -15,6 +16,12 @@ type ToFromGraphTranslator struct {
 	SchemaRoot               rsschema.NestedAttribute
 	IsDataSource             bool
 	IncludeNullObjectsInJson bool
+	// WriteOnlySource provides the values of write-only attributes (which are always null in plan and state), i.e.
+	// usually the config. Write-only attributes will not be sent to MS Graph if not set.
+	WriteOnlySource tftypes.Value
+	// ExcludedAttributes will neither be sent to MS Graph nor be read from it, e.g. as they are not available in the
+	// selected API version. Setting them in WriteOnlySource (i.e. the config) will result in an error.
+	ExcludedAttributes []path.Path
 }
 
 func NewToFromGraphTranslator(schema tftypes.AttributePathStepper, includeNullObjectsInJson bool) ToFromGraphTranslator {
@@ -31,6 +38,19 @@ func NewToFromGraphTranslator(schema tftypes.AttributePathStepper, includeNullOb
 		for k, v := range typ.GetAttributes() {
 			nestedAttributes[k] = v
 		}
+		// blocks (i.e. `timeouts`) are Terraform only but still need to be part of the type
+		for k, v := range typ.GetBlocks() {
+			block, ok := v.(rsschema.SingleNestedBlock)
+			if !ok {
+				panic(fmt.Errorf("block %s is not SingleNestedBlock but %T", k, v))
+			}
+			nestedAttributes[k] = rsschema.SingleNestedAttribute{
+				Attributes:  block.Attributes,
+				CustomType:  block.CustomType,
+				Optional:    true,
+				Description: TerraformOnlyAttribute,
+			}
+		}
 		result.SchemaRoot = rsschema.SingleNestedAttribute{
 			Attributes:  nestedAttributes,
 			Description: typ.GetDescription(),
@@ -73,7 +93,7 @@ func (t *ToFromGraphTranslator) GraphAttributeNameFromTerraformName(parentAttrib
 
 	if attribute, ok := parentNested.GetNestedObject().GetAttributes()[terraformAttributeName]; ok {
 		graphAttributeName := t.GraphAttributeNameFromTerraformNameImpl(terraformAttributeName, attribute)
-		attributeIsWritable := attribute.IsRequired() || attribute.IsOptional()
+		attributeIsWritable := (attribute.IsRequired() || attribute.IsOptional()) && graphAttributeName != TerraformOnlyAttribute
 		return graphAttributeName, attributeIsWritable, true
 	}
 
@@ -98,6 +118,10 @@ func (*ToFromGraphTranslator) TerraformAttributeNameFromGraphName(parentAttribut
 	}
 
 	for k, v := range parentNested.GetNestedObject().GetAttributes() {
+		if v.IsWriteOnly() {
+			// must never be read into Terraform state
+			continue
+		}
 		description := v.GetDescription()
 		if description != "" && description == graphAttributeName {
 			// custom mapping
@@ -112,8 +136,8 @@ func (*ToFromGraphTranslator) TerraformAttributeNameFromGraphName(parentAttribut
 	}
 
 	terraformAttributeName := strcase.ToSnake(graphAttributeName)
-	_, ok = parentNested.GetNestedObject().GetAttributes()[terraformAttributeName]
-	if ok {
+	attribute, ok := parentNested.GetNestedObject().GetAttributes()[terraformAttributeName]
+	if ok && !attribute.IsWriteOnly() && attribute.GetDescription() != TerraformOnlyAttribute {
 		// ok, attribute exists
 		return terraformAttributeName, true
 	}
@@ -270,3 +294,30 @@ func (t *ToFromGraphTranslator) TerraformToGraphTranslateValue(p *tftypes.Attrib
 
 	return nil, false, nil
 }
+
+// SensitiveGraphAttributePaths returns the (dotted) MS Graph JSON paths of all attributes marked as sensitive in the
+// schema, e.g. to redact them when logging requests.
+func SensitiveGraphAttributePaths(schema rsschema.Schema) []string {
+	translator := NewToFromGraphTranslator(schema, false)
+	return translator.sensitiveGraphAttributePaths(translator.SchemaRoot, "")
+}
+
+func (t *ToFromGraphTranslator) sensitiveGraphAttributePaths(parentAttribute rsschema.NestedAttribute, parentPath string) []string {
+	var result []string
+	for terraformAttributeName, attribute := range parentAttribute.GetNestedObject().GetAttributes() {
+		path := parentPath
+		if _, ok := attribute.(DerivedTypeNestedAttribute); !ok {
+			// attributes of derived types are part of the parent object in MS Graph
+			path = t.GraphAttributeNameFromTerraformNameImpl(terraformAttributeName, attribute)
+			if parentPath != "" {
+				path = parentPath + "." + path
+			}
+		}
+		if attribute.IsSensitive() {
+			result = append(result, path)
+		} else if nested, ok := attribute.(rsschema.NestedAttribute); ok {
+			result = append(result, t.sensitiveGraphAttributePaths(nested, path)...)
+		}
+	}
+	return result
+}
@@ -20,6 +20,7 @@ import (
 	"math/big"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpjsontypes"
 
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -151,8 +152,19 @@ func (t *ToFromGraphTranslator) rawFromTerraformValue(ctx context.Context, path
 
 		vs := make(map[string]any)
 		for name, val := range vals {
+			isWriteOnly := false
 			if typ.Is(tftypes.Object{}) {
 				path = path.WithAttributeName(name)
+				if isExcludedAttribute(t.ExcludedAttributes, path) {
+					if err := t.checkExcludedAttributeNotConfigured(path); err != nil {
+						return nil, err
+					}
+					path = path.WithoutLastStep()
+					continue
+				}
+				if val, isWriteOnly, err = t.writeOnlyValue(parentSchemaAttribute, name, path, val); err != nil {
+					return nil, err
+				}
 			} else {
 				path = path.WithElementKeyString(name)
 			}
@@ -161,10 +173,15 @@ func (t *ToFromGraphTranslator) rawFromTerraformValue(ctx context.Context, path
 				return nil, err
 			}
 			path = path.WithoutLastStep()
-			if v == nil && !t.IncludeNullObjectsInJson {
+			if v == nil && (!t.IncludeNullObjectsInJson || isWriteOnly) {
+				// write-only values that have not been set must not overwrite the existing ones in MS Graph
 				continue
 			}
-			odataType, ok := t.OdataTypeByTerraformAttributeName(parentSchemaAttribute, name)
+			odataType, ok := "", false
+			if typ.Is(tftypes.Object{}) {
+				// map keys are no attribute names (and map elements might not even be nested)
+				odataType, ok = t.OdataTypeByTerraformAttributeName(parentSchemaAttribute, name)
+			}
 			if ok {
 				if v != nil {
 					leaf, ok := v.(map[string]any)
@@ -193,3 +210,46 @@ func (t *ToFromGraphTranslator) rawFromTerraformValue(ctx context.Context, path
 
 	return nil, fmt.Errorf("unsupported value type: %s", typ)
 }
+
+// writeOnlyValue returns the value from WriteOnlySource (or null if unavailable) in case the attribute is write-only
+// and otherwise the value unchanged.
+func (t *ToFromGraphTranslator) writeOnlyValue(parentAttribute rsschema.Attribute, name string, path *tftypes.AttributePath, val tftypes.Value) (tftypes.Value, bool, error) {
+	parentNested, ok := parentAttribute.(rsschema.NestedAttribute)
+	if !ok {
+		return val, false, nil
+	}
+	if attribute, ok := parentNested.GetNestedObject().GetAttributes()[name]; !ok || !attribute.IsWriteOnly() {
+		return val, false, nil
+	}
+
+	if t.WriteOnlySource.Type() == nil {
+		return tftypes.NewValue(val.Type(), nil), true, nil
+	}
+	sourceVal, _, err := tftypes.WalkAttributePath(t.WriteOnlySource, path)
+	if err != nil {
+		// some parent does not exist in source
+		return tftypes.NewValue(val.Type(), nil), true, nil
+	}
+	result, ok := sourceVal.(tftypes.Value)
+	if !ok {
+		return tftypes.Value{}, false, fmt.Errorf("unexpected type %T of write-only value at %s", sourceVal, path)
+	}
+	return result, true, nil
+}
+
+// checkExcludedAttributeNotConfigured returns an error if the excluded attribute has been set in WriteOnlySource (i.e.
+// the config) as its value would silently get lost otherwise.
+func (t *ToFromGraphTranslator) checkExcludedAttributeNotConfigured(path *tftypes.AttributePath) error {
+	if t.WriteOnlySource.Type() == nil {
+		return nil
+	}
+	sourceVal, _, err := tftypes.WalkAttributePath(t.WriteOnlySource, path)
+	if err != nil {
+		// some parent does not exist in source
+		return nil
+	}
+	if v, ok := sourceVal.(tftypes.Value); ok && !v.IsNull() {
+		return fmt.Errorf("attribute %s is not available in the selected MS Graph API version", path)
+	}
+	return nil
+}
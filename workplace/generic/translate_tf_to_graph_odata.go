@@ -30,8 +30,17 @@ func ConvertTerraformToOdataJson(ctx context.Context, diags *diag.Diagnostics, s
 func ConvertTerraformToOdataRaw(ctx context.Context, diags *diag.Diagnostics, schema tftypes.AttributePathStepper, includeNullObjects bool, val tftypes.Value,
 	isUpdate bool, config tfsdk.Config, middlewareFunc TerraformToGraphMiddlewareFunc,
 	valSourceDesc string) map[string]any {
+	return ConvertTerraformToOdataRaw2(ctx, diags, schema, includeNullObjects, val, isUpdate, config, nil, middlewareFunc, valSourceDesc)
+}
+
+// ConvertTerraformToOdataRaw2 also passes the prior state (if any, i.e. on updates) to middlewareFunc.
+func ConvertTerraformToOdataRaw2(ctx context.Context, diags *diag.Diagnostics, schema tftypes.AttributePathStepper, includeNullObjects bool, val tftypes.Value,
+	isUpdate bool, config tfsdk.Config, state *tfsdk.State, middlewareFunc TerraformToGraphMiddlewareFunc,
+	valSourceDesc string) map[string]any {
 
 	translator := NewToFromGraphTranslator(schema, includeNullObjects)
+	translator.WriteOnlySource = config.Raw
+	translator.ExcludedAttributes = excludedAttributesFromContext(ctx)
 	rawVal, err := translator.TerraformAsRaw(ctx, val)
 
 	if err != nil {
@@ -45,6 +54,7 @@ func ConvertTerraformToOdataRaw(ctx context.Context, diags *diag.Diagnostics, sc
 	if middlewareFunc != nil {
 		params := TerraformToGraphMiddlewareParams{
 			Config:   config,
+			State:    state,
 			RawVal:   rawVal,
 			IsUpdate: isUpdate,
 		}
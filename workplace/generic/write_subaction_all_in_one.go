@@ -51,6 +51,7 @@ func (a *WriteSubActionAllInOne) executeRequest(ctx context.Context, diags *diag
 		// nothing to do for us here
 		return
 	}
+	wsaReq.Wrote = true
 
 	jsonBody, err := json.Marshal(rawBody)
 	if err != nil {
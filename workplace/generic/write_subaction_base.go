@@ -10,6 +10,7 @@ import (
 type WriteSubAction interface {
 	Initialize()
 	CheckRunAction(wsaOperation OperationType) bool
+	CheckWritePre(wsaOperation OperationType) bool
 	ExecutePre(context.Context, *diag.Diagnostics, *WriteSubActionRequest)
 	ExecutePost(context.Context, *diag.Diagnostics, *WriteSubActionRequest)
 }
@@ -33,6 +34,8 @@ type WriteSubActionRequest struct {
 	ReqPlan        *tfsdk.Plan
 	RespState      *tfsdk.State
 	RespPrivate    PrivateDataGetSetter
+	// Wrote must be set by ExecutePost when writing to MS Graph (as this might change the entity and its ETag)
+	Wrote bool
 }
 
 func (a *WriteSubActionBase) Initialize() {
@@ -53,6 +56,12 @@ func (a *WriteSubActionBase) CheckRunAction(wsaOperation OperationType) bool {
 		(wsaOperation == OperationDelete && a.EmptyBeforeDelete)
 }
 
+// CheckWritePre checks whether ExecutePre already writes to MS Graph (and therefore might change the entity and its
+// ETag) for the operation.
+func (a *WriteSubActionBase) CheckWritePre(wsaOperation OperationType) bool {
+	return wsaOperation == OperationDelete && a.EmptyBeforeDelete
+}
+
 func (a *WriteSubActionBase) ExecutePre(ctx context.Context, diags *diag.Diagnostics, wsaReq *WriteSubActionRequest,
 	executeImpl func(ctx context.Context, diags *diag.Diagnostics, wsaRequest *WriteSubActionRequest)) {
 	switch wsaReq.Operation {
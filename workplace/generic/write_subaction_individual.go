@@ -3,6 +3,9 @@ package generic
 import (
 	"context"
 	"fmt"
+	"net/http"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -70,6 +73,10 @@ func (a *WriteSubActionIndividual) ExecutePost(ctx context.Context, diags *diag.
 		diags.AddError(ErrorSummary, fmt.Sprintf(errorDetailPrefix+"planTfRaw is not tftypes.Value but %T", planTfRaw))
 		return
 	}
+	if !planTf.IsKnown() {
+		// not set in config (for optional and computed attributes), so the elements are not managed by Terraform
+		return
+	}
 	if err := planTf.As(&planTfSlice); err != nil {
 		diags.AddError(ErrorSummary, fmt.Sprintf(errorDetailPrefix+"%s", err.Error()))
 		return
@@ -137,6 +144,7 @@ func (a *WriteSubActionIndividual) ExecutePost(ctx context.Context, diags *diag.
 		// nothing to do
 		return
 	}
+	wsaReq.Wrote = true
 
 	parentId := wsaReq.GenRes.AccessParams.GetId(ctx, diags, wsaReq.Id, wsaReq.IdAttributer)
 	if diags.HasError() {
@@ -159,6 +167,10 @@ func (a *WriteSubActionIndividual) ExecutePost(ctx context.Context, diags *diag.
 
 	// We do not check for errors after Graph operations but continue to try to finish other tasks as much as possible.
 	// Our caller will check for errors and exit later.
+	// All elements of each kind get sent using JSON batches to avoid lots of single requests.
+
+	graphClient := wsaReq.GenRes.AccessParams.graphClient
+	validStatusCodes := []int{http.StatusOK, http.StatusCreated, http.StatusNoContent}
 
 	addElementsFunc := func() {
 
@@ -167,6 +179,7 @@ func (a *WriteSubActionIndividual) ExecutePost(ctx context.Context, diags *diag.
 			createUri += "/$ref"
 		}
 
+		requests := make([]msgraph.BatchRequest, 0, len(elementsToAdd))
 		for _, v := range elementsToAdd {
 
 			var diags2 diag.Diagnostics
@@ -176,14 +189,27 @@ func (a *WriteSubActionIndividual) ExecutePost(ctx context.Context, diags *diag.
 				return
 			}
 
-			wsaReq.GenRes.AccessParams.CreateRaw2(ctx, diags, createUri, nil, vRaw, true, false)
+			jsonVal := ConvertOdataRawToJson(ctx, diags, vRaw, "Plan")
+			if diags.HasError() {
+				return
+			}
+
+			requests = append(requests, msgraph.BatchRequest{
+				Method:           http.MethodPost,
+				Uri:              msgraph.Uri{Entity: createUri},
+				Body:             jsonVal,
+				ValidStatusCodes: validStatusCodes,
+			})
 		}
+
+		BatchRaw(ctx, diags, graphClient, requests, "Unable to create with MS Graph")
 	}
 
 	updateElementsFunc := func() {
 
 		// no need to check fo a.IsOdataReference here as updates are not supported for it
 
+		requests := make([]msgraph.BatchRequest, 0, len(elementsToUpdate))
 		for _, v := range elementsToUpdate {
 
 			var diags2 diag.Diagnostics
@@ -193,12 +219,26 @@ func (a *WriteSubActionIndividual) ExecutePost(ctx context.Context, diags *diag.
 				return
 			}
 
-			wsaReq.GenRes.AccessParams.UpdateRaw2(ctx, diags, childBaseUri, id, nil, vRaw, false)
+			uri := wsaReq.GenRes.AccessParams.GetUriWithIdForUD(ctx, diags, childBaseUri, id, nil)
+			jsonVal := ConvertOdataRawToJson(ctx, diags, vRaw, "Plan")
+			if diags.HasError() {
+				return
+			}
+
+			requests = append(requests, msgraph.BatchRequest{
+				Method:           http.MethodPatch,
+				Uri:              uri,
+				Body:             jsonVal,
+				ValidStatusCodes: validStatusCodes,
+			})
 		}
+
+		BatchRaw(ctx, diags, graphClient, requests, "Unable to update with MS Graph")
 	}
 
 	deleteElementsFunc := func() {
 
+		requests := make([]msgraph.BatchRequest, 0, len(elementsToDelete))
 		for _, v := range elementsToDelete {
 
 			var diags2 diag.Diagnostics
@@ -212,8 +252,19 @@ func (a *WriteSubActionIndividual) ExecutePost(ctx context.Context, diags *diag.
 				id += "/$ref"
 			}
 
-			wsaReq.GenRes.AccessParams.DeleteRaw(ctx, diags, childBaseUri, id, nil)
+			uri := wsaReq.GenRes.AccessParams.GetUriWithIdForUD(ctx, diags, childBaseUri, id, nil)
+			if diags.HasError() {
+				return
+			}
+
+			requests = append(requests, msgraph.BatchRequest{
+				Method:           http.MethodDelete,
+				Uri:              uri,
+				ValidStatusCodes: []int{http.StatusOK, http.StatusNoContent},
+			})
 		}
+
+		BatchRaw(ctx, diags, graphClient, requests, "Error deleting from MS Graph")
 	}
 
 	if a.IsOdataReference {
@@ -23,6 +23,10 @@ func (a *WriteSubActionPostAndPatch) CheckRunAction(wsaOperation OperationType)
 	return wsaOperation == OperationCreate
 }
 
+func (a *WriteSubActionPostAndPatch) CheckWritePre(wsaOperation OperationType) bool {
+	return false
+}
+
 func (a *WriteSubActionPostAndPatch) ExecutePre(ctx context.Context, diags *diag.Diagnostics, wsaReq *WriteSubActionRequest) {
 	// copy all attributes and only remove the ones that should not be included in the first POST request
 	attributesForPatch := make(map[string]any)
@@ -48,5 +52,6 @@ func (a *WriteSubActionPostAndPatch) ExecutePost(ctx context.Context, diags *dia
 		return
 	}
 
+	wsaReq.Wrote = true
 	wsaReq.GenRes.AccessParams.UpdateRaw(ctx, diags, "", wsaReq.Id, wsaReq.IdAttributer, attributesForPatch)
 }
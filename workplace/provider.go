@@ -10,31 +10,44 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/http/httputil"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
 	"terraform-provider-microsoft365wp/workplace/services"
 	mobileappfuncs "terraform-provider-microsoft365wp/workplace/services/mobile_app_funcs"
+	"terraform-provider-microsoft365wp/workplace/util/cassette"
+	"terraform-provider-microsoft365wp/workplace/util/redact"
 	"terraform-provider-microsoft365wp/workplace/util/retryablehttputil"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpvalidator"
 
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/claims"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ provider.ProviderWithFunctions = &workplaceProvider{}
+	_ provider.ProviderWithFunctions          = &workplaceProvider{}
+	_ provider.ProviderWithEphemeralResources = &workplaceProvider{}
+	_ provider.ProviderWithListResources      = &workplaceProvider{}
+	_ provider.ProviderWithActions            = &workplaceProvider{}
 )
 
 // Helper function to simplify provider server and testing implementation.
@@ -76,6 +89,10 @@ func (p *workplaceProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				// DefaultFunc: schema.EnvDefaultFunc("ARM_METADATA_HOSTNAME", ""),
 				Description: "The Hostname which should be used for the Azure Metadata Service.",
 			},
+			"api_version": schema.StringAttribute{
+				Optional:    true,
+				Description: "The preferred MS Graph API version. Possible values are: `beta` and `v1.0`. Only applies to resources and data sources that support several API versions (see their `api_version` attribute), all others always use `beta`. Defaults to `beta`",
+			},
 
 			// Client Certificate specific fields
 			"client_certificate": schema.StringAttribute{
@@ -156,6 +173,88 @@ func (p *workplaceProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:    true,
 				Description: "Allow tools/wpGetToken to be used for authentication",
 			},
+			"wgt_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of tools/wpGetToken when using `use_wgt`. Defaults to `wpGetToken` (looked up using `PATH`)",
+			},
+			"wgt_args": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional arguments to pass to tools/wpGetToken when using `use_wgt`",
+			},
+
+			"auxiliary_tenant_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "IDs of additional tenants to obtain access tokens for (e.g. for cross-tenant scenarios). These tokens are sent in the `x-ms-authorization-auxiliary` header of all MS Graph requests and can be obtained using the `tenant_id` attribute of the `access_token` ephemeral resource. Can also be set using the environment variable `ARM_AUXILIARY_TENANT_IDS` (separated by `;`)",
+			},
+
+			// MS Graph client specific fields
+			"graph_endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: "Base URL of MS Graph (e.g. of a local stand-in for testing) overriding the one of the configured `environment`, e.g. `https://graph.microsoft.com`. Authentication still uses the configured `environment`",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the HTTP proxy to send MS Graph and Azure Storage requests through. Defaults to the proxy configured using the environment variables `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY`",
+			},
+			"tls_ca_bundle_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a PEM file with additional CA certificates to trust for MS Graph and Azure Storage requests (e.g. of a TLS intercepting proxy)",
+			},
+			"tls_client_certificate_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a PEM encoded client certificate to present for MS Graph and Azure Storage requests (mutual TLS, e.g. for an egress proxy). Requires `tls_client_key_path`",
+			},
+			"tls_client_key_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of the PEM encoded private key of `tls_client_certificate_path`",
+			},
+			"disable_batching": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Disable combining read requests (and requests of sub-actions) into MS Graph JSON batches and send all requests individually instead",
+			},
+			"record_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a cassette file to which all MS Graph requests and their responses will be appended (with secrets redacted), e.g. to attach it to a bug report. Implies `disable_batching`",
+			},
+			"http_body_log_level": schema.StringAttribute{
+				Optional:    true,
+				Description: "Log level of the bodies of MS Graph requests and responses. Possible values are: `info`, `debug` and `trace`. Request and status lines and headers are always logged at `info` level. Defaults to `info`",
+			},
+			"redact_headers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional HTTP headers to redact when logging MS Graph requests and responses or recording them to a cassette (the `Authorization` header is always redacted)",
+			},
+			"redact_json_paths": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional MS Graph JSON attribute paths (e.g. `passwordProfile.password`) to redact when logging MS Graph requests and responses or recording them to a cassette (attributes marked as sensitive in the schema are always redacted)",
+			},
+			"rate_limits": schema.MapAttribute{
+				ElementType: types.Float64Type,
+				Optional:    true,
+				Description: "Maximum number of MS Graph requests per second by workload, i.e. the class of MS Graph services throttled together: `intune` (`deviceManagement` and `deviceAppManagement`), `cloudPc` (`deviceManagement/virtualEndpoint`), `directory` (`users`, `groups`, `applications`, `servicePrincipals`, `policies` etc.), `identityAndAccess` (`identity`), `identityGovernance` and `networkAccess`. Requests to other services belong to the workload named after the first segment of their path (after the API version). The key `*` applies to all other workloads. Items of JSON batches are limited individually. Requests delayed by this limit or throttled by MS Graph are logged at `info` level along with the request statistics of their workload so far. Requests are not limited by default",
+			},
+			"default_timeouts": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{Optional: true, Validators: []validator.String{wpvalidator.Duration()}, Description: "Default timeout of create operations"},
+					"read":   schema.StringAttribute{Optional: true, Validators: []validator.String{wpvalidator.Duration()}, Description: "Default timeout of read operations"},
+					"update": schema.StringAttribute{Optional: true, Validators: []validator.String{wpvalidator.Duration()}, Description: "Default timeout of update operations"},
+					"delete": schema.StringAttribute{Optional: true, Validators: []validator.String{wpvalidator.Duration()}, Description: "Default timeout of delete operations"},
+				},
+				Description: "Default timeouts of the `create`, `read`, `update` and `delete` operations of all resources (e.g. `30m`), which can be overridden by their `timeouts` block. Operations do not time out by default",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of MS Graph requests in flight at the same time (across all resources). Requests are not limited by default",
+			},
+			"replay_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a cassette file (see `record_path`) from which the responses will be served instead of sending the requests to MS Graph. No authentication will take place. Implies `disable_batching`",
+			},
 		},
 		Description: "Terraform Provider for Microsoft 365",
 	}
@@ -200,6 +299,20 @@ func (p *workplaceProvider) Configure(ctx context.Context, req provider.Configur
 			}
 			return result
 
+		case types.Int64:
+			var result int64
+			if !typedTfTarget.IsNull() {
+				result = typedTfTarget.ValueInt64()
+			} else if envVarValue := os.Getenv(envVarName); envVarValue != "" {
+				var err error
+				if result, err = strconv.ParseInt(envVarValue, 10, 64); err != nil {
+					resp.Diagnostics.AddError(fmt.Sprintf("Invalid value of environment variable %s", envVarName), err.Error())
+				}
+			} else {
+				result = defaultValue.(int64)
+			}
+			return result
+
 		}
 
 		panic(fmt.Sprintf("Don't know how to deal with config attribute of type %T", tfTarget))
@@ -212,6 +325,101 @@ func (p *workplaceProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	// Secrets are redacted from logged requests/responses and cassettes
+	var redactHeaders, redactJsonPaths []string
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("redact_headers"), &redactHeaders)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("redact_json_paths"), &redactJsonPaths)...)
+	redactor := redact.Default().With(append(p.sensitiveGraphAttributePaths(ctx), redactJsonPaths...)...)
+	redactor.Headers = append(redactor.Headers, redactHeaders...)
+
+	// Client-side rate limits are shared by all clients (also within JSON batches)
+	var rateLimits map[string]float64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rate_limits"), &rateLimits)...)
+	rateLimiter := msgraph.NewRateLimiter(rateLimits, int(dGet("max_concurrent_requests", "ARM_MAX_CONCURRENT_REQUESTS", int64(0)).(int64)))
+
+	// Default timeouts of resource operations (might be overridden by resources)
+	var defaultTimeouts generic.Timeouts
+	for operation, timeout := range map[string]*time.Duration{"create": &defaultTimeouts.Create, "read": &defaultTimeouts.Read,
+		"update": &defaultTimeouts.Update, "delete": &defaultTimeouts.Delete} {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("default_timeouts").AtName(operation), &value)...)
+		if value.ValueString() != "" {
+			var err error
+			if *timeout, err = time.ParseDuration(value.ValueString()); err != nil {
+				addError(fmt.Errorf("invalid default_timeouts.%s: %w", operation, err))
+				return
+			}
+		}
+	}
+
+	// Proxy and TLS settings apply to all requests but the ones for authentication
+	httpTransport, transportErr := retryablehttputil.NewTransport(retryablehttputil.TransportOptions{
+		ProxyUrl:              dGet("proxy_url", "ARM_PROXY_URL", "").(string),
+		CaBundlePath:          dGet("tls_ca_bundle_path", "ARM_TLS_CA_BUNDLE_PATH", "").(string),
+		ClientCertificatePath: dGet("tls_client_certificate_path", "ARM_TLS_CLIENT_CERTIFICATE_PATH", "").(string),
+		ClientKeyPath:         dGet("tls_client_key_path", "ARM_TLS_CLIENT_KEY_PATH", "").(string),
+	})
+	if transportErr != nil {
+		addError(transportErr)
+		return
+	}
+
+	// Log HTTP requests and responses, bodies possibly at a more verbose level only
+	httpBodyLogLevel := dGet("http_body_log_level", "ARM_HTTP_BODY_LOG_LEVEL", "info").(string)
+	if httpBodyLogLevel != "info" && httpBodyLogLevel != "debug" && httpBodyLogLevel != "trace" {
+		addError(fmt.Errorf("invalid http_body_log_level %q, must be one of: info, debug, trace", httpBodyLogLevel))
+		return
+	}
+
+	newGraphClient := func(authorizer auth.Authorizer) *msgraph.Client {
+		logBody := func(head []byte, body []byte) {
+			switch httpBodyLogLevel {
+			case "debug":
+				tflog.Info(ctx, fmt.Sprintf("%s\n", head))
+				tflog.Debug(ctx, fmt.Sprintf("%s\n", body))
+			case "trace":
+				tflog.Info(ctx, fmt.Sprintf("%s\n", head))
+				tflog.Trace(ctx, fmt.Sprintf("%s\n", body))
+			default:
+				tflog.Info(ctx, fmt.Sprintf("%s%s\n", head, body))
+			}
+		}
+		requestLogger := func(req *http.Request) (*http.Request, error) {
+			if req != nil {
+				if head, body, err := redactor.DumpRequest(req); err == nil {
+					logBody(head, body)
+				}
+			}
+			return req, nil
+		}
+		responseLogger := func(req *http.Request, resp *http.Response) (*http.Response, error) {
+			if resp != nil {
+				if head, body, err := redactor.DumpResponse(resp); err == nil {
+					logBody(head, body)
+				}
+			}
+			return resp, nil
+		}
+
+		graphClient := msgraph.NewClient(msgraph.VersionBeta)
+		graphClient.PreferredApiVersion = msgraph.ApiVersion(dGet("api_version", "ARM_API_VERSION", string(msgraph.VersionBeta)).(string))
+		graphClient.Authorizer = authorizer
+		graphClient.RequestMiddlewares = &[]msgraph.RequestMiddleware{requestLogger}
+		graphClient.ResponseMiddlewares = &[]msgraph.ResponseMiddleware{responseLogger}
+		if graphEndpoint := dGet("graph_endpoint", "ARM_GRAPH_ENDPOINT", "").(string); graphEndpoint != "" {
+			graphClient.Endpoint = strings.TrimSuffix(graphEndpoint, "/")
+		}
+		graphClient.RetryableClient.HTTPClient.Transport = httpTransport
+		retryablehttputil.ConfigureClientRetryLimitsAndBackoff(graphClient.RetryableClient)
+		graphClient.UseRateLimiter(rateLimiter)
+		// batch composition depends on timing, so cassettes must contain individual requests to be replayable
+		cassetteMode := dGet("record_path", "ARM_RECORD_PATH", "").(string) != "" || dGet("replay_path", "ARM_REPLAY_PATH", "").(string) != ""
+		if !dGet("disable_batching", "ARM_DISABLE_BATCHING", false).(bool) && !cassetteMode {
+			graphClient.Batcher = msgraph.NewBatcher()
+		}
+		return &graphClient
+	}
+
 	// --- Copied from member functions ---
 
 	decodeCertificate := func(clientCertificate string) ([]byte, error) {
@@ -247,6 +455,34 @@ func (p *workplaceProvider) Configure(ctx context.Context, req provider.Configur
 		return idToken, nil
 	}
 
+	// --- Cassette replay (without authentication) ---
+
+	recordPath := dGet("record_path", "ARM_RECORD_PATH", "").(string)
+	replayPath := dGet("replay_path", "ARM_REPLAY_PATH", "").(string)
+	if apiVersion := dGet("api_version", "ARM_API_VERSION", string(msgraph.VersionBeta)).(string); apiVersion != string(msgraph.VersionBeta) && apiVersion != string(msgraph.Version10) {
+		addError(fmt.Errorf("invalid api_version %q, must be one of: %s, %s", apiVersion, msgraph.VersionBeta, msgraph.Version10))
+		return
+	}
+	if recordPath != "" && replayPath != "" {
+		addError(errors.New("only one of record_path and replay_path may be specified"))
+		return
+	}
+	if replayPath != "" {
+		replayer, err := cassette.NewReplayer(replayPath, redactor)
+		if err != nil {
+			addError(err)
+			return
+		}
+		graphClient := newGraphClient(nil)
+		*graphClient.RequestMiddlewares = append(*graphClient.RequestMiddlewares, replayer.RequestMiddleware)
+		resp.DataSourceData = graphClient
+		resp.ResourceData = &generic.ProviderData{GraphClient: graphClient, DefaultTimeouts: defaultTimeouts, HttpTransport: httpTransport}
+		resp.ListResourceData = resp.ResourceData
+		resp.ActionData = resp.ResourceData
+		resp.EphemeralResourceData = &accessTokenData{}
+		return
+	}
+
 	// --- Copied from providerConfigure ---
 
 	var certData []byte
@@ -291,9 +527,20 @@ func (p *workplaceProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
+	var auxiliaryTenantIds, wgtArgs []string
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auxiliary_tenant_ids"), &auxiliaryTenantIds)...)
+	if auxiliaryTenantIds == nil && os.Getenv("ARM_AUXILIARY_TENANT_IDS") != "" {
+		auxiliaryTenantIds = strings.Split(os.Getenv("ARM_AUXILIARY_TENANT_IDS"), ";")
+	}
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("wgt_args"), &wgtArgs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	authConfig := auth.Credentials{
 		Environment:                 *env,
 		TenantID:                    dGet("tenant_id", "ARM_TENANT_ID", "").(string),
+		AuxiliaryTenantIDs:          auxiliaryTenantIds,
 		ClientID:                    dGet("client_id", "ARM_CLIENT_ID", "").(string),
 		ClientCertificateData:       certData,
 		ClientCertificatePassword:   dGet("client_certificate_password", "ARM_CLIENT_CERTIFICATE_PASSWORD", "").(string),
@@ -312,20 +559,32 @@ func (p *workplaceProvider) Configure(ctx context.Context, req provider.Configur
 	}
 
 	api := authConfig.Environment.MicrosoftGraph
+	graphScope, err := environments.Scope(api)
+	if err != nil {
+		addError(err)
+		return
+	}
 
-	var authorizer auth.Authorizer
-	if dGet("use_wgt", "ARM_USE_WGT", false).(bool) {
-		authorizer, err = NewWgtAuthorizer(ctx)
-		if err != nil {
-			resp.Diagnostics.AddError("Could not configure AzureCli Authorizer", err.Error())
+	// Authorizers for other APIs are used by the access_token ephemeral resource
+	newAuthorizer := func(ctx context.Context, api environments.Api) (auth.Authorizer, error) {
+		if dGet("use_wgt", "ARM_USE_WGT", false).(bool) {
+			if scope, err := environments.Scope(api); err != nil || *scope != *graphScope {
+				return nil, fmt.Errorf("wpGetToken only supports the scope %s", *graphScope)
+			}
+			return NewWgtAuthorizer(ctx, WgtAuthorizerOptions{
+				Path:         dGet("wgt_path", "ARM_WGT_PATH", "").(string),
+				Args:         wgtArgs,
+				AuxTenantIds: auxiliaryTenantIds,
+			})
 		}
-	} else {
 		// --- Copied from internal/clients/ClientBuilder ---
-		authorizer, err = auth.NewAuthorizerFromCredentials(ctx, authConfig, api)
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to build authorizer", err.Error())
-			return
-		}
+		return auth.NewAuthorizerFromCredentials(ctx, authConfig, api)
+	}
+
+	authorizer, err := newAuthorizer(ctx, api)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to build authorizer", err.Error())
+		return
 	}
 
 	// --- Copied from internal/clients/Client ---
@@ -356,34 +615,29 @@ func (p *workplaceProvider) Configure(ctx context.Context, req provider.Configur
 
 	// --- End of copied code ---
 
-	// Log HTTP requests and responses
-	requestLogger := func(req *http.Request) (*http.Request, error) {
-		if req != nil {
-			if dump, err := httputil.DumpRequestOut(req, true); err == nil {
-				tflog.Info(ctx, fmt.Sprintf("%s\n", dump))
-			}
-		}
-		return req, nil
-	}
-	responseLogger := func(req *http.Request, resp *http.Response) (*http.Response, error) {
-		if resp != nil {
-			if dump, err := httputil.DumpResponse(resp, true); err == nil {
-				tflog.Info(ctx, fmt.Sprintf("%s\n", dump))
-			}
+	graphClient := newGraphClient(authorizer)
+	graphClient.UseAuxiliaryTokens = len(auxiliaryTenantIds) > 0
+	if recordPath != "" {
+		recorder, err := cassette.NewRecorder(recordPath, redactor)
+		if err != nil {
+			addError(err)
+			return
 		}
-		return resp, nil
+		*graphClient.RequestMiddlewares = append(*graphClient.RequestMiddlewares, recorder.RequestMiddleware)
+		*graphClient.ResponseMiddlewares = append(*graphClient.ResponseMiddlewares, recorder.ResponseMiddleware)
 	}
 
-	graphClient := msgraph.NewClient(msgraph.VersionBeta)
-	graphClient.Authorizer = authorizer
-	graphClient.RequestMiddlewares = &[]msgraph.RequestMiddleware{requestLogger}
-	graphClient.ResponseMiddlewares = &[]msgraph.ResponseMiddleware{responseLogger}
-	retryablehttputil.ConfigureClientRetryLimitsAndBackoff(graphClient.RetryableClient)
-
 	// Make the graphClient available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = &graphClient
-	resp.ResourceData = &graphClient
+	resp.DataSourceData = graphClient
+	resp.ResourceData = &generic.ProviderData{GraphClient: graphClient, DefaultTimeouts: defaultTimeouts, HttpTransport: httpTransport}
+	resp.ListResourceData = resp.ResourceData
+	resp.ActionData = resp.ResourceData
+	resp.EphemeralResourceData = &accessTokenData{
+		NewAuthorizer:      newAuthorizer,
+		GraphScope:         *graphScope,
+		AuxiliaryTenantIds: auxiliaryTenantIds,
+	}
 }
 
 // Defines the data sources implemented in the provider.
@@ -393,6 +647,8 @@ func (p *workplaceProvider) DataSources(_ context.Context) []func() datasource.D
 		func() datasource.DataSource { return &services.AdministrativeUnitPluralDataSource },
 		func() datasource.DataSource { return &services.AndroidManagedAppProtectionSingularDataSource },
 		func() datasource.DataSource { return &services.AndroidManagedAppProtectionPluralDataSource },
+		func() datasource.DataSource { return &services.AppRoleAssignmentSingularDataSource },
+		func() datasource.DataSource { return &services.AppRoleAssignmentPluralDataSource },
 		func() datasource.DataSource { return &services.ApplicationSingularDataSource },
 		func() datasource.DataSource { return &services.ApplicationPluralDataSource },
 		func() datasource.DataSource { return &services.AttributeSetSingularDataSource },
@@ -524,6 +780,8 @@ func (p *workplaceProvider) DataSources(_ context.Context) []func() datasource.D
 		func() datasource.DataSource { return &services.SynchronizationSchemaJsonSingularDataSource },
 		func() datasource.DataSource { return &services.TargetedManagedAppConfigurationSingularDataSource },
 		func() datasource.DataSource { return &services.TargetedManagedAppConfigurationPluralDataSource },
+		func() datasource.DataSource { return &services.UnifiedRoleAssignmentSingularDataSource },
+		func() datasource.DataSource { return &services.UnifiedRoleAssignmentPluralDataSource },
 		func() datasource.DataSource { return &services.UnifiedRoleDefinitionSingularDataSource },
 		func() datasource.DataSource { return &services.UnifiedRoleDefinitionPluralDataSource },
 		func() datasource.DataSource { return &services.UnifiedRoleManagementPolicySingularDataSource },
@@ -532,6 +790,8 @@ func (p *workplaceProvider) DataSources(_ context.Context) []func() datasource.D
 		func() datasource.DataSource { return &services.UnifiedRoleManagementPolicyAssignmentPluralDataSource },
 		func() datasource.DataSource { return &services.UserSingularDataSource },
 		func() datasource.DataSource { return &services.UserPluralDataSource },
+		func() datasource.DataSource { return &services.UserAssignedLicenseSingularDataSource },
+		func() datasource.DataSource { return &services.UserAssignedLicensePluralDataSource },
 		func() datasource.DataSource { return &services.WindowsDriverUpdateProfileSingularDataSource },
 		func() datasource.DataSource { return &services.WindowsDriverUpdateProfilePluralDataSource },
 		func() datasource.DataSource { return &services.WindowsFeatureUpdateProfileSingularDataSource },
@@ -545,6 +805,10 @@ func (p *workplaceProvider) Resources(_ context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		func() resource.Resource { return &services.AdministrativeUnitResource },
 		func() resource.Resource { return &services.AndroidManagedAppProtectionResource },
+		func() resource.Resource { return &services.AppRoleAssignmentResource },
+		func() resource.Resource { return &services.ApplicationResource },
+		func() resource.Resource { return &services.ApplicationKeyCredentialResource },
+		func() resource.Resource { return &services.ApplicationPasswordCredentialResource },
 		func() resource.Resource { return &services.AttributeSetResource },
 		func() resource.Resource { return &services.AuthenticationCombinationConfigurationResource },
 		func() resource.Resource { return &services.AuthenticationContextClassReferenceResource },
@@ -580,6 +844,7 @@ func (p *workplaceProvider) Resources(_ context.Context) []func() resource.Resou
 		func() resource.Resource { return &services.DeviceRegistrationPolicyResource },
 		func() resource.Resource { return &services.DeviceShellScriptResource },
 		func() resource.Resource { return &services.ExternalIdentitiesPolicyResource },
+		func() resource.Resource { return &services.GroupResource },
 		func() resource.Resource { return &services.GroupAssignedLicenseResource },
 		func() resource.Resource { return &services.IdentityGovernanceCustomTaskExtensionResource },
 		func() resource.Resource { return &services.IdentityGovernanceLifecycleManagementSettingsResource },
@@ -593,17 +858,69 @@ func (p *workplaceProvider) Resources(_ context.Context) []func() resource.Resou
 		func() resource.Resource { return &services.MobilityManagementPolicyResource },
 		func() resource.Resource { return &services.NetworkaccessTenantStatusResource },
 		func() resource.Resource { return &services.NotificationMessageTemplateResource },
+		func() resource.Resource { return &services.PrivilegedAccessGroupAssignmentScheduleResource },
+		func() resource.Resource { return &services.PrivilegedAccessGroupEligibilityScheduleResource },
+		func() resource.Resource { return &services.ServicePrincipalResource },
 		func() resource.Resource { return &services.SharepointSettingsResource },
 		func() resource.Resource { return &services.SynchronizationSchemaJsonResource },
 		func() resource.Resource { return &services.TargetedManagedAppConfigurationResource },
+		func() resource.Resource { return &services.UnifiedRoleAssignmentResource },
+		func() resource.Resource { return &services.UnifiedRoleAssignmentScheduleRequestResource },
 		func() resource.Resource { return &services.UnifiedRoleDefinitionResource },
+		func() resource.Resource { return &services.UnifiedRoleEligibilityScheduleRequestResource },
 		func() resource.Resource { return &services.UnifiedRoleManagementPolicyResource },
+		func() resource.Resource { return &services.UserResource },
+		func() resource.Resource { return &services.UserAssignedLicenseResource },
 		func() resource.Resource { return &services.WindowsDriverUpdateProfileResource },
 		func() resource.Resource { return &services.WindowsFeatureUpdateProfileResource },
 		func() resource.Resource { return &services.WindowsManagementAppResource },
 	}
 }
 
+// Collects the MS Graph JSON paths of all sensitive resource attributes, so they can be redacted.
+func (p *workplaceProvider) sensitiveGraphAttributePaths(ctx context.Context) []string {
+	var result []string
+	for _, f := range p.Resources(ctx) {
+		if r, ok := f().(*generic.GenericResource); ok {
+			var resp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &resp)
+			result = append(result, generic.SensitiveGraphAttributePaths(resp.Schema)...)
+		}
+	}
+	return result
+}
+
+// Defines the list resources implemented in the provider, i.e. one for each resource whose entities can be listed.
+func (p *workplaceProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	result := []func() list.ListResource{}
+	for _, f := range p.Resources(ctx) {
+		if r, ok := f().(*generic.GenericResource); ok && r.SupportsList() {
+			listResource := generic.CreateGenericListResourceFromResource(r)
+			result = append(result, func() list.ListResource { return listResource })
+		}
+	}
+	return result
+}
+
+// Defines the actions implemented in the provider.
+func (p *workplaceProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		func() action.Action { return &services.CloudPcReprovisionAction },
+		func() action.Action { return &services.IdentityGovernanceWorkflowActivateAction },
+		func() action.Action { return &services.ManagedDeviceRetireAction },
+		func() action.Action { return &services.ManagedDeviceSyncAction },
+		func() action.Action { return &services.ManagedDeviceWipeAction },
+		func() action.Action { return &services.VppTokenSyncLicensesAction },
+	}
+}
+
+// Defines the ephemeral resources implemented in the provider.
+func (p *workplaceProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource { return &AccessTokenEphemeralResource{} },
+	}
+}
+
 func (p *workplaceProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
 		func() function.Function { return &mobileappfuncs.ParseIntunewinMetadataFunction{} },
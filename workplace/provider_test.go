@@ -0,0 +1,51 @@
+package workplace
+
+import (
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResourceIdentitySchemas(t *testing.T) {
+	ctx := t.Context()
+	p := &workplaceProvider{}
+
+	for _, f := range p.Resources(ctx) {
+		r, ok := f().(*generic.GenericResource)
+		if !ok {
+			continue
+		}
+		schemaResp := resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		identitySchemaResp := resource.IdentitySchemaResponse{}
+		r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+		if diags := identitySchemaResp.IdentitySchema.ValidateImplementation(ctx); diags.HasError() {
+			t.Errorf("%s: invalid identity schema: %s", r.TypeNameSuffix, diagsError(diags))
+		}
+
+		// all identity attributes must exist as string attributes in the resource schema
+		for name := range identitySchemaResp.IdentitySchema.Attributes {
+			if attribute, ok := schemaResp.Schema.Attributes[name]; !ok || !attribute.GetType().Equal(types.StringType) {
+				t.Errorf("%s: identity attribute %q is no string attribute of the resource", r.TypeNameSuffix, name)
+			}
+		}
+	}
+}
+
+func TestListResourceSchemas(t *testing.T) {
+	ctx := t.Context()
+	p := &workplaceProvider{}
+
+	for _, f := range p.ListResources(ctx) {
+		l := f().(*generic.GenericListResource)
+		schemaResp := list.ListResourceSchemaResponse{}
+		l.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &schemaResp)
+		if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+			t.Errorf("%s: invalid list resource schema: %s", l.Resource.TypeNameSuffix, diagsError(diags))
+		}
+	}
+}
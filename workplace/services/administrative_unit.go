@@ -1,6 +1,7 @@
 package services
 
 import (
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
 
@@ -14,7 +15,8 @@ var (
 		TypeNameSuffix: "administrative_unit",
 		SpecificSchema: administrativeUnitResourceSchema,
 		AccessParams: generic.AccessParams{
-			BaseUri: "/administrativeUnits",
+			BaseUri:     "/administrativeUnits",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
 			ReadOptions: generic.ReadOptions{
 				DataSource: generic.DataSourceOptions{
 					ExtraFilterAttributes: []string{"is_member_management_restricted", "membership_type"},
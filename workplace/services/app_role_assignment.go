@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+var (
+	AppRoleAssignmentResource = generic.GenericResource{
+		TypeNameSuffix: "app_role_assignment",
+		SpecificSchema: appRoleAssignmentResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/servicePrincipals",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ParentEntities: generic.ParentEntities{
+				{
+					ParentIdField: path.Root("resource_id"),
+					UriSuffix:     "appRoleAssignedTo",
+				},
+			},
+			ReadOptions: generic.ReadOptions{
+				SingleItemUseODataFilter: true,
+				DataSource: generic.DataSourceOptions{
+					ExtraFilterAttributes: []string{"principal_id"},
+					Plural: generic.PluralOptions{
+						ExtraAttributes: []string{"app_role_id", "principal_display_name", "principal_id", "principal_type"},
+					},
+				},
+			},
+			TerraformToGraphMiddleware: appRoleAssignmentTerraformToGraphMiddleware,
+		},
+	}
+
+	AppRoleAssignmentSingularDataSource = generic.CreateGenericDataSourceSingularFromResource(
+		&AppRoleAssignmentResource)
+
+	AppRoleAssignmentPluralDataSource = generic.CreateGenericDataSourcePluralFromResource(
+		&AppRoleAssignmentResource, "")
+)
+
+func appRoleAssignmentTerraformToGraphMiddleware(ctx context.Context, diags *diag.Diagnostics, params *generic.TerraformToGraphMiddlewareParams) generic.TerraformToGraphMiddlewareReturns {
+	// resourceId is not only part of the URI but must also be sent in the body
+	var resourceId string
+	diags.Append(params.Config.GetAttribute(ctx, path.Root("resource_id"), &resourceId)...)
+	if diags.HasError() {
+		return nil
+	}
+	params.RawVal["resourceId"] = resourceId
+	return nil
+}
+
+var appRoleAssignmentResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // appRoleAssignment
+		"resource_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The unique identifier (**id**) for the resource [service principal](service_principal.md) for which the assignment is made. Required on create.",
+		},
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "A unique identifier for the **appRoleAssignment** key. Not nullable. Read-only.",
+		},
+		"app_role_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The identifier (**id**) for the [app role](https://learn.microsoft.com/en-us/graph/api/resources/approle?view=graph-rest-beta) that is assigned to the principal. This app role must be exposed in the **appRoles** property on the resource application's service principal (**resourceId**). If the resource application hasn't declared any app roles, a default app role ID of `00000000-0000-0000-0000-000000000000` can be specified to signal that the principal is assigned to the resource app without any specific app roles. Required on create.",
+		},
+		"created_date_time": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The time when the app role assignment was created. The Timestamp type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Read-only.",
+		},
+		"principal_display_name": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The display name of the user, group, or service principal that was granted the app role assignment. Read-only.",
+		},
+		"principal_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The unique identifier (**id**) for the [user](user.md), [group](group.md), or [service principal](service_principal.md) being granted the app role. Security groups with dynamic memberships are supported. Required on create.",
+		},
+		"principal_type": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The type of the assigned principal. This can either be `User`, `Group`, or `ServicePrincipal`. Read-only.",
+		},
+		"resource_display_name": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The display name of the resource app's service principal to which the assignment is made.",
+		},
+	},
+	MarkdownDescription: "Used to record when a user, group, or service principal is assigned an app role for an app. The assignment is made in the **appRoleAssignedTo** navigation property of the resource [service principal](service_principal.md) (e.g. to grant application permissions of Microsoft Graph to an application, the resource is the service principal of Microsoft Graph and the principal is the service principal of the application). <br/> Also see [Microsoft docs for appRoleAssignment](https://learn.microsoft.com/en-us/graph/api/resources/approleassignment?view=graph-rest-beta).\n\n_Provider_ Note: App role assignments cannot be updated, any change will replace them. To import this resource, an ID consisting of `resource_id` and `id` being joined by a forward slash (`/`) must be used. ||| MS Graph: Entra ID",
+}
@@ -1,15 +1,62 @@
 package services
 
 import (
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 var (
-	applicationResource = generic.GenericResource{
+	ApplicationResource = generic.GenericResource{
 		TypeNameSuffix: "application",
 		SpecificSchema: applicationResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/applications",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ReadOptions: generic.ReadOptions{
+				ExtraRequests: []generic.ReadExtraRequest{
+					{
+						Attribute: "owners",
+					},
+				},
+				DataSource: generic.DataSourceOptions{
+					ExtraFilterAttributes: []string{"app_id"},
+					Plural: generic.PluralOptions{
+						ExtraAttributes: []string{"app_id", "sign_in_audience"},
+					},
+				},
+			},
+			WriteOptions: generic.WriteOptions{
+				SubActions: []generic.WriteSubAction{
+					&generic.WriteSubActionIndividual{
+						WriteSubActionBase: generic.WriteSubActionBase{
+							Attributes: []string{"owners"},
+							UriSuffix:  "owners",
+						},
+						ComparisonKeyAttribute: "id",
+						SetNestedPath:          tftypes.NewAttributePath().WithAttributeName("owners"),
+						IsOdataReference:       true,
+						OdataRefMapTypeToUriPrefix: map[string]string{
+							"": "https://graph.microsoft.com/beta/directoryObjects/", // this will work for users and service principals
+						},
+					},
+				},
+			},
+		},
+	}
+
+	applicationDataSourceResource = generic.GenericResource{
+		TypeNameSuffix: "application",
+		SpecificSchema: applicationDataSourceSchema,
 		AccessParams: generic.AccessParams{
 			BaseUri: "/applications",
 			ReadOptions: generic.ReadOptions{
@@ -24,13 +71,306 @@ var (
 	}
 
 	ApplicationSingularDataSource = generic.CreateGenericDataSourceSingularFromResource(
-		&applicationResource)
+		&applicationDataSourceResource)
 
 	ApplicationPluralDataSource = generic.CreateGenericDataSourcePluralFromResource(
-		&applicationResource, "")
+		&applicationDataSourceResource, "")
 )
 
+var applicationRedirectUrisAttribute = schema.SetAttribute{
+	ElementType:         types.StringType,
+	Optional:            true,
+	PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+	Computed:            true,
+	MarkdownDescription: "Specifies the URLs where user tokens are sent for sign-in, or the redirect URIs where OAuth 2.0 authorization codes and access tokens are sent. <br/> The _provider_ default value is `[]`.",
+}
+
 var applicationResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // application
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "Unique identifier for the application object. This property is referred to as **Object ID** in the Microsoft Entra admin center. Key. Not nullable. Read-only. Supports `$filter` (`eq`, `ne`, `not`, `in`).",
+		},
+		"api": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{ // apiApplication
+				"accept_mapped_claims": schema.BoolAttribute{
+					Optional:            true,
+					PlanModifiers:       []planmodifier.Bool{wpdefaultvaluemodifier.BoolDefaultValue(false)},
+					Computed:            true,
+					MarkdownDescription: "When `true`, allows an application to use claims mapping without specifying a custom signing key. <br/> The _provider_ default value is `false`.",
+				},
+				"known_client_applications": schema.SetAttribute{
+					ElementType:         types.StringType,
+					Optional:            true,
+					PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+					Computed:            true,
+					MarkdownDescription: "Used for bundling consent if you have a solution that contains two parts: a client app and a custom web API app. If you set the appID of the client app to this value, the user only consents once to the client app. Microsoft Entra ID knows that consenting to the client means implicitly consenting to the web API and automatically provisions service principals for both APIs at the same time. Both the client and the web API app must be registered in the same tenant. <br/> The _provider_ default value is `[]`.",
+				},
+				"oauth2_permission_scopes": schema.SetNestedAttribute{
+					Optional: true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{ // permissionScope
+							"admin_consent_description": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "A description of the delegated permissions, intended to be read by an administrator granting the permission on behalf of all users. This text appears in tenant-wide admin consent experiences.",
+							},
+							"admin_consent_display_name": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "The permission's title, intended to be read by an administrator granting the permission on behalf of all users.",
+							},
+							"id": schema.StringAttribute{
+								Required:            true,
+								MarkdownDescription: "Unique delegated permission identifier inside the collection of delegated permissions defined for a resource application.",
+							},
+							"is_enabled": schema.BoolAttribute{
+								Optional:            true,
+								PlanModifiers:       []planmodifier.Bool{wpdefaultvaluemodifier.BoolDefaultValue(true)},
+								Computed:            true,
+								MarkdownDescription: "When you create or update a permission, this property must be set to `true` (which is the default). To delete a permission, this property must first be set to `false`. At that point, in a subsequent call, the permission may be removed. <br/> The _provider_ default value is `true`.",
+							},
+							"type": schema.StringAttribute{
+								Optional:            true,
+								Validators:          []validator.String{stringvalidator.OneOf("User", "Admin")},
+								PlanModifiers:       []planmodifier.String{wpdefaultvaluemodifier.StringDefaultValue("User")},
+								Computed:            true,
+								MarkdownDescription: "The possible values are: `User` and `Admin`. Specifies whether this delegated permission should be considered safe for non-admin users to consent to on behalf of themselves, or whether an administrator consent should always be required. <br/> _Provider_ allowed values are: `User`, `Admin`. The _provider_ default value is `\"User\"`.",
+							},
+							"user_consent_description": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "A description of the delegated permissions, intended to be read by a user granting the permission on their own behalf. This text appears in consent experiences where the user is consenting only on behalf of themselves.",
+							},
+							"user_consent_display_name": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "A title for the permission, intended to be read by a user granting the permission on their own behalf.",
+							},
+							"value": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "Specifies the value to include in the `scp` (scope) claim in access tokens. Must not exceed 120 characters in length. Allowed characters are `:` `!` `#` `$` `%` `&` `'` `(` `)` `*` `+` `,` `-` `.` `/` `:` `;` `<` `=` `>` `?` `@` `[` `]` `^` `+` `_` `` ` `` `{` `|` `}` `~`, and characters in the ranges `0-9`, `A-Z` and `a-z`. Any other character, including the space character, aren't allowed. May not begin with `.`.",
+							},
+						},
+					},
+					PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+					Computed:            true,
+					Description:         `oauth2PermissionScopes`, // custom MS Graph attribute name
+					MarkdownDescription: "The definition of the delegated permissions exposed by the web API represented by this application registration. These delegated permissions may be requested by a client application, and may be granted by users or administrators during consent. Delegated permissions are sometimes referred to as OAuth 2.0 scopes. / Represents the definition of a delegated permission. Also see [Microsoft docs for permissionScope](https://learn.microsoft.com/en-us/graph/api/resources/permissionscope?view=graph-rest-beta). <br/> The _provider_ default value is `[]`. <br> ",
+				},
+				"requested_access_token_version": schema.Int64Attribute{
+					Optional:            true,
+					MarkdownDescription: "Specifies the access token version expected by this resource. This changes the version and format of the JWT produced independent of the endpoint or client used to request the access token. The endpoint used, v1.0 or v2.0, is chosen by the client and only impacts the version of id_tokens. Resources need to explicitly configure **requestedAccessTokenVersion** to indicate the supported access token format. Possible values for **requestedAccessTokenVersion** are `1`, `2`, or `null`. If the value is `null`, this defaults to `1`, which corresponds to the v1.0 endpoint. If **signInAudience** on the application is configured as `AzureADandPersonalMicrosoftAccount` or `PersonalMicrosoftAccount`, the value for this property must be `2`.",
+				},
+			},
+			PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Specifies settings for an application that implements a web API. / Specifies settings for an application that implements a web API. Also see [Microsoft docs for apiApplication](https://learn.microsoft.com/en-us/graph/api/resources/apiapplication?view=graph-rest-beta). <br/> The _provider_ default value is `{}`. <br> ",
+		},
+		"app_id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the application that is assigned by Microsoft Entra ID. Not nullable. Read-only. Alternate key. Supports `$filter` (`eq`).",
+		},
+		"app_roles": schema.SetNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{ // appRole
+					"allowed_member_types": schema.SetAttribute{
+						ElementType: types.StringType,
+						Required:    true,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(stringvalidator.OneOf("User", "Application")),
+						},
+						MarkdownDescription: "Specifies whether this app role can be assigned to users and groups (by setting to `[\"User\"]`), to other application's (by setting to `[\"Application\"]`, or both (by setting to `[\"User\", \"Application\"]`). App roles supporting assignment to other applications' service principals are also known as application permissions. The `Application` value is only supported for app roles defined on **application** entities. <br/> _Provider_ allowed values are: `User`, `Application`.",
+					},
+					"description": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "The description for the app role. This is displayed when the app role is being assigned and, if the app role functions as an application permission, during  consent experiences.",
+					},
+					"display_name": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Display name for the permission that appears in the app role assignment and consent experiences.",
+					},
+					"id": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Unique role identifier inside the **appRoles** collection. You must specify a new GUID identifier when you create a new app role.",
+					},
+					"is_enabled": schema.BoolAttribute{
+						Optional:            true,
+						PlanModifiers:       []planmodifier.Bool{wpdefaultvaluemodifier.BoolDefaultValue(true)},
+						Computed:            true,
+						MarkdownDescription: "When creating or updating an app role, this must be set to `true` (which is the default). To delete a role, this must first be set to `false`.  At that point, in a subsequent call, this role may be removed. <br/> The _provider_ default value is `true`.",
+					},
+					"value": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Specifies the value to include in the `roles` claim in ID tokens and access tokens authenticating an assigned user or service principal. Must not exceed 120 characters in length. Allowed characters are `:` `!` `#` `$` `%` `&` `'` `(` `)` `*` `+` `,` `-` `.` `/` `:` `;` `<` `=` `>` `?` `@` `[` `]` `^` `+` `_` `` ` `` `{` `|` `}` `~`, and characters in the ranges `0-9`, `A-Z` and `a-z`. Any other character, including the space character, aren't allowed. May not begin with `.`.",
+					},
+				},
+			},
+			PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "The collection of roles defined for the application. With [app role assignments](app_role_assignment.md), these roles can be assigned to users, groups, or service principals associated with other applications. Not nullable. / Represents an application role that can be requested by (and granted to) a client application, or that can be used to assign an application to users or groups in a specified role. Also see [Microsoft docs for appRole](https://learn.microsoft.com/en-us/graph/api/resources/approle?view=graph-rest-beta). <br/> The _provider_ default value is `[]`. <br>  \n_Provider_ Note: Use `microsoft365wp_app_role_assignment` to assign these roles (after a service principal has been created for the application). <br> ",
+		},
+		"created_date_time": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The date and time the application was registered. The DateTimeOffset type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Read-only. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, and `eq` on `null` values) and `$orderby`.",
+		},
+		"description": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Free text field to provide a description of the application object to end users. The maximum allowed size is 1,024 characters. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `startsWith`) and `$search`.",
+		},
+		"display_name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The display name for the application. Maximum length is 256 characters. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values), `$search`, and `$orderby`.",
+		},
+		"group_membership_claims": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Configures the **groups** claim issued in a user or OAuth 2.0 access token that the application expects. To set this attribute, use one of the following string values: `None`, `SecurityGroup` (for security groups and Microsoft Entra roles), `All` (this gets all security groups, distribution groups, and Microsoft Entra directory roles that the signed-in user is a member of).",
+		},
+		"identifier_uris": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Also known as App ID URI, this value is set when an application is used as a resource app. The identifierUris acts as the prefix for the scopes you reference in your API's code, and it must be globally unique. You can use the default value provided, which is in the form `api://<appId>`, or specify a more readable URI like `https://contoso.com/api`. For more information on valid identifierUris patterns and best practices, see [Microsoft Entra application registration security best practices](https://learn.microsoft.com/en-us/azure/active-directory/develop/security-best-practices-for-app-registration#appid-uri-configuration). Not nullable. <br/> Supports `$filter` (`eq`, `ne`, `ge`, `le`, `startsWith`). <br/> The _provider_ default value is `[]`.",
+		},
+		"is_device_only_auth_supported": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Specifies whether this application supports device authentication without a user. The default is `false`.",
+		},
+		"is_fallback_public_client": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Specifies the fallback application type as public client, such as an installed application running on a mobile device. The default value is `false`, which means the fallback application type is confidential client such as a web app. There are certain scenarios where Microsoft Entra ID can't determine the client application type. For example, the [ROPC](https://learn.microsoft.com/en-us/azure/active-directory/develop/v2-oauth-ropc) flow where it's configured without specifying a redirect URI. In those cases, Microsoft Entra ID interprets the application type based on the value of this property.",
+		},
+		"notes": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Notes relevant for the management of the application.",
+		},
+		"owners": schema.SetNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: groupDirectoryObjectAttributes,
+			},
+			PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Directory objects that are owners of the application. Read-only. Nullable. <br/> The _provider_ default value is `[]`.",
+		},
+		"public_client": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{ // publicClientApplication
+				"redirect_uris": applicationRedirectUrisAttribute,
+			},
+			PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Specifies settings for installed clients such as desktop or mobile devices. / Also see [Microsoft docs for publicClientApplication](https://learn.microsoft.com/en-us/graph/api/resources/publicclientapplication?view=graph-rest-beta). <br/> The _provider_ default value is `{}`. <br> ",
+		},
+		"publisher_domain": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The verified publisher domain for the application. Read-only. Supports `$filter` (`eq`, `ne`, `ge`, `le`, `startsWith`).",
+		},
+		"required_resource_access": schema.SetNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{ // requiredResourceAccess
+					"resource_access": schema.SetNestedAttribute{
+						Required: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{ // resourceAccess
+								"id": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "The unique identifier of an app role or delegated permission exposed by the resource application. For delegated permissions, this should match the **id** property of one of the [delegated permissions](https://learn.microsoft.com/en-us/graph/api/resources/permissionscope?view=graph-rest-beta) in the **oauth2PermissionScopes** collection of the resource application's [service principal](service_principal.md). For app roles (application permissions), this should match the **id** property of an [app role](https://learn.microsoft.com/en-us/graph/api/resources/approle?view=graph-rest-beta) in the **appRoles** collection of the resource application's [service principal](service_principal.md).",
+								},
+								"type": schema.StringAttribute{
+									Required:            true,
+									Validators:          []validator.String{stringvalidator.OneOf("Scope", "Role")},
+									MarkdownDescription: "Specifies whether the **id** property references a [delegated permission](https://learn.microsoft.com/en-us/graph/api/resources/permissionscope?view=graph-rest-beta) or an [app role (application permission)](https://learn.microsoft.com/en-us/graph/api/resources/approle?view=graph-rest-beta). The possible values are: `Scope` (for delegated permissions) or `Role` (for app roles). <br/> _Provider_ allowed values are: `Scope`, `Role`.",
+								},
+							},
+						},
+						MarkdownDescription: "The list of OAuth2.0 permission scopes and app roles that the application requires from the specified resource. / Object used to specify an OAuth 2.0 permission scope or an app role that an application requires, through the **resourceAccess** property of the [requiredResourceAccess](https://learn.microsoft.com/en-us/graph/api/resources/requiredresourceaccess?view=graph-rest-beta) resource type. Also see [Microsoft docs for resourceAccess](https://learn.microsoft.com/en-us/graph/api/resources/resourceaccess?view=graph-rest-beta).",
+					},
+					"resource_app_id": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The unique identifier for the resource that the application requires access to. This should be equal to the **appId** declared on the target resource application.",
+					},
+				},
+			},
+			PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Specifies the resources that the application needs to access. This property also specifies the set of delegated permissions and application roles that it needs for each of those resources. This configuration of access to the required resources drives the consent experience. No more than 50 resource services (APIs) can be configured. Beginning mid-October 2021, the total number of required permissions must not exceed 400. Not nullable. <br/> Supports `$filter` (`eq`, `not`, `ge`, `le`). / Specifies the set of OAuth 2.0 permission scopes and app roles under the specified resource that an application requires access to. Also see [Microsoft docs for requiredResourceAccess](https://learn.microsoft.com/en-us/graph/api/resources/requiredresourceaccess?view=graph-rest-beta). <br/> The _provider_ default value is `[]`. <br>  \n_Provider_ Note: Permissions listed here still need to be granted, e.g. using `microsoft365wp_app_role_assignment` for application permissions. <br> ",
+		},
+		"service_management_reference": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "References application or service contact information from a Service or Asset Management database. Nullable.",
+		},
+		"sign_in_audience": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("AzureADMyOrg", "AzureADMultipleOrgs", "AzureADandPersonalMicrosoftAccount", "PersonalMicrosoftAccount"),
+			},
+			PlanModifiers:       []planmodifier.String{wpdefaultvaluemodifier.StringDefaultValue("AzureADMyOrg")},
+			Computed:            true,
+			MarkdownDescription: "Specifies the Microsoft accounts that are supported for the current application. The possible values are: `AzureADMyOrg` (default), `AzureADMultipleOrgs`, `AzureADandPersonalMicrosoftAccount`, and `PersonalMicrosoftAccount`. See more in the [table](#signinaudience-values). The value of this object also limits the number of permissions an app can request. For more information, see [Limits on requested permissions per app](https://learn.microsoft.com/en-us/entra/identity-platform/reference-app-manifest#requiredresourceaccess-attribute). The value for this property has implications on other app object properties. As a result, if you change this property, you may need to change other properties first. <br/> Supports `$filter` (`eq`, `ne`, `not`). <br/> _Provider_ allowed values are: `AzureADMyOrg`, `AzureADMultipleOrgs`, `AzureADandPersonalMicrosoftAccount`, `PersonalMicrosoftAccount`. The _provider_ default value is `\"AzureADMyOrg\"`.",
+		},
+		"spa": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{ // spaApplication
+				"redirect_uris": applicationRedirectUrisAttribute,
+			},
+			PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Specifies settings for a single-page application, including sign out URLs and redirect URIs for authorization codes and access tokens. / Also see [Microsoft docs for spaApplication](https://learn.microsoft.com/en-us/graph/api/resources/spaapplication?view=graph-rest-beta). <br/> The _provider_ default value is `{}`. <br> ",
+		},
+		"tags": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Custom strings that can be used to categorize and identify the application. Not nullable. Strings added here will also appear in the **tags** property of any associated [service principals](service_principal.md). <br/> Supports `$filter` (`eq`, `not`, `ge`, `le`, `startsWith`) and `$search`. <br/> The _provider_ default value is `[]`.",
+		},
+		"web": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{ // webApplication
+				"home_page_url": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Home page or landing page of the application.",
+				},
+				"implicit_grant_settings": schema.SingleNestedAttribute{
+					Optional: true,
+					Attributes: map[string]schema.Attribute{ // implicitGrantSettings
+						"enable_access_token_issuance": schema.BoolAttribute{
+							Optional:            true,
+							PlanModifiers:       []planmodifier.Bool{wpdefaultvaluemodifier.BoolDefaultValue(false)},
+							Computed:            true,
+							MarkdownDescription: "Specifies whether this web application can request an access token using the OAuth 2.0 implicit flow. <br/> The _provider_ default value is `false`.",
+						},
+						"enable_id_token_issuance": schema.BoolAttribute{
+							Optional:            true,
+							PlanModifiers:       []planmodifier.Bool{wpdefaultvaluemodifier.BoolDefaultValue(false)},
+							Computed:            true,
+							MarkdownDescription: "Specifies whether this web application can request an ID token using the OAuth 2.0 implicit flow. <br/> The _provider_ default value is `false`.",
+						},
+					},
+					PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty()},
+					Computed:            true,
+					MarkdownDescription: "Specifies whether this web application can request tokens using the OAuth 2.0 implicit flow. / Also see [Microsoft docs for implicitGrantSettings](https://learn.microsoft.com/en-us/graph/api/resources/implicitgrantsettings?view=graph-rest-beta). <br/> The _provider_ default value is `{}`. <br> ",
+				},
+				"logout_url": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Specifies the URL that is used by Microsoft's authorization service to log out a user using [front-channel](https://openid.net/specs/openid-connect-frontchannel-1_0.html), [back-channel](https://openid.net/specs/openid-connect-backchannel-1_0.html) or SAML logout protocols.",
+				},
+				"redirect_uris": applicationRedirectUrisAttribute,
+			},
+			PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Specifies settings for a web application. / Also see [Microsoft docs for webApplication](https://learn.microsoft.com/en-us/graph/api/resources/webapplication?view=graph-rest-beta). <br/> The _provider_ default value is `{}`. <br> ",
+		},
+	},
+	MarkdownDescription: "Represents an application. Any application that outsources authentication to Microsoft Entra ID must be registered in the Microsoft identity platform. Application registration involves telling Microsoft Entra ID about your application, including the URL where it's located, the URL to send replies after authentication, the URI to identify your application, and more. <br/> Also see [Microsoft docs for application](https://learn.microsoft.com/en-us/graph/api/resources/application?view=graph-rest-beta).\n\n_Provider_ Note: Password and certificate credentials are not part of this resource but can be managed using `microsoft365wp_application_password_credential` and `microsoft365wp_application_key_credential`. ||| MS Graph: Entra ID",
+}
+
+var applicationDataSourceSchema = schema.Schema{
 	Attributes: map[string]schema.Attribute{ // application
 		"id": schema.StringAttribute{
 			MarkdownDescription: "Unique identifier for the application object. This property is referred to as **Object ID** in the Microsoft Entra admin center. Key. Not nullable. Read-only. Supports `$filter` (`eq`, `ne`, `not`, `in`).",
@@ -44,5 +384,5 @@ var applicationResourceSchema = schema.Schema{
 			MarkdownDescription: "The display name for the application. Maximum length is 256 characters. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values), `$search`, and `$orderby`.",
 		},
 	},
-	MarkdownDescription: "Represents an application. Any application that outsources authentication to Microsoft Entra ID must be registered in the Microsoft identity platform. Application registration involves telling Microsoft Entra ID about your application, including the URL where it's located, the URL to send replies after authentication, the URI to identify your application, and more. <br/> Also see [Microsoft docs for application](https://learn.microsoft.com/en-us/graph/api/resources/application?view=graph-rest-beta).\n\n_Provider_ Note: This data source is only provided as a companion to `azuread_application` to allow for OData filtering. It is not planned to add more attributes or even provide a resource for this entity. ||| MS Graph: Entra ID",
+	MarkdownDescription: "Represents an application. Any application that outsources authentication to Microsoft Entra ID must be registered in the Microsoft identity platform. Application registration involves telling Microsoft Entra ID about your application, including the URL where it's located, the URL to send replies after authentication, the URI to identify your application, and more. <br/> Also see [Microsoft docs for application](https://learn.microsoft.com/en-us/graph/api/resources/application?view=graph-rest-beta).\n\n_Provider_ Note: This data source is only provided as a companion to `azuread_application` to allow for OData filtering. It is not planned to add more attributes to it (see the `microsoft365wp_application` resource instead). ||| MS Graph: Entra ID",
 }
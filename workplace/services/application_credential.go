@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"terraform-provider-microsoft365wp/workplace/generic"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+//
+// Password and key credentials are no entities of their own but show up in the passwordCredentials and keyCredentials
+// attributes of their application, so the resources application_password_credential and application_key_credential
+// share these functions.
+//
+// Some values (i.e. secretText of password credentials) are only returned by MS Graph once when the credential gets
+// created. To not lose them on the next read they are taken from the prior state if MS Graph does not return them.
+//
+
+// applicationCredentialGraphToTerraformMiddleware returns a middleware that picks the credential with the expected
+// keyId from the given collection attribute of the application. Root values of preservedAttributes (having been added
+// by applicationCredentialExtraRequestCustom) are used if the credential itself does not contain them.
+func applicationCredentialGraphToTerraformMiddleware(collectionAttribute string, preservedAttributes ...string) generic.GraphToTerraformMiddlewareFunc {
+	return func(ctx context.Context, diags *diag.Diagnostics, params *generic.GraphToTerraformMiddlewareParams) generic.GraphToTerraformMiddlewareReturns {
+
+		credentials, ok1 := params.RawVal[collectionAttribute].([]any)
+		if !ok1 {
+			return fmt.Errorf("property '%s' not found or of wrong type", collectionAttribute)
+		}
+
+		preserved := map[string]any{}
+		for _, a := range preservedAttributes {
+			if v, ok := params.RawVal[a]; ok && v != nil {
+				preserved[a] = v
+			}
+		}
+
+		clear(params.RawVal)
+		for _, credentialAny := range credentials {
+			if credentialMap, ok2 := credentialAny.(map[string]any); ok2 && credentialMap["keyId"] == params.ExpectedId {
+				maps.Copy(params.RawVal, credentialMap)
+				for k, v := range preserved {
+					if params.RawVal[k] == nil {
+						params.RawVal[k] = v
+					}
+				}
+				return nil
+			}
+		}
+		// empty map gets translated to "not found" upstream
+		tflog.Info(ctx, fmt.Sprintf("No element found with keyId '%s' in property %s", params.ExpectedId, collectionAttribute))
+
+		return nil
+	}
+}
+
+// applicationCredentialExtraRequestCustom returns a custom extra request that copies the prior state value of the
+// given Terraform attribute to the raw value (using the MS Graph attribute name), see
+// applicationCredentialGraphToTerraformMiddleware.
+func applicationCredentialExtraRequestCustom(terraformAttribute string, graphAttribute string) generic.ReadExtraRequestCustom {
+	return func(ctx context.Context, diags *diag.Diagnostics, params generic.ReadExtraRequestCustomParams) {
+		if params.ReqState == nil || params.ReqState.Raw.IsNull() {
+			return
+		}
+
+		var value types.String
+		diags.Append(params.ReqState.GetAttribute(ctx, path.Root(terraformAttribute), &value)...)
+		if diags.HasError() || value.IsNull() || value.IsUnknown() {
+			return
+		}
+		params.RawVal[graphAttribute] = value.ValueString()
+	}
+}
+
+// applicationCredentialReadCollection reads the current credentials of the given collection attribute of the
+// application.
+func applicationCredentialReadCollection(ctx context.Context, diags *diag.Diagnostics, aps *generic.AccessParams,
+	baseUri string, idAttributer generic.GetAttributer, collectionAttribute string) (string, []any) {
+
+	uri := aps.GetBaseUri(ctx, diags, baseUri, idAttributer)
+	if diags.HasError() {
+		return "", nil
+	}
+
+	rawVal := aps.ReadRaw2(ctx, diags, uri, "", "", []string{collectionAttribute}, false)
+	if diags.HasError() {
+		return "", nil
+	}
+	credentials, ok := rawVal[collectionAttribute].([]any)
+	if !ok {
+		diags.AddError("Unable to read credentials", fmt.Sprintf("property '%s' not found or of wrong type", collectionAttribute))
+		return "", nil
+	}
+
+	return uri.Entity, credentials
+}
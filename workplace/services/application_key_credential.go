@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	ApplicationKeyCredentialResource = generic.GenericResource{
+		TypeNameSuffix: "application_key_credential",
+		SpecificSchema: applicationKeyCredentialResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri: "/applications",
+			ParentEntities: generic.ParentEntities{
+				{
+					ParentIdField: path.Root("application_id"),
+				},
+			},
+			UriNoId: true,
+			EntityId: generic.EntityIdOptions{
+				AttrNameGraph: "keyId",
+			},
+			ReadOptions: generic.ReadOptions{
+				ODataSelect: []string{"keyCredentials"},
+				ExtraRequestsCustom: []generic.ReadExtraRequestCustom{
+					applicationCredentialExtraRequestCustom("key_base64", "key"),
+				},
+			},
+			WriteOptions: generic.WriteOptions{
+				SerializeWrites:   true,
+				SerialWritesDelay: time.Second * 3,
+			},
+			GraphToTerraformMiddleware: applicationCredentialGraphToTerraformMiddleware("keyCredentials", "key"),
+			CreateReplaceFunc:          applicationKeyCredentialCreateReplaceFunc,
+			DeleteReplaceFunc:          applicationKeyCredentialDeleteReplaceFunc,
+		},
+	}
+)
+
+func applicationKeyCredentialCreateReplaceFunc(ctx context.Context, diags *diag.Diagnostics, params *generic.CreateReplaceFuncParams) {
+
+	proof := params.RawVal["proof"]
+	password := params.RawVal["password"]
+	delete(params.RawVal, "proof")
+	delete(params.RawVal, "password")
+
+	if proof == nil {
+		// no proof of possession of an existing key, so we have to add the key by patching the application
+		if password != nil {
+			diags.AddAttributeError(path.Root("password_wo"), "Unable to create with MS Graph",
+				"`password_wo` can only be used together with `proof_wo`")
+			return
+		}
+		applicationKeyCredentialCreateByPatch(ctx, diags, params)
+		return
+	}
+
+	uri := params.R.AccessParams.GetBaseUri(ctx, diags, params.BaseUri, params.IdAttributer)
+	if diags.HasError() {
+		return
+	}
+	uri.Entity += "/addKey"
+
+	var passwordCredential any
+	if password != nil {
+		passwordCredential = map[string]any{"secretText": password}
+	}
+	postRawVal := map[string]any{
+		"keyCredential":      params.RawVal,
+		"passwordCredential": passwordCredential,
+		"proof":              proof,
+	}
+	rawResult := generic.CreateRaw(ctx, diags, params.Client, uri, postRawVal, nil, false, false)
+	if diags.HasError() {
+		return
+	}
+
+	params.Id, _ = rawResult["keyId"].(string)
+	if params.Id == "" {
+		diags.AddError("Unable to create with MS Graph", "MS Graph did not return a keyId for the new credential")
+		return
+	}
+	params.RawResult = map[string]any{
+		"keyCredentials": []any{rawResult},
+	}
+}
+
+func applicationKeyCredentialCreateByPatch(ctx context.Context, diags *diag.Diagnostics, params *generic.CreateReplaceFuncParams) {
+
+	uriEntity, credentials := applicationCredentialReadCollection(ctx, diags, &params.R.AccessParams, params.BaseUri,
+		params.IdAttributer, "keyCredentials")
+	if diags.HasError() {
+		return
+	}
+
+	keyId, err := applicationKeyCredentialNewKeyId()
+	if err != nil {
+		diags.AddError("Unable to generate keyId", err.Error())
+		return
+	}
+	params.RawVal["keyId"] = keyId
+
+	credentials = append(credentials, params.RawVal)
+	params.R.AccessParams.UpdateRaw(ctx, diags, uriEntity, "", nil, map[string]any{"keyCredentials": credentials})
+	if diags.HasError() {
+		return
+	}
+
+	params.Id = keyId
+}
+
+func applicationKeyCredentialDeleteReplaceFunc(ctx context.Context, diags *diag.Diagnostics, params *generic.DeleteReplaceFuncParams) {
+
+	// removeKey would require a proof of possession which is not available anymore when destroying, so we patch the
+	// application instead
+	id := params.R.AccessParams.GetId(ctx, diags, params.Id, params.IdAttributer)
+	if diags.HasError() {
+		return
+	}
+
+	uriEntity, credentials := applicationCredentialReadCollection(ctx, diags, &params.R.AccessParams, params.BaseUri,
+		params.IdAttributer, "keyCredentials")
+	if diags.HasError() {
+		return
+	}
+
+	credentials = slices.DeleteFunc(credentials, func(c any) bool {
+		credentialMap, ok := c.(map[string]any)
+		return ok && credentialMap["keyId"] == id
+	})
+	params.R.AccessParams.UpdateRaw(ctx, diags, uriEntity, "", nil, map[string]any{"keyCredentials": credentials})
+}
+
+// applicationKeyCredentialNewKeyId returns a random (version 4) UUID, as MS Graph requires the client to provide the
+// keyId when patching keyCredentials.
+func applicationKeyCredentialNewKeyId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+var applicationKeyCredentialResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // keyCredential
+		"application_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The unique identifier (**id**, not **appId**) of the [application](application.md) to add the key credential to.",
+		},
+		"custom_key_identifier": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "A 40-character binary type that can be used to identify the credential. Read-only. Will be set to the thumbprint of the certificate by MS Graph.",
+		},
+		"display_name": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The friendly name for the key, with a maximum length of 90 characters. Longer values are accepted but shortened.",
+		},
+		"end_date_time": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The date and time at which the credential expires. The Timestamp type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Read-only. Will be taken from the certificate by MS Graph.",
+		},
+		"key_base64": schema.StringAttribute{
+			Required:            true,
+			Description:         `key`, // custom MS Graph attribute name
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The certificate's raw data in byte array converted to Base64 string (i.e. the Base64 encoded DER certificate without PEM header and footer).",
+		},
+		"key_id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the key.",
+		},
+		"password_wo": schema.StringAttribute{
+			Optional:            true,
+			WriteOnly:           true,
+			Sensitive:           true,
+			Description:         `password`, // custom MS Graph attribute name
+			MarkdownDescription: "The password of the key when `type` is `X509CertAndPassword`. Can only be used together with `proof_wo`.",
+		},
+		"proof_wo": schema.StringAttribute{
+			Optional:            true,
+			WriteOnly:           true,
+			Sensitive:           true,
+			Description:         `proof`, // custom MS Graph attribute name
+			MarkdownDescription: "A self-signed JWT token used as a proof of possession of one of the existing valid certificates of the application (see [Microsoft docs](https://learn.microsoft.com/en-us/graph/application-rollkey-prooftoken)). If set, the key will be added using the `addKey` action, otherwise the **keyCredentials** property of the application will be patched (which is required for the first certificate of an application, as there is no existing certificate to create a proof with).",
+		},
+		"start_date_time": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The date and time at which the credential becomes valid. The Timestamp type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Read-only. Will be taken from the certificate by MS Graph.",
+		},
+		"type": schema.StringAttribute{
+			Required:      true,
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			Validators: []validator.String{
+				stringvalidator.OneOf("AsymmetricX509Cert", "X509CertAndPassword"),
+			},
+			MarkdownDescription: "The type of key credential; for example, `Symmetric`, `AsymmetricX509Cert`, or `X509CertAndPassword`. <br/> _Provider_ allowed values are: `AsymmetricX509Cert`, `X509CertAndPassword`.",
+		},
+		"usage": schema.StringAttribute{
+			Required:      true,
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			Validators: []validator.String{
+				stringvalidator.OneOf("Verify", "Sign"),
+			},
+			MarkdownDescription: "A string that describes the purpose for which the key can be used; for example, `Verify`. <br/> _Provider_ allowed values are: `Verify`, `Sign`.",
+		},
+	},
+	MarkdownDescription: "Contains a key credential (certificate) associated with an [application](application.md). The **keyCredentials** property of the application entity is a collection of **keyCredential** objects. <br/> Also see [Microsoft docs for keyCredential](https://learn.microsoft.com/en-us/graph/api/resources/keycredential?view=graph-rest-beta).\n\n_Provider_ Note: Key credentials cannot be updated, any change will replace them. As removing a key using the `removeKey` action would require a proof of possession, keys are always removed by patching the **keyCredentials** property of the application. To import this resource, an ID consisting of `application_id` and `key_id` being joined by a forward slash (`/`) must be used. ||| MS Graph: Entra ID",
+}
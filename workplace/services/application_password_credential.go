@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	ApplicationPasswordCredentialResource = generic.GenericResource{
+		TypeNameSuffix: "application_password_credential",
+		SpecificSchema: applicationPasswordCredentialResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri: "/applications",
+			ParentEntities: generic.ParentEntities{
+				{
+					ParentIdField: path.Root("application_id"),
+				},
+			},
+			UriNoId: true,
+			EntityId: generic.EntityIdOptions{
+				AttrNameGraph: "keyId",
+			},
+			ReadOptions: generic.ReadOptions{
+				ODataSelect: []string{"passwordCredentials"},
+				ExtraRequestsCustom: []generic.ReadExtraRequestCustom{
+					applicationCredentialExtraRequestCustom("secret_text", "secretText"),
+				},
+			},
+			WriteOptions: generic.WriteOptions{
+				SerializeWrites:   true,
+				SerialWritesDelay: time.Second * 3,
+			},
+			GraphToTerraformMiddleware: applicationCredentialGraphToTerraformMiddleware("passwordCredentials", "secretText"),
+			CreateReplaceFunc:          applicationPasswordCredentialCreateReplaceFunc,
+			DeleteReplaceFunc:          applicationPasswordCredentialDeleteReplaceFunc,
+		},
+	}
+)
+
+func applicationPasswordCredentialCreateReplaceFunc(ctx context.Context, diags *diag.Diagnostics, params *generic.CreateReplaceFuncParams) {
+
+	uri := params.R.AccessParams.GetBaseUri(ctx, diags, params.BaseUri, params.IdAttributer)
+	if diags.HasError() {
+		return
+	}
+	uri.Entity += "/addPassword"
+
+	postRawVal := map[string]any{
+		"passwordCredential": params.RawVal,
+	}
+	rawResult := generic.CreateRaw(ctx, diags, params.Client, uri, postRawVal, nil, false, false)
+	if diags.HasError() {
+		return
+	}
+
+	params.Id, _ = rawResult["keyId"].(string)
+	if params.Id == "" {
+		diags.AddError("Unable to create with MS Graph", "MS Graph did not return a keyId for the new credential")
+		return
+	}
+	params.RawResult = map[string]any{
+		"passwordCredentials": []any{rawResult},
+	}
+}
+
+func applicationPasswordCredentialDeleteReplaceFunc(ctx context.Context, diags *diag.Diagnostics, params *generic.DeleteReplaceFuncParams) {
+
+	id := params.R.AccessParams.GetId(ctx, diags, params.Id, params.IdAttributer)
+	if diags.HasError() {
+		return
+	}
+
+	uri := params.R.AccessParams.GetBaseUri(ctx, diags, params.BaseUri, params.IdAttributer)
+	if diags.HasError() {
+		return
+	}
+	uri.Entity += "/removePassword"
+
+	postRawVal := map[string]any{
+		"keyId": id,
+	}
+	generic.CreateRaw(ctx, diags, params.Client, uri, postRawVal, nil, true, false)
+}
+
+var applicationPasswordCredentialResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // passwordCredential
+		"application_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The unique identifier (**id**, not **appId**) of the [application](application.md) to add the password credential to.",
+		},
+		"display_name": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Friendly name for the password. Optional.",
+		},
+		"end_date_time": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace(), wpplanmodifier.StringUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "The date and time at which the password expires represented using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Optional. If not set, MS Graph will use a validity of two years.",
+		},
+		"hint": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "Contains the first three characters of the password. Read-only.",
+		},
+		"key_id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the password.",
+		},
+		"rotate_when_changed": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			Description:         generic.TerraformOnlyAttribute,
+			PlanModifiers:       []planmodifier.Map{mapplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Arbitrary map of values that, when changed, will trigger the creation of a new password credential (and the removal of the current one), e.g. to rotate the password periodically.",
+		},
+		"secret_text": schema.StringAttribute{
+			Computed:            true,
+			Sensitive:           true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The strong password generated by Microsoft Entra ID that is 16-64 characters in length. The generated password value is only returned by MS Graph during the initial creation and is therefore kept in Terraform state afterwards (it will be empty after import).",
+		},
+		"start_date_time": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace(), wpplanmodifier.StringUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "The date and time at which the password becomes valid. The Timestamp type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Optional. If not set, the current date and time will be used.",
+		},
+	},
+	MarkdownDescription: "Represents a password credential (client secret) associated with an [application](application.md). The **passwordCredentials** property of the application entity is a collection of **passwordCredential** objects. The password gets generated by Microsoft Entra ID using the `addPassword` action and cannot be specified. <br/> Also see [Microsoft docs for passwordCredential](https://learn.microsoft.com/en-us/graph/api/resources/passwordcredential?view=graph-rest-beta).\n\n_Provider_ Note: Password credentials cannot be updated, any change will replace them. To import this resource, an ID consisting of `application_id` and `key_id` being joined by a forward slash (`/`) must be used. ||| MS Graph: Entra ID",
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestApplicationResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&ApplicationResource)
+
+	config := func(displayName string) string {
+		return generictest.Config(&ApplicationResource, fmt.Sprintf(`
+			display_name     = %q
+			sign_in_audience = "AzureADMyOrg"
+			web = {
+				redirect_uris = ["https://contoso.com/signin-oidc"]
+			}
+		`, displayName))
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &ApplicationResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/applications")
+			es.Defaults = map[string]any{"appId": "00000000-0000-0000-0000-0000000000a1", "createdDateTime": "2024-01-01T00:00:00Z",
+				"publisherDomain": "contoso.com"}
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("My App"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "id"),
+					resource.TestCheckResourceAttr(name, "app_id", "00000000-0000-0000-0000-0000000000a1"),
+					resource.TestCheckResourceAttr(name, "web.redirect_uris.#", "1"),
+					resource.TestCheckResourceAttr(name, "owners.#", "0"),
+				),
+			},
+			{
+				Config: config("My App updated"),
+				Check:  resource.TestCheckResourceAttr(name, "display_name", "My App updated"),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if ids := es.Ids(); len(ids) != 0 {
+				return fmt.Errorf("entities still exist: %v", ids)
+			}
+			return nil
+		},
+	})
+}
+
+func TestApplicationPasswordCredentialResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&ApplicationPasswordCredentialResource)
+	const applicationId = "app1"
+	var firstKeyId string
+
+	keyIds := func() []string {
+		result := []string{}
+		for _, c := range es.Get(applicationId)["passwordCredentials"].([]any) {
+			result = append(result, c.(map[string]any)["keyId"].(string))
+		}
+		return result
+	}
+
+	config := func(rotation string) string {
+		return generictest.Config(&ApplicationPasswordCredentialResource, fmt.Sprintf(`
+			application_id      = %q
+			display_name        = "terraform"
+			rotate_when_changed = { rotation = %q }
+		`, applicationId, rotation))
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &ApplicationPasswordCredentialResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/applications")
+			es.Actions = map[string]graphmock.ActionFunc{
+				"addPassword": func(e *graphmock.EntitySet, id string, _ *http.Request, body map[string]any) (int, any) {
+					application := e.Get(id)
+					credential, _ := body["passwordCredential"].(map[string]any)
+					credential["keyId"] = graphmock.NewUuid()
+					credential["hint"] = "gen"
+					credential["startDateTime"] = "2024-01-01T00:00:00Z"
+					credential["endDateTime"] = "2026-01-01T00:00:00Z"
+					// MS Graph only returns the secret once
+					stored := map[string]any{}
+					for k, v := range credential {
+						stored[k] = v
+					}
+					stored["secretText"] = nil
+					application["passwordCredentials"] = append(application["passwordCredentials"].([]any), stored)
+					e.Put(application)
+					credential["secretText"] = "gen3r4ted-s3cr3t"
+					return http.StatusOK, credential
+				},
+				"removePassword": func(e *graphmock.EntitySet, id string, _ *http.Request, body map[string]any) (int, any) {
+					application := e.Get(id)
+					application["passwordCredentials"] = slices.DeleteFunc(application["passwordCredentials"].([]any), func(c any) bool {
+						return c.(map[string]any)["keyId"] == body["keyId"]
+					})
+					e.Put(application)
+					return http.StatusNoContent, nil
+				},
+			}
+			es.Put(map[string]any{"id": applicationId, "displayName": "My App", "passwordCredentials": []any{}})
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "key_id"),
+					resource.TestCheckResourceAttr(name, "hint", "gen"),
+					resource.TestCheckResourceAttr(name, "end_date_time", "2026-01-01T00:00:00Z"),
+					// must have survived the refresh after apply
+					resource.TestCheckResourceAttr(name, "secret_text", "gen3r4ted-s3cr3t"),
+					func(s *terraform.State) error {
+						firstKeyId = generictest.Attributes(s)["key_id"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("2"),
+				Check: func(s *terraform.State) error {
+					if ids := keyIds(); len(ids) != 1 || ids[0] == firstKeyId || ids[0] != generictest.Attributes(s)["key_id"] {
+						return fmt.Errorf("expected password to be rotated, got %v (first key id: %s)", ids, firstKeyId)
+					}
+					return nil
+				},
+			},
+			{
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotate_when_changed", "secret_text"},
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if ids := keyIds(); len(ids) != 0 {
+				return fmt.Errorf("password credentials still exist: %v", ids)
+			}
+			return nil
+		},
+	})
+}
+
+func TestApplicationKeyCredentialResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&ApplicationKeyCredentialResource)
+	const applicationId = "app1"
+	existingCredential := map[string]any{"keyId": "existing", "type": "AsymmetricX509Cert", "usage": "Verify", "key": "ZXhpc3Rpbmc="}
+
+	keyIds := func() []string {
+		result := []string{}
+		for _, c := range es.Get(applicationId)["keyCredentials"].([]any) {
+			result = append(result, c.(map[string]any)["keyId"].(string))
+		}
+		return result
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &ApplicationKeyCredentialResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/applications")
+			es.Put(map[string]any{"id": applicationId, "displayName": "My App", "keyCredentials": []any{existingCredential}})
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&ApplicationKeyCredentialResource, fmt.Sprintf(`
+					application_id = %q
+					display_name   = "CN=terraform"
+					key_base64     = "Y2VydGlmaWNhdGU="
+					type           = "AsymmetricX509Cert"
+					usage          = "Verify"
+				`, applicationId)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "key_id"),
+					resource.TestCheckResourceAttr(name, "key_base64", "Y2VydGlmaWNhdGU="),
+					func(s *terraform.State) error {
+						if ids := keyIds(); len(ids) != 2 || ids[0] != "existing" || ids[1] != generictest.Attributes(s)["key_id"] {
+							return fmt.Errorf("expected key to be added to existing keys, got %v", ids)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: generictest.Config(&ApplicationKeyCredentialResource, fmt.Sprintf(`
+					application_id = %q
+					key_base64     = "Y2VydGlmaWNhdGU="
+					type           = "AsymmetricX509Cert"
+					usage          = "Verify"
+					password_wo    = "s3cr3t!"
+				`, applicationId)),
+				ExpectError: regexp.MustCompile("can only be used together with `proof_wo`"),
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if ids := fmt.Sprint(keyIds()); ids != "[existing]" {
+				return fmt.Errorf("expected only existing key credential to be left, got %s", ids)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAppRoleAssignmentResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&AppRoleAssignmentResource)
+	const resourceId = "sp-graph"
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &AppRoleAssignmentResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/servicePrincipals")
+			es.NavigationDefaults = map[string]map[string]any{
+				"appRoleAssignedTo": {"principalType": "ServicePrincipal", "principalDisplayName": "My App",
+					"resourceDisplayName": "Microsoft Graph", "createdDateTime": "2024-01-01T00:00:00Z"},
+			}
+			es.Put(map[string]any{"id": resourceId, "displayName": "Microsoft Graph"})
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&AppRoleAssignmentResource, fmt.Sprintf(`
+					resource_id  = %q
+					principal_id = "sp-app"
+					app_role_id  = "role1"
+				`, resourceId)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "id"),
+					resource.TestCheckResourceAttr(name, "principal_type", "ServicePrincipal"),
+					resource.TestCheckResourceAttr(name, "resource_display_name", "Microsoft Graph"),
+					func(s *terraform.State) error {
+						for _, r := range generictest.Graph().Requests() {
+							if r.Method != http.MethodPost {
+								continue
+							}
+							var body map[string]any
+							_ = json.Unmarshal(r.Body, &body)
+							if body["resourceId"] != resourceId {
+								return fmt.Errorf("expected resourceId to be sent to MS Graph, got %v", body)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if assignments, _ := es.GetNavigation(resourceId, "appRoleAssignedTo").([]any); len(assignments) != 0 {
+				return fmt.Errorf("app role assignments still exist: %v", assignments)
+			}
+			return nil
+		},
+	})
+}
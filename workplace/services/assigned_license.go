@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+//
+// Licenses get assigned to groups and users using the same assignLicense action and show up in the same assignedLicenses
+// attribute of their parent, so the resources group_assigned_license and user_assigned_license share these functions.
+//
+
+func assignedLicenseGraphToTerraformMiddleware(ctx context.Context, diags *diag.Diagnostics, params *generic.GraphToTerraformMiddlewareParams) generic.GraphToTerraformMiddlewareReturns {
+
+	assignedLicenses, ok1 := params.RawVal["assignedLicenses"].([]any)
+	if !ok1 {
+		return errors.New("property 'assignedLicenses' not found or of wrong type")
+	}
+
+	clear(params.RawVal)
+	if !params.IsTargetSetOnRawVal {
+		// resource or singular data source
+		for _, assignedLicenseAny := range assignedLicenses {
+			if assignedLicenseMap, ok2 := assignedLicenseAny.(map[string]any); ok2 && assignedLicenseMap["skuId"] == params.ExpectedId {
+				maps.Copy(params.RawVal, assignedLicenseMap)
+			}
+		}
+		// empty map gets translated to "not found" upstream
+		tflog.Info(ctx, fmt.Sprintf("No element found with skuId '%s' in property assignedLicenses", params.ExpectedId))
+	} else {
+		// plural data source
+		params.RawVal["value"] = assignedLicenses
+	}
+
+	return nil
+}
+
+func assignedLicenseCreateReplaceFunc(ctx context.Context, diags *diag.Diagnostics, params *generic.CreateReplaceFuncParams) {
+
+	assignedLicensePostAssignLicense(ctx, diags, &params.R.AccessParams, params.BaseUri, params.IdAttributer, params.Client, []any{params.RawVal}, nil)
+	if diags.HasError() {
+		return
+	}
+
+	params.Id, _ = params.RawVal["skuId"].(string)
+}
+
+func assignedLicenseDeleteReplaceFunc(ctx context.Context, diags *diag.Diagnostics, params *generic.DeleteReplaceFuncParams) {
+
+	id := params.R.AccessParams.GetId(ctx, diags, params.Id, params.IdAttributer)
+	if diags.HasError() {
+		return
+	}
+
+	assignedLicensePostAssignLicense(ctx, diags, &params.R.AccessParams, params.BaseUri, params.IdAttributer, params.Client, nil, []string{id})
+}
+
+func assignedLicensePostAssignLicense(ctx context.Context, diags *diag.Diagnostics, aps *generic.AccessParams,
+	baseUri string, idAttributer generic.GetAttributer, client *msgraph.Client,
+	addLicenses []any, removeLicenses []string) {
+
+	uri := aps.GetBaseUri(ctx, diags, baseUri, idAttributer)
+	if diags.HasError() {
+		return
+	}
+	uri.Entity += "/assignLicense"
+
+	postRawVal := map[string]any{
+		"addLicenses":    addLicenses,
+		"removeLicenses": removeLicenses,
+	}
+	generic.CreateRaw(ctx, diags, client, uri, postRawVal, []int{http.StatusAccepted}, true, false)
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -13,7 +14,8 @@ var (
 		TypeNameSuffix: "attribute_set",
 		SpecificSchema: attributeSetResourceSchema,
 		AccessParams: generic.AccessParams{
-			BaseUri: "/directory/attributeSets",
+			BaseUri:     "/directory/attributeSets",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
 			ReadOptions: generic.ReadOptions{
 				DataSource: generic.DataSourceOptions{
 					NoFilterSupport: true,
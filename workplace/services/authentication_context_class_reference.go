@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
 
@@ -18,7 +19,8 @@ var (
 		TypeNameSuffix: "authentication_context_class_reference",
 		SpecificSchema: authenticationContextClassReferenceResourceSchema,
 		AccessParams: generic.AccessParams{
-			BaseUri: "/identity/conditionalAccess/authenticationContextClassReferences",
+			BaseUri:     "/identity/conditionalAccess/authenticationContextClassReferences",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
 			ReadOptions: generic.ReadOptions{
 				ValidStatusCodesExtra: []int{201},
 				DataSource: generic.DataSourceOptions{
@@ -83,6 +85,10 @@ func (*authenticationContextClassReferenceClearIsAvailableBeforeDeleteWsa) Check
 	return wsaOperation == generic.OperationDelete
 }
 
+func (*authenticationContextClassReferenceClearIsAvailableBeforeDeleteWsa) CheckWritePre(wsaOperation generic.OperationType) bool {
+	return wsaOperation == generic.OperationDelete
+}
+
 func (*authenticationContextClassReferenceClearIsAvailableBeforeDeleteWsa) ExecutePre(ctx context.Context, diags *diag.Diagnostics, wsaReq *generic.WriteSubActionRequest) {
 	// will be called on Delete only
 	var isAvailableTypes types.Bool // might be null
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAuthenticationContextClassReferenceResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&AuthenticationContextClassReferenceResource)
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &AuthenticationContextClassReferenceResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/identity/conditionalAccess/authenticationContextClassReferences")
+			es.ETags = true
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&AuthenticationContextClassReferenceResource, `
+					id           = "c1"
+					display_name = "Test"
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "c1"),
+					resource.TestCheckResourceAttr(name, "is_available", "false"),
+				),
+			},
+			{
+				Config: generictest.Config(&AuthenticationContextClassReferenceResource, `
+					id           = "c1"
+					display_name = "Test"
+					is_available = true
+				`),
+				Check: resource.TestCheckResourceAttr(name, "is_available", "true"),
+			},
+		},
+		// clearing isAvailable before deletion changes the ETag, so deletion must not use the one read before
+		CheckDestroy: func(*graphmock.Server) error {
+			if ids := es.Ids(); len(ids) != 0 {
+				return fmt.Errorf("entities still exist: %v", ids)
+			}
+			return nil
+		},
+	})
+}
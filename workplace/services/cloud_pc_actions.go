@@ -0,0 +1,45 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/generic"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	CloudPcReprovisionAction = generic.GenericAction{
+		TypeNameSuffix: "cloud_pc_reprovision",
+		SpecificSchema: cloudPcReprovisionActionSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:   "/deviceManagement/virtualEndpoint/cloudPCs",
+			UriSuffix: "reprovision",
+		},
+	}
+)
+
+var cloudPcReprovisionActionSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // cloudPC.reprovision parameters
+		"id": schema.StringAttribute{
+			Required:            true,
+			Description:         generic.TerraformOnlyAttribute,
+			MarkdownDescription: "The id of the Cloud PC.",
+		},
+		"os_version": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("windows10", "windows11"),
+			},
+			MarkdownDescription: "The version of the operating system to reprovision the Cloud PC with (using the current version if not set). / _Provider_ allowed values are: `windows10`, `windows11`.",
+		},
+		"user_account_type": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("standardUser", "administrator"),
+			},
+			MarkdownDescription: "The account type of the user on the reprovisioned Cloud PC (using the current type if not set). / _Provider_ allowed values are: `standardUser`, `administrator`.",
+		},
+	},
+	MarkdownDescription: "Reprovisions the Cloud PC, i.e. creates it again with the current provisioning policy (MS Graph action `reprovision`).",
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
@@ -23,7 +24,8 @@ var (
 		TypeNameSuffix: "custom_security_attribute_definition",
 		SpecificSchema: customSecurityAttributeDefinitionResourceSchema,
 		AccessParams: generic.AccessParams{
-			BaseUri: "/directory/customSecurityAttributeDefinitions",
+			BaseUri:     "/directory/customSecurityAttributeDefinitions",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
 			EntityId: generic.EntityIdOptions{
 				FallbackIdGetterFunc: customSecurityAttributeDefinitionFallbackIdGetterFunc,
 			},
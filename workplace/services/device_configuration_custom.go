@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"maps"
+	"slices"
 	"terraform-provider-microsoft365wp/workplace/generic"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
@@ -16,7 +18,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 var (
@@ -59,8 +63,15 @@ var (
 
 func deviceConfigurationCustomTerraformToGraphMiddleware(ctx context.Context, diags *diag.Diagnostics, params *generic.TerraformToGraphMiddlewareParams) generic.TerraformToGraphMiddlewareReturns {
 
-	omaSettings := params.RawVal["omaSettings"].([]any)
+	// write-only values do not exist in MS Graph by themselves but are part of the OMA settings
+	writeOnlyValues, _ := params.RawVal["omaSettingValuesWo"].(map[string]any)
+	delete(params.RawVal, "omaSettingValuesWo")
+
+	omaSettings, _ := params.RawVal["omaSettings"].([]any)
 	if omaSettings == nil {
+		if len(writeOnlyValues) > 0 {
+			return fmt.Errorf("oma_setting_values_wo has been specified but there are no OMA settings")
+		}
 		return nil
 	}
 
@@ -73,6 +84,17 @@ func deviceConfigurationCustomTerraformToGraphMiddleware(ctx context.Context, di
 		if !ok {
 			return fmt.Errorf("omaSettings[%d]: @odata.type not found or not of type string", i)
 		}
+		if slices.Contains(deviceConfigurationCustomEncryptedOmaSettingTypes, odataType) {
+			omaUri, _ := omaSetting["omaUri"].(string)
+			writeOnlyValue, hasWriteOnlyValue := writeOnlyValues[omaUri]
+			delete(writeOnlyValues, omaUri)
+			if omaSetting["value"] == nil {
+				if !hasWriteOnlyValue {
+					return fmt.Errorf("omaSettings[%d]: value must either be specified or be provided by oma_setting_values_wo (OMA-URI %q)", i, omaUri)
+				}
+				omaSetting["value"] = writeOnlyValue
+			}
+		}
 		if odataType == "#microsoft.graph.omaSettingStringXml" {
 			value, ok := omaSetting["value"].(string)
 			if !ok {
@@ -82,9 +104,21 @@ func deviceConfigurationCustomTerraformToGraphMiddleware(ctx context.Context, di
 		}
 	}
 
+	if len(writeOnlyValues) > 0 {
+		return fmt.Errorf("oma_setting_values_wo contains values for OMA-URIs which are not used by any OMA setting of type base64, string or string_xml: %v",
+			slices.Sorted(maps.Keys(writeOnlyValues)))
+	}
+
 	return nil
 }
 
+// OMA setting types whose values are saved encrypted in MS Graph and therefore support write-only values
+var deviceConfigurationCustomEncryptedOmaSettingTypes = []string{
+	"#microsoft.graph.omaSettingBase64",
+	"#microsoft.graph.omaSettingString",
+	"#microsoft.graph.omaSettingStringXml",
+}
+
 func deviceConfigurationCustomExtraRequestCustomReadSecretValue(ctx context.Context, diags *diag.Diagnostics, params generic.ReadExtraRequestCustomParams) {
 	warningDetail := "Skipping retrieval of secret value(s)"
 
@@ -94,12 +128,19 @@ func deviceConfigurationCustomExtraRequestCustomReadSecretValue(ctx context.Cont
 		return
 	}
 
+	writeOnlyOmaUris := deviceConfigurationCustomWriteOnlyOmaUris(params.ReqState)
+
 	for i, omaSettingRaw := range omaSettings {
 		omaSetting, ok := omaSettingRaw.(map[string]any)
 		if !ok {
 			diags.AddWarning(fmt.Sprintf("omaSettings[%d] not of type map[string]any", i), warningDetail)
 			continue
 		}
+		if omaUri, _ := omaSetting["omaUri"].(string); writeOnlyOmaUris[omaUri] {
+			// value has been provided by oma_setting_values_wo and must not be persisted (even if not encrypted)
+			omaSetting["value"] = nil
+			continue
+		}
 		isEncrypted, ok := omaSetting["isEncrypted"].(bool)
 		if !ok {
 			diags.AddWarning(fmt.Sprintf("omaSettings[%d]: isEncrypted not found or not of type bool", i), warningDetail)
@@ -129,6 +170,46 @@ func deviceConfigurationCustomExtraRequestCustomReadSecretValue(ctx context.Cont
 	}
 }
 
+// deviceConfigurationCustomWriteOnlyOmaUris returns the OMA-URIs of all OMA settings in state whose (encrypted) value
+// is null, i.e. has been provided by oma_setting_values_wo.
+func deviceConfigurationCustomWriteOnlyOmaUris(state *tfsdk.State) map[string]bool {
+	result := map[string]bool{}
+	if state == nil || state.Raw.IsNull() {
+		return result
+	}
+
+	omaSettingsRaw, _, err := tftypes.WalkAttributePath(state.Raw, tftypes.NewAttributePath().WithAttributeName("windows10").WithAttributeName("oma_settings"))
+	if err != nil {
+		return result
+	}
+	var omaSettings []tftypes.Value
+	if err := omaSettingsRaw.(tftypes.Value).As(&omaSettings); err != nil {
+		return result
+	}
+
+	for _, omaSettingRaw := range omaSettings {
+		var omaSetting map[string]tftypes.Value
+		if err := omaSettingRaw.As(&omaSetting); err != nil {
+			continue
+		}
+		var omaUri string
+		if err := omaSetting["oma_uri"].As(&omaUri); err != nil {
+			continue
+		}
+		for derivedType, valueAttribute := range map[string]string{"base64": "value_base64", "string": "value", "string_xml": "value"} {
+			var derived map[string]tftypes.Value
+			if omaSetting[derivedType].IsNull() || omaSetting[derivedType].As(&derived) != nil {
+				continue
+			}
+			if derived[valueAttribute].IsNull() {
+				result[omaUri] = true
+			}
+		}
+	}
+
+	return result
+}
+
 var deviceConfigurationCustomResourceSchema = schema.Schema{
 	Attributes: map[string]schema.Attribute{ // deviceConfiguration
 		"id": schema.StringAttribute{
@@ -244,6 +325,13 @@ var deviceConfigurationCustomResourceSchema = schema.Schema{
 			MarkdownDescription: "Version of the device configuration.",
 		},
 		"assignments": deviceAndAppManagementAssignment,
+		"oma_setting_values_wo": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			WriteOnly:           true,
+			MarkdownDescription: "Write-only values of OMA settings of type `base64`, `string` or `string_xml`, keyed by their `oma_uri`. These values will be used for all OMA settings that do not specify a value themselves and will never be persisted to Terraform state (requires Terraform 1.11 or later).",
+		},
+		"oma_setting_values_wo_version": generic.WriteOnlyVersionAttribute("oma_setting_values_wo"),
 		"windows10": generic.OdataDerivedTypeNestedAttributeRs{
 			DerivedType: "#microsoft.graph.windows10CustomConfiguration",
 			SingleNestedAttribute: schema.SingleNestedAttribute{
@@ -275,9 +363,9 @@ var deviceConfigurationCustomResourceSchema = schema.Schema{
 												MarkdownDescription: "File name associated with the Value property (*.cer",
 											},
 											"value_base64": schema.StringAttribute{
-												Required:            true,
+												Optional:            true,
 												Description:         `value`, // custom MS Graph attribute name
-												MarkdownDescription: "Value. (Base64 encoded string)",
+												MarkdownDescription: "Value. (Base64 encoded string) <br/> _Provider_ Note: Can be omitted to use the write-only value from `oma_setting_values_wo` instead.",
 											},
 										},
 										Validators:          []validator.Object{deviceConfigurationCustomOmaSettingValidator},
@@ -346,8 +434,8 @@ var deviceConfigurationCustomResourceSchema = schema.Schema{
 										Optional: true,
 										Attributes: map[string]schema.Attribute{ // omaSettingString
 											"value": schema.StringAttribute{
-												Required:            true,
-												MarkdownDescription: "Value.",
+												Optional:            true,
+												MarkdownDescription: "Value. <br/> _Provider_ Note: Can be omitted to use the write-only value from `oma_setting_values_wo` instead.",
 											},
 										},
 										Validators:          []validator.Object{deviceConfigurationCustomOmaSettingValidator},
@@ -364,8 +452,8 @@ var deviceConfigurationCustomResourceSchema = schema.Schema{
 												MarkdownDescription: "File name associated with the Value property (*.xml).",
 											},
 											"value": schema.StringAttribute{
-												Required:            true,
-												MarkdownDescription: "Value. (UTF8 encoded byte array)",
+												Optional:            true,
+												MarkdownDescription: "Value. (UTF8 encoded byte array) <br/> _Provider_ Note: Can be omitted to use the write-only value from `oma_setting_values_wo` instead.",
 											},
 										},
 										Validators:          []validator.Object{deviceConfigurationCustomOmaSettingValidator},
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestDeviceConfigurationCustomResourceWriteOnlyValues(t *testing.T) {
+	var es *graphmock.EntitySet
+	var requestsBefore int
+	name := generictest.ResourceName(&DeviceConfigurationCustomResource)
+
+	// MS Graph saves values of some OMA setting types encrypted and only returns them using a special function
+	encrypt := func() {
+		entity := es.Get(es.Ids()[0])
+		for _, s := range entity["omaSettings"].([]any) {
+			omaSetting := s.(map[string]any)
+			omaSetting["isEncrypted"] = true
+			omaSetting["secretReferenceValueId"] = omaSetting["omaUri"]
+			omaSetting["plainValue"] = omaSetting["value"]
+			omaSetting["value"] = "****"
+		}
+		es.Put(entity)
+		requestsBefore = len(generictest.Graph().Requests())
+	}
+	plainTextRequested := func(omaUri string) bool {
+		for _, r := range generictest.Graph().Requests()[requestsBefore:] {
+			if strings.Contains(r.Path, "getOmaSettingPlainTextValue") && strings.Contains(r.Path, omaUri) {
+				return true
+			}
+		}
+		return false
+	}
+	graphValue := func(omaUri string) string {
+		for _, s := range es.Get(es.Ids()[0])["omaSettings"].([]any) {
+			if omaSetting := s.(map[string]any); omaSetting["omaUri"] == omaUri {
+				if plainValue, ok := omaSetting["plainValue"]; ok {
+					return plainValue.(string)
+				}
+				return omaSetting["value"].(string)
+			}
+		}
+		return ""
+	}
+
+	config := func(secret string, version int) string {
+		return generictest.Config(&DeviceConfigurationCustomResource, fmt.Sprintf(`
+			display_name = "Test"
+			windows10 = {
+				oma_settings = [
+					{ display_name = "Secret", oma_uri = "./Vendor/Secret", string = {} },
+					{ display_name = "Visible", oma_uri = "./Vendor/Visible", string = { value = "visible" } },
+				]
+			}
+			oma_setting_values_wo         = { "./Vendor/Secret" = %q }
+			oma_setting_values_wo_version = %d
+		`, secret, version))
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &DeviceConfigurationCustomResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/deviceManagement/deviceConfigurations")
+			es.Actions = map[string]graphmock.ActionFunc{
+				"assign": graphmock.StoreNavigation("assignments", "assignments"),
+				"getOmaSettingPlainTextValue": func(e *graphmock.EntitySet, id string, r *http.Request, _ map[string]any) (int, any) {
+					for _, s := range e.Get(id)["omaSettings"].([]any) {
+						if omaSetting := s.(map[string]any); strings.Contains(r.URL.Path, omaSetting["omaUri"].(string)) {
+							return http.StatusOK, map[string]any{"value": omaSetting["plainValue"]}
+						}
+					}
+					return http.StatusNotFound, nil
+				},
+			}
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("s3cr3t", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(name, "oma_setting_values_wo.%"),
+					resource.TestCheckResourceAttr(name, "oma_setting_values_wo_version", "1"),
+					func(s *terraform.State) error {
+						for k, v := range generictest.Attributes(s) {
+							if v == "s3cr3t" {
+								return fmt.Errorf("write-only value has been persisted to state in %s", k)
+							}
+						}
+						if v := graphValue("./Vendor/Secret"); v != "s3cr3t" {
+							return fmt.Errorf("write-only value has not been sent to MS Graph, got %q", v)
+						}
+						if _, ok := es.Get(es.Ids()[0])["omaSettingValuesWo"]; ok {
+							return fmt.Errorf("write-only attribute has been sent to MS Graph as is")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: encrypt,
+				Config:    config("s3cr3t", 1),
+				Check: func(s *terraform.State) error {
+					if plainTextRequested("./Vendor/Secret") {
+						return fmt.Errorf("plain text value has been requested for write-only value")
+					}
+					if !plainTextRequested("./Vendor/Visible") {
+						return fmt.Errorf("plain text value has not been requested for regular value")
+					}
+					return nil
+				},
+			},
+			{
+				Config: config("r0tated", 2),
+				Check: func(s *terraform.State) error {
+					if v := graphValue("./Vendor/Secret"); v != "r0tated" {
+						return fmt.Errorf("rotated write-only value has not been sent to MS Graph, got %q", v)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
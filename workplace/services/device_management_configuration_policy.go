@@ -40,6 +40,9 @@ var (
 					},
 				},
 			},
+			ImportOptions: generic.ImportOptions{
+				KeyAttributes: []string{"name"},
+			},
 		},
 	}
 
@@ -42,6 +42,9 @@ var (
 					},
 				},
 			},
+			ImportOptions: generic.ImportOptions{
+				KeyAttributes: []string{"name"},
+			},
 		},
 	}
 
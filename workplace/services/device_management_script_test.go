@@ -0,0 +1,104 @@
+package services
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestDeviceManagementScriptResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&DeviceManagementScriptResource)
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &DeviceManagementScriptResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/deviceManagement/deviceManagementScripts")
+			es.Defaults = map[string]any{
+				"createdDateTime":      "2024-01-01T00:00:00Z",
+				"lastModifiedDateTime": "2024-01-01T00:00:00Z",
+			}
+			es.Actions = map[string]graphmock.ActionFunc{
+				"assign": graphmock.StoreNavigation("deviceManagementScriptAssignments", "assignments"),
+			}
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&DeviceManagementScriptResource, `
+					display_name   = "Test"
+					file_name      = "test.ps1"
+					script_content = "Write-Host 'Test'"
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "run_as_account", "user"),
+					resource.TestCheckResourceAttr(name, "run_as_32_bit", "false"),
+					resource.TestCheckResourceAttr(name, "assignments.#", "0"),
+					func(s *terraform.State) error {
+						entity := es.Get(generictest.Attributes(s)["id"])
+						if entity["scriptContent"] != base64.StdEncoding.EncodeToString([]byte("Write-Host 'Test'")) {
+							return fmt.Errorf("script content has not been encoded, got %v", entity["scriptContent"])
+						}
+						if _, ok := entity["runAs32Bit"]; !ok {
+							return fmt.Errorf("custom MS Graph attribute name has not been used")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: generictest.Config(&DeviceManagementScriptResource, `
+					display_name   = "Test"
+					file_name      = "test.ps1"
+					script_content = "Write-Host 'Test'"
+					assignments = [
+						{ target = { all_devices = {} } },
+						{ target = { group = { group_id = "a3f4e0b6-3f47-4b5e-9c4d-1c0e5b6b8f11" }, filter_type = "none" } },
+					]
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "assignments.#", "2"),
+					func(s *terraform.State) error {
+						assignments, _ := es.GetNavigation(generictest.Attributes(s)["id"], "assignments").([]any)
+						if len(assignments) != 2 {
+							return fmt.Errorf("expected 2 assignments in MS Graph, got %d", len(assignments))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			{
+				ImportState:       true,
+				ImportStateId:     "displayName = Test",
+				ImportStateVerify: true,
+			},
+			{
+				ImportState:   true,
+				ImportStateId: "display_name=Unknown",
+				ExpectError:   regexp.MustCompile(`No entity with displayName 'Unknown' found`),
+			},
+			{
+				PreConfig: func() {
+					es.Put(map[string]any{"displayName": "Test", "fileName": "other.ps1"})
+				},
+				ImportState:   true,
+				ImportStateId: "displayName=Test",
+				ExpectError:   regexp.MustCompile(`2 entities with displayName 'Test' found`),
+			},
+		},
+	})
+}
@@ -1,16 +1,91 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"slices"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpvalidator"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 var (
-	groupResource = generic.GenericResource{
+	GroupResource = generic.GenericResource{
+		TypeNameSuffix:           "group",
+		SpecificSchema:           groupResourceSchema,
+		SpecificConfigValidators: []resource.ConfigValidator{groupConfigValidator{}},
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/groups",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ReadOptions: generic.ReadOptions{
+				// many attributes (e.g. assignedLicenses) only get returned when being selected explicitly
+				ODataSelect: []string{"id", "assignedLicenses", "classification", "createdDateTime", "description",
+					"displayName", "groupTypes", "isAssignableToRole", "mail", "mailEnabled", "mailNickname",
+					"membershipRule", "membershipRuleProcessingState", "securityEnabled", "visibility"},
+				ExtraRequests: []generic.ReadExtraRequest{
+					{
+						Attribute: "owners",
+					},
+				},
+				ExtraRequestsCustom: []generic.ReadExtraRequestCustom{
+					groupMembersExtraRequestCustom,
+				},
+				DataSource: generic.DataSourceOptions{
+					ExtraFilterAttributes: []string{"mail", "mail_enabled", "mail_nickname", "security_enabled"},
+					Plural: generic.PluralOptions{
+						ExtraAttributes: []string{"assigned_licenses", "group_types", "mail", "mail_enabled", "mail_nickname", "security_enabled"},
+					},
+				},
+			},
+			WriteOptions: generic.WriteOptions{
+				SubActions: []generic.WriteSubAction{
+					&generic.WriteSubActionIndividual{
+						WriteSubActionBase: generic.WriteSubActionBase{
+							Attributes: []string{"owners"},
+							UriSuffix:  "owners",
+						},
+						ComparisonKeyAttribute: "id",
+						SetNestedPath:          tftypes.NewAttributePath().WithAttributeName("owners"),
+						IsOdataReference:       true,
+						OdataRefMapTypeToUriPrefix: map[string]string{
+							"": "https://graph.microsoft.com/beta/directoryObjects/", // this will work for users and service principals
+						},
+					},
+					&generic.WriteSubActionIndividual{
+						WriteSubActionBase: generic.WriteSubActionBase{
+							Attributes: []string{"members"},
+							UriSuffix:  "members",
+						},
+						ComparisonKeyAttribute: "id",
+						SetNestedPath:          tftypes.NewAttributePath().WithAttributeName("members"),
+						IsOdataReference:       true,
+						OdataRefMapTypeToUriPrefix: map[string]string{
+							"": "https://graph.microsoft.com/beta/directoryObjects/", // this will work for users, groups, devices etc.
+						},
+					},
+				},
+			},
+		},
+	}
+
+	groupDataSourceResource = generic.GenericResource{
 		TypeNameSuffix: "group",
-		SpecificSchema: groupResourceSchema,
+		SpecificSchema: groupDataSourceSchema,
 		AccessParams: generic.AccessParams{
 			BaseUri: "/groups",
 			ReadOptions: generic.ReadOptions{
@@ -25,13 +100,207 @@ var (
 	}
 
 	GroupSingularDataSource = generic.CreateGenericDataSourceSingularFromResource(
-		&groupResource)
+		&groupDataSourceResource)
 
 	GroupPluralDataSource = generic.CreateGenericDataSourcePluralFromResource(
-		&groupResource, "")
+		&groupDataSourceResource, "")
 )
 
+const groupTypeDynamicMembership = "DynamicMembership"
+
+// groupMembersExtraRequestCustom reads the members of the group unless it is a dynamic group. Members of dynamic groups
+// are managed by MS Graph (according to the membership rule), so they are neither read nor managed here.
+func groupMembersExtraRequestCustom(ctx context.Context, diags *diag.Diagnostics, params generic.ReadExtraRequestCustomParams) {
+	if groupTypes, ok := params.RawVal["groupTypes"].([]any); ok && slices.Contains(groupTypes, any(groupTypeDynamicMembership)) {
+		params.RawVal["members"] = []any{}
+		return
+	}
+
+	uri := msgraph.Uri{Entity: fmt.Sprintf("%s/members", params.Uri.Entity)}
+	members := generic.ReadRaw2(ctx, diags, params.Client, uri, nil, nil, params.TolerateNotFound)
+	if diags.HasError() || members == nil {
+		return
+	}
+	params.RawVal["members"], _ = members["value"].([]any)
+}
+
+// groupConfigValidator checks the combinations of attributes not supported by MS Graph (to fail during plan already).
+type groupConfigValidator struct{}
+
+var _ resource.ConfigValidator = groupConfigValidator{}
+
+func (v groupConfigValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v groupConfigValidator) MarkdownDescription(_ context.Context) string {
+	return "`membership_rule` must be set if and only if `group_types` contains `DynamicMembership`, dynamic groups " +
+		"must not have `members` and role-assignable groups must be security enabled and must not be dynamic"
+}
+
+func (v groupConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+
+	var groupTypes types.Set
+	var membershipRule types.String
+	var members types.Set
+	var isAssignableToRole, securityEnabled types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("group_types"), &groupTypes)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("membership_rule"), &membershipRule)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("members"), &members)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("is_assignable_to_role"), &isAssignableToRole)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("security_enabled"), &securityEnabled)...)
+	if resp.Diagnostics.HasError() || groupTypes.IsUnknown() {
+		return
+	}
+
+	isDynamic := false
+	for _, e := range groupTypes.Elements() {
+		if s, ok := e.(types.String); ok && s.ValueString() == groupTypeDynamicMembership {
+			isDynamic = true
+		}
+	}
+
+	const summary = "Invalid Attribute Combination"
+	if isDynamic && membershipRule.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("membership_rule"), summary,
+			"membership_rule must be set for dynamic groups (i.e. if group_types contains DynamicMembership).")
+	}
+	if !isDynamic && !membershipRule.IsNull() && !membershipRule.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("membership_rule"), summary,
+			"membership_rule may only be set for dynamic groups (i.e. if group_types contains DynamicMembership).")
+	}
+	if isDynamic && !members.IsNull() && !members.IsUnknown() && len(members.Elements()) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("members"), summary,
+			"Members of dynamic groups are managed by MS Graph according to membership_rule and must not be set.")
+	}
+	if isAssignableToRole.ValueBool() {
+		if isDynamic {
+			resp.Diagnostics.AddAttributeError(path.Root("is_assignable_to_role"), summary,
+				"Groups assignable to roles must not be dynamic.")
+		}
+		if !securityEnabled.IsUnknown() && !securityEnabled.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("is_assignable_to_role"), summary,
+				"Groups assignable to roles must be security enabled.")
+		}
+	}
+}
+
 var groupResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // group
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the group. <br/> Returned by default. Key. Not nullable. Read-only. <br/> Supports `$filter` (`eq`, `ne`, `not`, `in`).",
+		},
+		"assigned_licenses": schema.SetNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{ // assignedLicense
+					"disabled_plans": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Computed:            true,
+						MarkdownDescription: "A collection of the unique identifiers for plans that have been disabled. IDs are available in **servicePlans** > **servicePlanId** in the tenant's [subscribedSkus](https://learn.microsoft.com/en-us/graph/api/resources/subscribedsku?view=graph-rest-beta) or **serviceStatus** > **servicePlanId** in the tenant's [companySubscription](https://learn.microsoft.com/en-us/graph/api/resources/subscribedsku?view=graph-rest-beta).",
+					},
+					"sku_id": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The unique identifier for the SKU. Corresponds to the **skuId** from [subscribedSkus](https://learn.microsoft.com/en-us/graph/api/resources/subscribedsku?view=graph-rest-beta) or [companySubscription](https://learn.microsoft.com/en-us/graph/api/resources/companysubscription?view=graph-rest-beta).",
+					},
+				},
+			},
+			PlanModifiers:       []planmodifier.Set{wpplanmodifier.SetUseStateForUnknown()},
+			MarkdownDescription: "The licenses that are assigned to the group. <br/> Returned only on `$select`. Supports `$filter` (`eq`). Read-only. <br/> Represents a license assigned to a user or group. The **assignedLicenses** property of the [user](user.md) or [group](group.md) entitity is a collection of **assignedLicense** objects. Also see [Microsoft docs for assignedLicense](https://learn.microsoft.com/en-us/graph/api/resources/assignedlicense?view=graph-rest-beta). <br>  \n_Provider_ Note: Use `microsoft365wp_group_assigned_license` to assign licenses to the group. <br> ",
+		},
+		"classification": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Describes a classification for the group (such as low, medium or high business impact). Valid values for this property are defined by creating a ClassificationList [setting](directorysetting.md) value, based on the [template definition](directorysettingtemplate.md). <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `startsWith`).",
+		},
+		"created_date_time": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "Timestamp of when the group was created. The value can't be modified and is automatically populated when the group is created. The Timestamp type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`). Read-only.",
+		},
+		"description": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "An optional description for the group. <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `startsWith`) and `$search`.",
+		},
+		"display_name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The display name for the group. Required. Maximum length is 256 characters. <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values), `$search`, and `$orderby`.",
+		},
+		"group_types": schema.SetAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(stringvalidator.OneOf("Unified", "DynamicMembership")),
+			},
+			PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Specifies the group type and its membership. <br/> If the collection contains `Unified`, the group is a Microsoft 365 group; otherwise, it's either a security group or a distribution group. For details, see [groups overview](groups-overview.md). <br/> If the collection includes `DynamicMembership`, the group has dynamic membership; otherwise, membership is static. <br/> Returned by default. Supports `$filter` (`eq`, `not`). <br/> _Provider_ allowed values are: `Unified`, `DynamicMembership`. The _provider_ default value is `[]`.",
+		},
+		"is_assignable_to_role": schema.BoolAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Indicates whether this group can be assigned to a Microsoft Entra role. Optional. <br/> This property can only be set while creating the group and is immutable. If set to `true`, the **securityEnabled** property must also be set to `true`, **visibility** must be `Hidden`, and the group can't be a dynamic group (that is, **groupTypes** can't contain `DynamicMembership`). <br/> Only callers with at least the Privileged Role Administrator role can set this property. The caller must also be assigned the _RoleManagement.ReadWrite.Directory_ permission to set this property or update the membership of such groups. For more, see [Using a group to manage Microsoft Entra role assignments](https://go.microsoft.com/fwlink/?linkid=2103037) <br/> Using this feature requires a Microsoft Entra ID P1 license. Returned by default. Supports `$filter` (`eq`, `ne`, `not`).",
+		},
+		"mail": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The SMTP address for the group, for example, \"serviceadmins@contoso.com\". <br/> Returned by default. Read-only. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"mail_enabled": schema.BoolAttribute{
+			Required:            true,
+			MarkdownDescription: "Specifies whether the group is mail-enabled. Required. <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, and `eq` on `null` values).",
+		},
+		"mail_nickname": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The mail alias for the group, unique for Microsoft 365 groups in the organization. Maximum length is 64 characters. This property can contain only characters in the [ASCII character set 0 - 127](https://learn.microsoft.com/en-us/office/vba/language/reference/user-interface-help/character-set-0127) except the following: ` @ () \\ [] \" ; : <> , SPACE`. Required. <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"membership_rule": schema.StringAttribute{
+			Optional:            true,
+			Validators:          []validator.String{wpvalidator.MembershipRule()},
+			MarkdownDescription: "The rule that determines members for this group if the group is a dynamic group (groupTypes contains `DynamicMembership`). For more information about the syntax of the membership rule, see [Membership Rules syntax](https://learn.microsoft.com/en-us/entra/identity/users/groups-dynamic-membership). <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `startsWith`).",
+		},
+		"membership_rule_processing_state": schema.StringAttribute{
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.OneOf("On", "Paused")},
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "Indicates whether the dynamic membership processing is on or paused. Possible values are `On` or `Paused`. <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, `in`). <br/> _Provider_ allowed values are: `On`, `Paused`.",
+		},
+		"security_enabled": schema.BoolAttribute{
+			Required:            true,
+			MarkdownDescription: "Specifies whether the group is a security group. Required. <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, `in`).",
+		},
+		"visibility": schema.StringAttribute{
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.OneOf("Private", "Public", "HiddenMembership")},
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "Specifies the group join policy and group content visibility for groups. Possible values are: `Private`, `Public`, or `HiddenMembership`. `HiddenMembership` can be set only for Microsoft 365 groups when the groups are created and can't be updated later. Other values of **visibility** can be updated after group creation. <br/> If visibility value isn't specified during group creation on Microsoft Graph, a security group is created as `Private` by default, and Microsoft 365 group is `Public`. Groups assignable to roles are always `Private`. To learn more, see [group visibility options](#group-visibility-options). <br/> Returned by default. Nullable. <br/> _Provider_ allowed values are: `Private`, `Public`, `HiddenMembership`.",
+		},
+		"owners": schema.SetNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: groupDirectoryObjectAttributes,
+			},
+			PlanModifiers:       []planmodifier.Set{wpplanmodifier.SetUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "The owners of the group who can be users or service principals. Limited to 100 owners. Nullable. <br/> If this property isn't specified when creating a Microsoft 365 group the calling user (if any) is automatically assigned as the group owner. <br>  \n_Provider_ Note: If not set, the owners of the group are not managed by Terraform (e.g. to keep the owner assigned automatically by MS Graph). <br> ",
+		},
+		"members": schema.SetNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: groupDirectoryObjectAttributes,
+			},
+			PlanModifiers:       []planmodifier.Set{wpplanmodifier.SetUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "The members of this group, who can be users, devices, other groups, or service principals. Nullable. <br>  \n_Provider_ Note: If not set, the members of the group are not managed by Terraform. Members of dynamic groups are managed by MS Graph according to `membership_rule`, hence they must not be set and are always empty in the state. <br> ",
+		},
+	},
+	MarkdownDescription: "Represents a Microsoft Entra group, which can be a Microsoft 365 group, a team in Microsoft Teams, or a security group.\n\nFor performance reasons, the [create](https://learn.microsoft.com/en-us/graph/api/group-post-groups?view=graph-rest-beta), [get](https://learn.microsoft.com/en-us/graph/api/group-get?view=graph-rest-beta), and [list](https://learn.microsoft.com/en-us/graph/api/group-list?view=graph-rest-beta) operations return only a subset of more commonly used properties by default. These _default_ properties are noted in the [Properties](#properties) section. To get any of the properties not returned by default, specify them in a `$select` OData query option.\n\nAlso see [Microsoft docs for group](https://learn.microsoft.com/en-us/graph/api/resources/group?view=graph-rest-beta). ||| MS Graph: Entra ID",
+}
+
+var groupDataSourceSchema = schema.Schema{
 	Attributes: map[string]schema.Attribute{ // group
 		"id": schema.StringAttribute{
 			MarkdownDescription: "The unique identifier for the group. <br/> Returned by default. Key. Not nullable. Read-only. <br/> Supports `$filter` (`eq`, `ne`, `not`, `in`).",
@@ -57,5 +326,12 @@ var groupResourceSchema = schema.Schema{
 			MarkdownDescription: "The display name for the group. Required. Maximum length is 256 characters. <br/> Returned by default. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values), `$search`, and `$orderby`.",
 		},
 	},
-	MarkdownDescription: "Represents a Microsoft Entra group, which can be a Microsoft 365 group, a team in Microsoft Teams, or a security group.\n\nFor performance reasons, the [create](https://learn.microsoft.com/en-us/graph/api/group-post-groups?view=graph-rest-beta), [get](https://learn.microsoft.com/en-us/graph/api/group-get?view=graph-rest-beta), and [list](https://learn.microsoft.com/en-us/graph/api/group-list?view=graph-rest-beta) operations return only a subset of more commonly used properties by default. These _default_ properties are noted in the [Properties](#properties) section. To get any of the properties not returned by default, specify them in a `$select` OData query option.\n\nAlso see [Microsoft docs for group](https://learn.microsoft.com/en-us/graph/api/resources/group?view=graph-rest-beta).\n\n_Provider_ Note: This data source is only provided as a companion to `azuread_group` to allow for OData filtering. It is not planned to add more attributes or even provide a resource for this entity. ||| MS Graph: Entra ID",
+	MarkdownDescription: "Represents a Microsoft Entra group, which can be a Microsoft 365 group, a team in Microsoft Teams, or a security group.\n\nFor performance reasons, the [create](https://learn.microsoft.com/en-us/graph/api/group-post-groups?view=graph-rest-beta), [get](https://learn.microsoft.com/en-us/graph/api/group-get?view=graph-rest-beta), and [list](https://learn.microsoft.com/en-us/graph/api/group-list?view=graph-rest-beta) operations return only a subset of more commonly used properties by default. These _default_ properties are noted in the [Properties](#properties) section. To get any of the properties not returned by default, specify them in a `$select` OData query option.\n\nAlso see [Microsoft docs for group](https://learn.microsoft.com/en-us/graph/api/resources/group?view=graph-rest-beta).\n\n_Provider_ Note: This data source is only provided as a companion to `azuread_group` to allow for OData filtering. It is not planned to add more attributes to it (see the `microsoft365wp_group` resource instead). ||| MS Graph: Entra ID",
+}
+
+var groupDirectoryObjectAttributes = map[string]schema.Attribute{ // directoryObject
+	"id": schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "The unique identifier for the object. For example, 12345678-9abc-def0-1234-56789abcde. The value of the **id** property is often but not exclusively in the form of a GUID; treat it as an opaque identifier and do not rely on it being a GUID. Key. Not nullable. Read-only.",
+	},
 }
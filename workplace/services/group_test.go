@@ -0,0 +1,218 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func groupTestSetup(s *graphmock.Server) *graphmock.EntitySet {
+	es := s.AddEntitySet("/groups")
+	es.Defaults = map[string]any{"createdDateTime": "2024-01-01T00:00:00Z", "assignedLicenses": []any{}}
+	return es
+}
+
+func groupTestMemberIds(es *graphmock.EntitySet, id string, property string) []string {
+	result := []string{}
+	values, _ := es.GetNavigation(id, property).([]any)
+	for _, v := range values {
+		result = append(result, v.(map[string]any)["id"].(string))
+	}
+	return result
+}
+
+func TestGroupResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&GroupResource)
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &GroupResource,
+		Setup: func(s *graphmock.Server) {
+			es = groupTestSetup(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&GroupResource, `
+					display_name     = "Test"
+					mail_enabled     = false
+					mail_nickname    = "test"
+					security_enabled = true
+					owners           = [{ id = "owner1" }]
+					members          = [{ id = "user1" }, { id = "user2" }]
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "id"),
+					resource.TestCheckResourceAttr(name, "group_types.#", "0"),
+					resource.TestCheckResourceAttr(name, "owners.#", "1"),
+					resource.TestCheckResourceAttr(name, "members.#", "2"),
+					func(s *terraform.State) error {
+						id := generictest.Attributes(s)["id"]
+						if members := groupTestMemberIds(es, id, "members"); len(members) != 2 {
+							return fmt.Errorf("expected 2 members in MS Graph, got %v", members)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: generictest.Config(&GroupResource, `
+					display_name     = "Test updated"
+					mail_enabled     = false
+					mail_nickname    = "test"
+					security_enabled = true
+					owners           = [{ id = "owner2" }]
+					members          = [{ id = "user2" }, { id = "user3" }]
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "display_name", "Test updated"),
+					resource.TestCheckResourceAttr(name, "owners.#", "1"),
+					resource.TestCheckResourceAttr(name, "members.#", "2"),
+					func(s *terraform.State) error {
+						id := generictest.Attributes(s)["id"]
+						if owners := groupTestMemberIds(es, id, "owners"); len(owners) != 1 || owners[0] != "owner2" {
+							return fmt.Errorf("expected owner2 as only owner in MS Graph, got %v", owners)
+						}
+						if members := fmt.Sprint(groupTestMemberIds(es, id, "members")); members != "[user2 user3]" {
+							return fmt.Errorf("expected user2 and user3 as members in MS Graph, got %s", members)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if ids := es.Ids(); len(ids) != 0 {
+				return fmt.Errorf("entities still exist: %v", ids)
+			}
+			return nil
+		},
+	})
+}
+
+func TestGroupResourceDynamic(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&GroupResource)
+
+	const dynamicMembershipRule = `membership_rule = "(user.department -eq \"Sales\") -and (user.accountEnabled -eq true)"`
+	dynamicConfig := func(attributes string) string {
+		return generictest.Config(&GroupResource, `
+			display_name     = "Dynamic"
+			mail_enabled     = false
+			mail_nickname    = "dynamic"
+			security_enabled = true
+			group_types      = ["DynamicMembership"]
+		`+attributes)
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &GroupResource,
+		Setup: func(s *graphmock.Server) {
+			es = groupTestSetup(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      dynamicConfig(""),
+				ExpectError: regexp.MustCompile(`membership_rule\s+must\s+be\s+set\s+for\s+dynamic\s+groups`),
+			},
+			{
+				Config: dynamicConfig(`
+					membership_rule = "(user.department -eq \"Sales\""
+					members         = [{ id = "user1" }]
+				`),
+				ExpectError: regexp.MustCompile(`(?s)missing\s+closing\s+parenthesis.*must\s+not\s+be\s+set`),
+			},
+			{
+				Config: dynamicConfig(`
+					membership_rule       = "user.department -equals \"Sales\""
+					is_assignable_to_role = true
+				`),
+				ExpectError: regexp.MustCompile(`(?s)unsupported\s+operator\s+-equals.*must\s+not\s+be\s+dynamic`),
+			},
+			{
+				Config: dynamicConfig(dynamicMembershipRule),
+				Check:  resource.TestCheckResourceAttr(name, "members.#", "0"),
+			},
+			{
+				// members get added by MS Graph according to the membership rule, which must not cause any changes
+				PreConfig: func() {
+					es.SetNavigation(es.Ids()[0], "members", []any{map[string]any{"id": "user1"}})
+				},
+				Config: dynamicConfig(dynamicMembershipRule),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "members.#", "0"),
+					func(*terraform.State) error {
+						for _, r := range generictest.Graph().Requests() {
+							if strings.HasSuffix(r.Path, "/members") {
+								return fmt.Errorf("unexpected request %s %s for members of dynamic group", r.Method, r.Path)
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestGroupResourceOwnersNotManaged(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&GroupResource)
+
+	config := func(attributes string) string {
+		return generictest.Config(&GroupResource, `
+			display_name     = "Test"
+			mail_enabled     = false
+			mail_nickname    = "test"
+			security_enabled = true
+		`+attributes)
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &GroupResource,
+		Setup: func(s *graphmock.Server) {
+			es = groupTestSetup(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+			},
+			{
+				// MS Graph automatically assigns the caller as owner, which must neither cause any changes nor be removed
+				PreConfig: func() {
+					es.SetNavigation(es.Ids()[0], "owners", []any{map[string]any{"id": "caller"}})
+				},
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "owners.#", "1"),
+					resource.TestCheckResourceAttr(name, "owners.0.id", "caller"),
+					func(s *terraform.State) error {
+						if owners := groupTestMemberIds(es, generictest.Attributes(s)["id"], "owners"); len(owners) != 1 {
+							return fmt.Errorf("expected caller as owner in MS Graph, got %v", owners)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config(`owners = [{ id = "owner1" }]`),
+				Check: func(s *terraform.State) error {
+					if owners := groupTestMemberIds(es, generictest.Attributes(s)["id"], "owners"); len(owners) != 1 || owners[0] != "owner1" {
+						return fmt.Errorf("expected owner1 as only owner in MS Graph, got %v", owners)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
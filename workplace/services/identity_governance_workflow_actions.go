@@ -0,0 +1,47 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/generic"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	IdentityGovernanceWorkflowActivateAction = generic.GenericAction{
+		TypeNameSuffix: "identity_governance_workflow_activate",
+		SpecificSchema: identityGovernanceWorkflowActivateActionSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:   "/identityGovernance/lifecycleWorkflows/workflows",
+			UriSuffix: "microsoft.graph.identityGovernance.activate",
+		},
+	}
+)
+
+var identityGovernanceWorkflowActivateActionSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // identityGovernance.workflow.activate parameters
+		"id": schema.StringAttribute{
+			Required:            true,
+			Description:         generic.TerraformOnlyAttribute,
+			MarkdownDescription: "The id of the workflow.",
+		},
+		"subjects": schema.SetNestedAttribute{
+			Required: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{ // user
+					"id": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The id of the user.",
+					},
+				},
+			},
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+			MarkdownDescription: "The users to run the workflow for.",
+		},
+	},
+	MarkdownDescription: "Runs a lifecycle workflow on demand for the given users (MS Graph action `activate`), e.g. a workflow " +
+		"created with `execution_conditions.on_demand_execution_only`.",
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/generic"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+var (
+	ManagedDeviceSyncAction = generic.GenericAction{
+		TypeNameSuffix: "managed_device_sync",
+		SpecificSchema: managedDeviceActionSchema("Requests the managed device to check in with Intune (MS Graph action `syncDevice`)."),
+		AccessParams: generic.AccessParams{
+			BaseUri:   "/deviceManagement/managedDevices",
+			UriSuffix: "syncDevice",
+		},
+	}
+
+	ManagedDeviceRetireAction = generic.GenericAction{
+		TypeNameSuffix: "managed_device_retire",
+		SpecificSchema: managedDeviceActionSchema("Retires the managed device, i.e. removes company data and unenrolls it from Intune (MS Graph action `retire`)."),
+		AccessParams: generic.AccessParams{
+			BaseUri:   "/deviceManagement/managedDevices",
+			UriSuffix: "retire",
+		},
+	}
+
+	ManagedDeviceWipeAction = generic.GenericAction{
+		TypeNameSuffix: "managed_device_wipe",
+		SpecificSchema: managedDeviceWipeActionSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:   "/deviceManagement/managedDevices",
+			UriSuffix: "wipe",
+		},
+	}
+)
+
+var managedDeviceIdAttribute = schema.StringAttribute{
+	Required:            true,
+	Description:         generic.TerraformOnlyAttribute,
+	MarkdownDescription: "The id of the managed device.",
+}
+
+func managedDeviceActionSchema(markdownDescription string) schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": managedDeviceIdAttribute,
+		},
+		MarkdownDescription: markdownDescription,
+	}
+}
+
+var managedDeviceWipeActionSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // managedDevice.wipe parameters
+		"id": managedDeviceIdAttribute,
+		"keep_enrollment_data": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Whether to keep the enrollment data.",
+		},
+		"keep_user_data": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Whether to keep the user data.",
+		},
+		"mac_os_unlock_code": schema.StringAttribute{
+			Optional:            true,
+			Sensitive:           true,
+			MarkdownDescription: "The six digit PIN to unlock macOS devices after the wipe.",
+		},
+		"persist_esim_data_plan": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Whether to keep the eSIM data plan.",
+		},
+		"use_protected_wipe": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Whether to use protected wipe (Windows only), i.e. to continue wiping even if the device loses power.",
+		},
+	},
+	MarkdownDescription: "Wipes the managed device, i.e. restores its factory default settings (MS Graph action `wipe`).",
+}
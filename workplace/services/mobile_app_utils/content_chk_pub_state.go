@@ -49,22 +49,18 @@ func (wsa *CheckPublishingStateWsa) ExecutePost(ctx context.Context, diags *diag
 	}
 	tflog.Trace(ctx, kLogPrefCntWPost+"entityUri: "+entityUriUri.Entity)
 
-	for {
-		// include id for easier debugging
-		body := wsaReq.GenRes.AccessParams.ReadRaw2(ctx, diags, entityUriUri, "", "", []string{"id", "publishingState"}, false)
-		if diags.HasError() {
-			return
-		}
-		publishingState := body["publishingState"].(string)
-		tflog.Trace(ctx, kLogPrefChkPubStPost+"publishingState: "+publishingState)
-
-		if publishingState != "processing" {
-			if !wsa.AllowNotPublished && publishingState != "published" {
-				diags.AddError(errSummChkPubSt, "publishing_state is not 'published' but: "+publishingState)
-			}
-			return
-		}
-
-		time.Sleep(time.Millisecond * 200)
+	successStates := []string{"published"}
+	if wsa.AllowNotPublished {
+		successStates = nil
+	}
+	poller := generic.Poller{
+		StateAttribute: "publishingState",
+		PendingStates:  []string{"processing"},
+		SuccessStates:  successStates,
+		Select:         []string{"id"}, // include id for easier debugging
+		InitialDelay:   time.Millisecond * 200,
+		MaxDelay:       time.Second * 5,
+		ErrorSummary:   errSummChkPubSt,
 	}
+	poller.Poll(ctx, diags, &wsaReq.GenRes.AccessParams, entityUriUri)
 }
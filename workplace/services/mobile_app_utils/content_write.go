@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
 	"terraform-provider-microsoft365wp/workplace/util/azurestorage"
 	"terraform-provider-microsoft365wp/workplace/util/mobileappcontent"
@@ -180,21 +181,25 @@ func (wsa *WriteContentWsa) ExecutePost(ctx context.Context, diags *diag.Diagnos
 		return // nothing to do for us
 	}
 	sad := sadAny.(writeContentWsaData)
+	wsaReq.Wrote = true
 
 	// serialize content updates for now due to problems with concurring content files creation
 	if !wsa.writeMutex.TryLock() {
 		tflog.Info(ctx, kLogPrefCntWPost+"Wait for serialization mutex")
 		wsa.writeMutex.Lock()
 		tflog.Info(ctx, kLogPrefCntWPost+"Lock of serialization mutex succeeded, delay a bit (just in case)")
-		time.Sleep(time.Second * 3)
+		generic.Sleep(ctx, diags, time.Second*3)
 	}
 	defer wsa.writeMutex.Unlock()
 
-	tflog.Info(ctx, kLogPrefCntWPost+"Execute update of mobileApp content")
-
 	if !kDebugContent {
 		defer os.Remove(sad.FileNameEncrypted) // this has been created in Pre
 	}
+	if diags.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, kLogPrefCntWPost+"Execute update of mobileApp content")
 
 	tflog.Debug(ctx, kLogPrefCntWPost+"Get entityUri")
 	entityUriUri := wsaReq.GenRes.AccessParams.GetUriWithIdForUD(ctx, diags, "", wsaReq.Id, wsaReq.IdAttributer)
@@ -206,7 +211,10 @@ func (wsa *WriteContentWsa) ExecutePost(ctx context.Context, diags *diag.Diagnos
 
 	// (hopefully) avoid Precondition Failed / ConditionNotMet errors
 	tflog.Debug(ctx, kLogPrefCntWPost+"Delay before create contentVersion")
-	time.Sleep(time.Second * 1)
+	generic.Sleep(ctx, diags, time.Second*1)
+	if diags.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, kLogPrefCntWPost+"Create contentVersion")
 	contentVersionId, versionUri := wsa.createContentVersion(ctx, diags, wsaReq.GenRes, entityUri, sad.OdataType)
@@ -231,7 +239,7 @@ func (wsa *WriteContentWsa) ExecutePost(ctx context.Context, diags *diag.Diagnos
 	tflog.Trace(ctx, kLogPrefCntWPost+"azureStorageUri: "+azureStorageUri)
 
 	tflog.Debug(ctx, kLogPrefCntWPost+"Upload IntuneWin to Azure Storage")
-	fileMd5Sum, err := azurestorage.UploadBlobInBlocks(ctx, sad.FileNameEncrypted, azureStorageUri)
+	fileMd5Sum, err := azurestorage.UploadBlobInBlocks(ctx, wsaReq.GenRes.AccessParams.HttpTransport(), sad.FileNameEncrypted, azureStorageUri)
 	if err != nil {
 		diags.AddError(kErrSummCntW, "Error uploading IntuneWin file to Azure Storage: "+err.Error())
 		return
@@ -306,27 +314,23 @@ func (*WriteContentWsa) createContentFile(ctx context.Context, diags *diag.Diagn
 func (*WriteContentWsa) ensureContentFileUploadStateSuccess(ctx context.Context, diags *diag.Diagnostics, r *generic.GenericResource,
 	fileUri string, statePrefix string, returnAttributeName string) (returnValue string) {
 
-	for {
-		fileBody := r.AccessParams.ReadRaw(ctx, diags, fileUri, false)
-		if diags.HasError() {
-			return
-		}
-
-		uploadState := fileBody["uploadState"].(string)
-		tflog.Trace(ctx, kLogPrefCntWPost+"uploadState: "+uploadState)
-		if uploadState == statePrefix+"Success" {
-			if returnAttributeName != "" {
-				returnValue = fileBody[returnAttributeName].(string)
-			}
-			return
-		}
-		if uploadState != statePrefix+"Pending" {
-			diags.AddError(kErrSummCntW, "mobileAppContentFile.uploadState does not indicate success: "+uploadState)
-			return
-		}
+	poller := generic.Poller{
+		StateAttribute: "uploadState",
+		PendingStates:  []string{statePrefix + "Pending"},
+		SuccessStates:  []string{statePrefix + "Success"},
+		InitialDelay:   time.Millisecond * 500,
+		MaxDelay:       time.Second * 5,
+		ErrorSummary:   kErrSummCntW,
+	}
+	fileBody := poller.Poll(ctx, diags, &r.AccessParams, msgraph.Uri{Entity: fileUri})
+	if diags.HasError() {
+		return
+	}
 
-		time.Sleep(time.Millisecond * 500)
+	if returnAttributeName != "" {
+		returnValue, _ = fileBody[returnAttributeName].(string)
 	}
+	return
 }
 
 func (*WriteContentWsa) commitContentFile(ctx context.Context, diags *diag.Diagnostics, r *generic.GenericResource,
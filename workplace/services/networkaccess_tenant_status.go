@@ -80,7 +80,10 @@ func networkaccessTenantStatusUpdateReplaceFunc(ctx context.Context, diags *diag
 				return
 			}
 
-			time.Sleep(time.Millisecond * 500)
+			generic.Sleep(ctx, diags, time.Millisecond*500)
+			if diags.HasError() {
+				return
+			}
 		}
 
 		//lint:ignore S1002 Keep for clarity
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestNetworkaccessTenantStatusResourceTimeouts(t *testing.T) {
+	var mu sync.Mutex
+	status := "offboarded"
+	pendingReads := -1 // onboarding will never finish if negative
+
+	setup := func(s *graphmock.Server) {
+		s.Handle("/networkAccess/tenantStatus", graphmock.HandlerFunc(func(w http.ResponseWriter, r *http.Request, _ graphmock.Path) {
+			mu.Lock()
+			defer mu.Unlock()
+			if status == "onboardingInProgress" && pendingReads >= 0 {
+				if pendingReads == 0 {
+					status = "onboarded"
+				}
+				pendingReads--
+			}
+			graphmock.WriteJson(w, http.StatusOK, map[string]any{"onboardingStatus": status})
+		}))
+		s.Handle("/networkAccess/microsoft.graph.networkaccess.onboard", graphmock.HandlerFunc(func(w http.ResponseWriter, r *http.Request, _ graphmock.Path) {
+			mu.Lock()
+			defer mu.Unlock()
+			status = "onboardingInProgress"
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	}
+
+	var start time.Time
+	name := generictest.ResourceName(&NetworkaccessTenantStatusResource)
+	generictest.Test(t, generictest.TestCase{
+		Resource: &NetworkaccessTenantStatusResource,
+		Setup:    setup,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					start = time.Now()
+				},
+				Config: generictest.Config(&NetworkaccessTenantStatusResource, `
+					activate = true
+					timeouts {
+						create = "1s"
+					}
+				`),
+				ExpectError: regexp.MustCompile("Operation aborted"),
+			},
+			{
+				PreConfig: func() {
+					if elapsed := time.Since(start); elapsed > 10*time.Second {
+						t.Errorf("polling has not been aborted after the timeout but took %s", elapsed)
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					status = "offboarded"
+					pendingReads = 3
+				},
+				Config: generictest.Config(&NetworkaccessTenantStatusResource, `
+					activate = true
+					timeouts {
+						create = "1m"
+					}
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "onboarding_status", "onboarded"),
+					resource.TestCheckResourceAttr(name, "timeouts.create", "1m"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func notificationMessageTemplateTestSetup(s *graphmock.Server) *graphmock.EntitySet {
+	es := s.AddEntitySet("/deviceManagement/notificationMessageTemplates")
+	es.ETags = true
+	es.Defaults = map[string]any{"lastModifiedDateTime": "2024-01-01T00:00:00Z"}
+	es.NavigationDefaults = map[string]map[string]any{"localizedNotificationMessages": {"isDefault": false}}
+	es.NewNavigationId = func(_ string, parentId string, entity map[string]any) string {
+		return fmt.Sprintf("%s_%s", parentId, entity["locale"])
+	}
+	return es
+}
+
+func TestNotificationMessageTemplateResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&NotificationMessageTemplateResource)
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &NotificationMessageTemplateResource,
+		Setup: func(s *graphmock.Server) {
+			es = notificationMessageTemplateTestSetup(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&NotificationMessageTemplateResource, `
+					display_name = "Test"
+					localized_notification_messages = [
+						{ locale = "en-us", is_default = true, subject = "Subject", message_template = "Message" },
+					]
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "id"),
+					resource.TestCheckResourceAttr(name, "branding_options", "includeCompanyLogo,includeCompanyName,includeContactInformation"),
+					resource.TestCheckResourceAttr(name, "role_scope_tag_ids.#", "1"),
+					resource.TestCheckResourceAttr(name, "last_modified_date_time", "2024-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(name, "localized_notification_messages.#", "1"),
+					resource.TestCheckResourceAttr(name, "localized_notification_messages.0.subject", "Subject"),
+				),
+			},
+			{
+				Config: generictest.Config(&NotificationMessageTemplateResource, `
+					display_name     = "Test updated"
+					branding_options = "none"
+					localized_notification_messages = [
+						{ locale = "en-us", is_default = true, subject = "Subject updated", message_template = "Message" },
+						{ locale = "de-de", subject = "Betreff", message_template = "Nachricht" },
+					]
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "display_name", "Test updated"),
+					resource.TestCheckResourceAttr(name, "branding_options", "none"),
+					resource.TestCheckResourceAttr(name, "localized_notification_messages.#", "2"),
+					func(s *terraform.State) error {
+						id := generictest.Attributes(s)["id"]
+						if messages := es.GetNavigation(id, "localizedNotificationMessages").([]any); len(messages) != 2 {
+							return fmt.Errorf("expected 2 localized messages in MS Graph, got %d", len(messages))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if ids := es.Ids(); len(ids) != 0 {
+				return fmt.Errorf("entities still exist: %v", ids)
+			}
+			return nil
+		},
+	})
+}
+
+func TestNotificationMessageTemplateResourceChangedOutsideBetweenPlanAndApply(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&NotificationMessageTemplateResource)
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &NotificationMessageTemplateResource,
+		Setup: func(s *graphmock.Server) {
+			es = notificationMessageTemplateTestSetup(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&NotificationMessageTemplateResource, `display_name = "Test"`),
+			},
+			{
+				Config: generictest.Config(&NotificationMessageTemplateResource, `display_name = "Test updated"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						generictest.PreApply(func(*graphmock.Server) {
+							entity := es.Get(es.Ids()[0])
+							entity["displayName"] = "Changed outside"
+							es.Put(entity)
+						}),
+					},
+				},
+				ExpectError: regexp.MustCompile("Entity changed outside Terraform since plan"),
+			},
+			{
+				Config: generictest.Config(&NotificationMessageTemplateResource, `display_name = "Test updated"`),
+				Check:  resource.TestCheckResourceAttr(name, "display_name", "Test updated"),
+			},
+		},
+	})
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+var (
+	PrivilegedAccessGroupAssignmentScheduleResource = generic.GenericResource{
+		TypeNameSuffix: "privileged_access_group_assignment_schedule",
+		SpecificSchema: privilegedAccessGroupAssignmentScheduleResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/identityGovernance/privilegedAccess/group/assignmentSchedules",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ReadOptions: generic.ReadOptions{
+				ExtraRequestsCustom: []generic.ReadExtraRequestCustom{
+					privilegedAccessGroupScheduleExtraRequestCustom,
+				},
+			},
+			GraphToTerraformMiddleware: privilegedAccessGroupScheduleGraphToTerraformMiddleware,
+			CreateReplaceFunc:          privilegedAccessGroupScheduleCreateReplaceFunc("/identityGovernance/privilegedAccess/group/assignmentScheduleRequests"),
+			DeleteReplaceFunc: roleScheduleRequestDeleteReplaceFunc("/identityGovernance/privilegedAccess/group/assignmentScheduleRequests",
+				"/identityGovernance/privilegedAccess/group/assignmentSchedules", "id",
+				"access_id", "group_id", "principal_id", "justification"),
+		},
+	}
+)
+
+var privilegedAccessGroupAssignmentScheduleResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // privilegedAccessGroupAssignmentSchedule
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the privilegedAccessGroupAssignmentSchedule. Key, not nullable, Read-only.",
+		},
+		"access_id": privilegedAccessGroupScheduleAccessIdAttribute,
+		"assignment_type": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "Indicates whether the membership or ownership assignment for the principal is granted through activation or direct assignment, i.e. `assigned` or `activated`. Read-only.",
+		},
+		"created_date_time": privilegedAccessGroupScheduleCreatedDateTimeAttribute,
+		"created_using":     privilegedAccessGroupScheduleCreatedUsingAttribute,
+		"group_id":          privilegedAccessGroupScheduleGroupIdAttribute,
+		"justification":     privilegedAccessGroupScheduleJustificationAttribute,
+		"member_type":       privilegedAccessGroupScheduleMemberTypeAttribute,
+		"principal_id":      privilegedAccessGroupSchedulePrincipalIdAttribute,
+		"schedule_info":     privilegedAccessGroupScheduleScheduleInfoAttribute,
+		"status":            privilegedAccessGroupScheduleStatusAttribute,
+	},
+	MarkdownDescription: "Represents a schedule for an active membership or ownership of a group for a principal through PIM for Groups. <br/> Also see [Microsoft docs for privilegedAccessGroupAssignmentSchedule](https://learn.microsoft.com/en-us/graph/api/resources/privilegedaccessgroupassignmentschedule?view=graph-rest-beta).\n\n_Provider_ Note: This resource creates the schedule using a [privilegedAccessGroupAssignmentScheduleRequest](https://learn.microsoft.com/en-us/graph/api/resources/privilegedaccessgroupassignmentschedulerequest?view=graph-rest-beta) with the action `adminAssign` and then reads back the resulting schedule (i.e. the `id` is the one of the schedule, not of the request). When being destroyed, the schedule gets removed using another request with the action `adminRemove`. Schedules cannot be updated, any change will replace them. If the schedule has expired or has been removed outside of Terraform, the resource is considered to be gone and will be created again. ||| MS Graph: Role management",
+}
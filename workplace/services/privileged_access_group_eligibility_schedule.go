@@ -0,0 +1,51 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+var (
+	PrivilegedAccessGroupEligibilityScheduleResource = generic.GenericResource{
+		TypeNameSuffix: "privileged_access_group_eligibility_schedule",
+		SpecificSchema: privilegedAccessGroupEligibilityScheduleResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/identityGovernance/privilegedAccess/group/eligibilitySchedules",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ReadOptions: generic.ReadOptions{
+				ExtraRequestsCustom: []generic.ReadExtraRequestCustom{
+					privilegedAccessGroupScheduleExtraRequestCustom,
+				},
+			},
+			GraphToTerraformMiddleware: privilegedAccessGroupScheduleGraphToTerraformMiddleware,
+			CreateReplaceFunc:          privilegedAccessGroupScheduleCreateReplaceFunc("/identityGovernance/privilegedAccess/group/eligibilityScheduleRequests"),
+			DeleteReplaceFunc: roleScheduleRequestDeleteReplaceFunc("/identityGovernance/privilegedAccess/group/eligibilityScheduleRequests",
+				"/identityGovernance/privilegedAccess/group/eligibilitySchedules", "id",
+				"access_id", "group_id", "principal_id", "justification"),
+		},
+	}
+)
+
+var privilegedAccessGroupEligibilityScheduleResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // privilegedAccessGroupEligibilitySchedule
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the privilegedAccessGroupEligibilitySchedule. Key, not nullable, Read-only.",
+		},
+		"access_id":         privilegedAccessGroupScheduleAccessIdAttribute,
+		"created_date_time": privilegedAccessGroupScheduleCreatedDateTimeAttribute,
+		"created_using":     privilegedAccessGroupScheduleCreatedUsingAttribute,
+		"group_id":          privilegedAccessGroupScheduleGroupIdAttribute,
+		"justification":     privilegedAccessGroupScheduleJustificationAttribute,
+		"member_type":       privilegedAccessGroupScheduleMemberTypeAttribute,
+		"principal_id":      privilegedAccessGroupSchedulePrincipalIdAttribute,
+		"schedule_info":     privilegedAccessGroupScheduleScheduleInfoAttribute,
+		"status":            privilegedAccessGroupScheduleStatusAttribute,
+	},
+	MarkdownDescription: "Represents a schedule for a principal's eligibility for membership or ownership of a group through PIM for Groups. <br/> Also see [Microsoft docs for privilegedAccessGroupEligibilitySchedule](https://learn.microsoft.com/en-us/graph/api/resources/privilegedaccessgroupeligibilityschedule?view=graph-rest-beta).\n\n_Provider_ Note: This resource creates the schedule using a [privilegedAccessGroupEligibilityScheduleRequest](https://learn.microsoft.com/en-us/graph/api/resources/privilegedaccessgroupeligibilityschedulerequest?view=graph-rest-beta) with the action `adminAssign` and then reads back the resulting schedule (i.e. the `id` is the one of the schedule, not of the request). When being destroyed, the schedule gets removed using another request with the action `adminRemove`. Schedules cannot be updated, any change will replace them. If the schedule has expired or has been removed outside of Terraform, the resource is considered to be gone and will be created again. ||| MS Graph: Role management",
+}
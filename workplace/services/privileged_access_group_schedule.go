@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+//
+// The PIM for Groups resources manage the schedule (e.g. privilegedAccessGroupEligibilitySchedule) that results from a
+// one-shot schedule request (also see role_schedule_request.go): The request only gets created (and polled until it
+// has been processed) when creating the resource, afterwards the resource id is the one of the schedule and all reads
+// go to the schedule. Some attributes are only part of the request though and are therefore taken from the prior
+// state when reading:
+//   - justification is not returned for schedules at all
+//   - schedules requested with an expiration of type afterDuration are returned with type afterDateTime (and the
+//     calculated endDateTime) instead
+//
+
+// privilegedAccessGroupScheduleRequestedKey is used to pass the requested values from prior state on from
+// privilegedAccessGroupScheduleExtraRequestCustom to privilegedAccessGroupScheduleGraphToTerraformMiddleware.
+const privilegedAccessGroupScheduleRequestedKey = "@provider.requested"
+
+var privilegedAccessGroupScheduleRequestPoller = generic.Poller{
+	StateAttribute: "status",
+	PendingStates:  []string{"Granted", "PendingProvisioning", "PendingScheduleCreation"},
+	FailureStates:  []string{"Canceled", "Denied", "Failed", "Revoked"},
+	ErrorSummary:   "Error waiting for schedule request to be processed",
+}
+
+// privilegedAccessGroupScheduleApprovalStates are the states of requests waiting for an approval, which might take
+// days and is therefore not waited for.
+var privilegedAccessGroupScheduleApprovalStates = []string{"PendingApproval", "PendingAdminDecision"}
+
+// privilegedAccessGroupScheduleCreateReplaceFunc returns a function that creates a schedule by creating a request with
+// the action `adminAssign` and waits for it to be processed.
+func privilegedAccessGroupScheduleCreateReplaceFunc(requestBaseUri string) func(context.Context, *diag.Diagnostics, *generic.CreateReplaceFuncParams) {
+	return func(ctx context.Context, diags *diag.Diagnostics, params *generic.CreateReplaceFuncParams) {
+
+		params.RawVal["action"] = "adminAssign"
+		request := generic.CreateRaw(ctx, diags, params.Client, msgraph.Uri{Entity: requestBaseUri}, params.RawVal, nil, false, false)
+		if diags.HasError() {
+			return
+		}
+
+		requestId, _ := request["id"].(string)
+		if requestId == "" {
+			diags.AddError("Unable to create with MS Graph", "MS Graph did not return an id for the new schedule request")
+			return
+		}
+		request = privilegedAccessGroupScheduleRequestPoller.Poll(ctx, diags, &params.R.AccessParams,
+			msgraph.Uri{Entity: fmt.Sprintf("%s/%s", requestBaseUri, requestId)})
+		if diags.HasError() {
+			return
+		}
+
+		if status, _ := request["status"].(string); slices.Contains(privilegedAccessGroupScheduleApprovalStates, status) {
+			diags.AddError("Unable to create with MS Graph", fmt.Sprintf("Schedule request '%s' requires approval (status '%s'). "+
+				"Once it has been approved, the resulting schedule can be imported.", requestId, status))
+			return
+		}
+
+		params.Id, _ = request["targetScheduleId"].(string)
+		if params.Id == "" {
+			diags.AddError("Unable to create with MS Graph", fmt.Sprintf("MS Graph did not return a targetScheduleId for schedule request '%s'", requestId))
+			return
+		}
+	}
+}
+
+// privilegedAccessGroupScheduleExtraRequestCustom copies the values that are only part of the request from prior state
+// (see above).
+func privilegedAccessGroupScheduleExtraRequestCustom(ctx context.Context, diags *diag.Diagnostics, params generic.ReadExtraRequestCustomParams) {
+	if params.ReqState == nil || params.ReqState.Raw.IsNull() {
+		return
+	}
+
+	var justification, expirationType, duration types.String
+	expirationPath := path.Root("schedule_info").AtName("expiration")
+	diags.Append(params.ReqState.GetAttribute(ctx, path.Root("justification"), &justification)...)
+	diags.Append(params.ReqState.GetAttribute(ctx, expirationPath.AtName("type"), &expirationType)...)
+	diags.Append(params.ReqState.GetAttribute(ctx, expirationPath.AtName("duration"), &duration)...)
+	if diags.HasError() {
+		return
+	}
+
+	requested := map[string]any{}
+	if !justification.IsNull() {
+		requested["justification"] = justification.ValueString()
+	}
+	if expirationType.ValueString() == "afterDuration" {
+		requested["duration"] = duration.ValueString()
+	}
+	params.RawVal[privilegedAccessGroupScheduleRequestedKey] = requested
+}
+
+func privilegedAccessGroupScheduleGraphToTerraformMiddleware(ctx context.Context, diags *diag.Diagnostics, params *generic.GraphToTerraformMiddlewareParams) generic.GraphToTerraformMiddlewareReturns {
+	requested, _ := params.RawVal[privilegedAccessGroupScheduleRequestedKey].(map[string]any)
+	delete(params.RawVal, privilegedAccessGroupScheduleRequestedKey)
+	if requested == nil {
+		return nil
+	}
+
+	if justification, ok := requested["justification"]; ok {
+		params.RawVal["justification"] = justification
+	}
+
+	if duration, ok := requested["duration"]; ok {
+		scheduleInfo, _ := params.RawVal["scheduleInfo"].(map[string]any)
+		expiration, _ := scheduleInfo["expiration"].(map[string]any)
+		if expiration != nil && expiration["type"] == "afterDateTime" {
+			expiration["type"] = "afterDuration"
+			expiration["duration"] = duration
+		}
+	}
+
+	return nil
+}
+
+var privilegedAccessGroupScheduleAccessIdAttribute = schema.StringAttribute{
+	Required: true,
+	Validators: []validator.String{
+		stringvalidator.OneOf("member", "owner"),
+	},
+	PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	MarkdownDescription: "The identifier of a membership or ownership assignment relationship to the group. <br/> _Provider_ allowed values are: `member`, `owner`.",
+}
+
+var privilegedAccessGroupScheduleCreatedDateTimeAttribute = schema.StringAttribute{
+	Computed:            true,
+	PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+	MarkdownDescription: "When the schedule was created. The Timestamp type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Read-only.",
+}
+
+var privilegedAccessGroupScheduleCreatedUsingAttribute = schema.StringAttribute{
+	Computed:            true,
+	PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+	MarkdownDescription: "The identifier of the schedule request that created this schedule. Read-only.",
+}
+
+var privilegedAccessGroupScheduleGroupIdAttribute = schema.StringAttribute{
+	Required:            true,
+	PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	MarkdownDescription: "The identifier of the [group](group.md) representing the scope of the membership or ownership through PIM for groups.",
+}
+
+var privilegedAccessGroupScheduleJustificationAttribute = schema.StringAttribute{
+	Optional:            true,
+	PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	MarkdownDescription: "A message provided by users and administrators when they create the schedule request about why it is needed. Depending on the PIM policy of the group this might be required. Will also be used when removing the schedule. <br/> _Provider_ Note: This value is not returned by MS Graph for schedules and will therefore be empty after import.",
+}
+
+var privilegedAccessGroupScheduleMemberTypeAttribute = schema.StringAttribute{
+	Computed:            true,
+	PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+	MarkdownDescription: "Indicates whether the membership or ownership is granted directly or through a group, i.e. `direct` or `group`. Read-only.",
+}
+
+var privilegedAccessGroupSchedulePrincipalIdAttribute = schema.StringAttribute{
+	Required:            true,
+	PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	MarkdownDescription: "The identifier of the principal ([user](user.md) or [group](group.md)) whose membership or ownership is granted through PIM for groups.",
+}
+
+var privilegedAccessGroupScheduleScheduleInfoAttribute = schema.SingleNestedAttribute{
+	Optional: true,
+	Attributes: map[string]schema.Attribute{ // requestSchedule
+		"expiration": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{ // expirationPattern
+				"duration": schema.StringAttribute{
+					Optional:            true,
+					PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+					MarkdownDescription: "The requested duration of access in ISO 8601 format, e.g. `PT8H` for eight hours or `P180D` for 180 days. Required when `type` is `afterDuration`.",
+				},
+				"end_date_time": schema.StringAttribute{
+					Optional:            true,
+					PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace(), wpplanmodifier.StringUseStateForUnknown()},
+					Computed:            true,
+					MarkdownDescription: "Timestamp of date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Required when `type` is `afterDateTime`. <br/> _Provider_ Note: When `type` is `afterDuration`, this will be set to the resulting end of the schedule.",
+				},
+				"type": schema.StringAttribute{
+					Optional: true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("noExpiration", "afterDateTime", "afterDuration"),
+					},
+					PlanModifiers:       []planmodifier.String{wpdefaultvaluemodifier.StringDefaultValue("noExpiration"), stringplanmodifier.RequiresReplace()},
+					Computed:            true,
+					MarkdownDescription: "The requestor's desired expiration pattern type. <br/> _Provider_ allowed values are: `noExpiration`, `afterDateTime`, `afterDuration`. The _provider_ default value is `\"noExpiration\"`.",
+				},
+			},
+			PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty(), objectplanmodifier.RequiresReplace()},
+			Computed:            true,
+			MarkdownDescription: "When the schedule expires. Depending on the PIM policy of the group (see [unified_role_management_policy](unified_role_management_policy.md)) schedules without expiration or longer durations might not be allowed. <br/> The _provider_ default value is `{}`.",
+		},
+		"start_date_time": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace(), wpplanmodifier.StringUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "When the eligible or active membership or ownership becomes active. The Timestamp type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. If not set, the current date and time will be used.",
+		},
+	},
+	PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty(), objectplanmodifier.RequiresReplace()},
+	Computed:            true,
+	MarkdownDescription: "The period of the schedule, i.e. when it starts and when it expires. / Also see [Microsoft docs for requestSchedule](https://learn.microsoft.com/en-us/graph/api/resources/requestschedule?view=graph-rest-beta). <br/> The _provider_ default value is `{}`.",
+}
+
+var privilegedAccessGroupScheduleStatusAttribute = schema.StringAttribute{
+	Computed:            true,
+	PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+	MarkdownDescription: "The status of the schedule, e.g. `Provisioned`. Read-only.",
+}
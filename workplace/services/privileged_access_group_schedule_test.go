@@ -0,0 +1,163 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestPrivilegedAccessGroupEligibilityScheduleResource(t *testing.T) {
+	var m *roleScheduleRequestMock
+	var firstId string
+	name := generictest.ResourceName(&PrivilegedAccessGroupEligibilityScheduleResource)
+
+	config := generictest.Config(&PrivilegedAccessGroupEligibilityScheduleResource, `
+		access_id     = "member"
+		group_id      = "group1"
+		principal_id  = "user1"
+		justification = "On-call rotation"
+		schedule_info = {
+			expiration = { type = "afterDuration", duration = "P180D" }
+		}
+	`)
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &PrivilegedAccessGroupEligibilityScheduleResource,
+		Setup: func(s *graphmock.Server) {
+			m = newRoleScheduleRequestMock(s, "/identityGovernance/privilegedAccess/group/eligibilityScheduleRequests",
+				"/identityGovernance/privilegedAccess/group/eligibilitySchedules", "accessId", "groupId", "principalId")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "status", "Provisioned"),
+					resource.TestCheckResourceAttr(name, "member_type", "direct"),
+					// the schedule only contains the resulting end, the requested duration must have been kept
+					resource.TestCheckResourceAttr(name, "schedule_info.expiration.type", "afterDuration"),
+					resource.TestCheckResourceAttr(name, "schedule_info.expiration.duration", "P180D"),
+					resource.TestCheckResourceAttr(name, "schedule_info.expiration.end_date_time", "2024-06-29T00:00:00Z"),
+					resource.TestCheckResourceAttr(name, "justification", "On-call rotation"),
+					func(s *terraform.State) error {
+						// the resource must represent the schedule, not the request
+						attributes := generictest.Attributes(s)
+						firstId = attributes["id"]
+						if m.Requests.Get(attributes["id"]) != nil || m.Requests.Get(attributes["created_using"]) == nil {
+							return fmt.Errorf("expected id %q to be the one of the schedule created using request %q", attributes["id"], attributes["created_using"])
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// an expired schedule must be requested again
+				PreConfig: func() {
+					m.Expire()
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					if generictest.Attributes(s)["id"] == firstId {
+						return fmt.Errorf("expected a new schedule to have been requested after the schedule expired")
+					}
+					return nil
+				},
+			},
+			{
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"justification", "schedule_info.expiration.duration", "schedule_info.expiration.type"},
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if n := m.ScheduleCount(); n != 0 {
+				return fmt.Errorf("schedules still exist: %d", n)
+			}
+			for _, r := range generictest.Graph().Requests() {
+				if r.Method != http.MethodPost || !strings.HasSuffix(r.Path, "/eligibilityScheduleRequests") {
+					continue
+				}
+				var body map[string]any
+				_ = json.Unmarshal(r.Body, &body)
+				if body["action"] == "adminRemove" && body["justification"] != "On-call rotation" {
+					return fmt.Errorf("expected justification to be sent when removing the schedule, got %v", body)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func TestPrivilegedAccessGroupAssignmentScheduleResource(t *testing.T) {
+	var m *roleScheduleRequestMock
+	name := generictest.ResourceName(&PrivilegedAccessGroupAssignmentScheduleResource)
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &PrivilegedAccessGroupAssignmentScheduleResource,
+		Setup: func(s *graphmock.Server) {
+			m = newRoleScheduleRequestMock(s, "/identityGovernance/privilegedAccess/group/assignmentScheduleRequests",
+				"/identityGovernance/privilegedAccess/group/assignmentSchedules", "accessId", "groupId", "principalId")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&PrivilegedAccessGroupAssignmentScheduleResource, `
+					access_id    = "owner"
+					group_id     = "group1"
+					principal_id = "user1"
+					schedule_info = {
+						start_date_time = "2025-01-01T00:00:00Z"
+						expiration      = { type = "afterDateTime", end_date_time = "2025-12-31T00:00:00Z" }
+					}
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "schedule_info.expiration.type", "afterDateTime"),
+					resource.TestCheckResourceAttr(name, "schedule_info.expiration.end_date_time", "2025-12-31T00:00:00Z"),
+				),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if n := m.ScheduleCount(); n != 0 {
+				return fmt.Errorf("schedules still exist: %d", n)
+			}
+			return nil
+		},
+	})
+}
+
+func TestPrivilegedAccessGroupScheduleResourceRequiresApproval(t *testing.T) {
+	var m *roleScheduleRequestMock
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &PrivilegedAccessGroupAssignmentScheduleResource,
+		Setup: func(s *graphmock.Server) {
+			m = newRoleScheduleRequestMock(s, "/identityGovernance/privilegedAccess/group/assignmentScheduleRequests",
+				"/identityGovernance/privilegedAccess/group/assignmentSchedules", "accessId", "groupId", "principalId")
+			m.RequireApproval = true
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&PrivilegedAccessGroupAssignmentScheduleResource, `
+					access_id    = "member"
+					group_id     = "group1"
+					principal_id = "user1"
+					schedule_info = {
+						expiration = { type = "noExpiration" }
+					}
+				`),
+				ExpectError: regexp.MustCompile(`requires approval\s+\(status 'PendingApproval'\)`),
+			},
+		},
+	})
+}
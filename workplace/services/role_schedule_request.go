@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/external/strcase"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+//
+// PIM schedule requests (e.g. unifiedRoleEligibilityScheduleRequest) are one-shot requests: Creating a request with
+// the action `adminAssign` makes MS Graph create a schedule (the actual eligibility or assignment) and removing that
+// schedule again requires yet another request with the action `adminRemove`. The original request stays around
+// unchanged, so the directory role resources (which manage the request) also check for the resulting schedule
+// (referenced by the targetScheduleId of the request) when reading and treat the request as gone once its schedule has
+// been removed or has expired. The PIM for Groups resources instead manage the resulting schedule directly, see
+// privileged_access_group_schedule.go.
+//
+
+// roleScheduleRequestTargetScheduleKey is used to pass the result of roleScheduleRequestExtraRequestCustom on to
+// roleScheduleRequestGraphToTerraformMiddleware.
+const roleScheduleRequestTargetScheduleKey = "@provider.targetSchedule"
+
+func roleScheduleRequestTerraformToGraphMiddleware(ctx context.Context, diags *diag.Diagnostics, params *generic.TerraformToGraphMiddlewareParams) generic.TerraformToGraphMiddlewareReturns {
+	if !params.IsUpdate {
+		params.RawVal["action"] = "adminAssign"
+	}
+	return nil
+}
+
+// roleScheduleRequestExtraRequestCustom returns a custom extra request that reads the schedule referenced by the
+// targetScheduleId of the request from the given schedule collection (e.g. roleEligibilitySchedules).
+func roleScheduleRequestExtraRequestCustom(scheduleBaseUri string) generic.ReadExtraRequestCustom {
+	return func(ctx context.Context, diags *diag.Diagnostics, params generic.ReadExtraRequestCustomParams) {
+		targetScheduleId, _ := params.RawVal["targetScheduleId"].(string)
+		if targetScheduleId == "" {
+			return
+		}
+
+		uri := msgraph.Uri{Entity: fmt.Sprintf("%s/%s", scheduleBaseUri, targetScheduleId)}
+		targetSchedule := generic.ReadRaw2(ctx, diags, params.Client, uri, nil, nil, true)
+		if diags.HasError() {
+			return
+		}
+		if targetSchedule == nil {
+			params.RawVal[roleScheduleRequestTargetScheduleKey] = nil // untyped nil, to be detected by the middleware
+		} else {
+			params.RawVal[roleScheduleRequestTargetScheduleKey] = targetSchedule
+		}
+	}
+}
+
+func roleScheduleRequestGraphToTerraformMiddleware(ctx context.Context, diags *diag.Diagnostics, params *generic.GraphToTerraformMiddlewareParams) generic.GraphToTerraformMiddlewareReturns {
+	targetSchedule, targetScheduleRead := params.RawVal[roleScheduleRequestTargetScheduleKey]
+	delete(params.RawVal, roleScheduleRequestTargetScheduleKey)
+
+	status, _ := params.RawVal["status"].(string)
+	scheduleRemoved := status == "Canceled" || status == "Revoked" ||
+		(status == "Provisioned" && targetScheduleRead && targetSchedule == nil)
+	if !scheduleRemoved {
+		return nil
+	}
+
+	// empty map gets translated to "not found" upstream
+	tflog.Info(ctx, fmt.Sprintf("Schedule of request '%s' (status '%s') does not exist (anymore)", params.ExpectedId, status))
+	clear(params.RawVal)
+	return nil
+}
+
+// roleScheduleRequestDeleteReplaceFunc returns a function that removes a schedule by creating another request with the
+// action `adminRemove`. The id of the schedule is taken from the given Terraform attribute and the given
+// removeAttributes get copied from state to the request. Nothing needs to be done if the schedule does not exist
+// anymore (e.g. because it has expired).
+func roleScheduleRequestDeleteReplaceFunc(requestBaseUri string, scheduleBaseUri string, scheduleIdAttribute string,
+	removeAttributes ...string) func(context.Context, *diag.Diagnostics, *generic.DeleteReplaceFuncParams) {
+	return func(ctx context.Context, diags *diag.Diagnostics, params *generic.DeleteReplaceFuncParams) {
+
+		var scheduleId types.String
+		diags.Append(params.IdAttributer.GetAttribute(ctx, path.Root(scheduleIdAttribute), &scheduleId)...)
+		if diags.HasError() {
+			return
+		}
+		if scheduleId.ValueString() != "" {
+			uri := msgraph.Uri{Entity: fmt.Sprintf("%s/%s", scheduleBaseUri, scheduleId.ValueString())}
+			if generic.ReadRaw2(ctx, diags, params.Client, uri, nil, nil, true) == nil {
+				if !diags.HasError() {
+					tflog.Info(ctx, fmt.Sprintf("Schedule '%s' does not exist (anymore), nothing to remove", scheduleId.ValueString()))
+				}
+				return
+			}
+		}
+
+		postRawVal := map[string]any{
+			"action": "adminRemove",
+		}
+		for _, a := range removeAttributes {
+			var value types.String
+			diags.Append(params.IdAttributer.GetAttribute(ctx, path.Root(a), &value)...)
+			if diags.HasError() {
+				return
+			}
+			if !value.IsNull() {
+				postRawVal[strcase.ToLowerCamel(a)] = value.ValueString()
+			}
+		}
+
+		generic.CreateRaw(ctx, diags, params.Client, msgraph.Uri{Entity: requestBaseUri}, postRawVal, nil, false, false)
+	}
+}
+
+var roleScheduleRequestCreatedDateTimeAttribute = schema.StringAttribute{
+	Computed:            true,
+	PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+	MarkdownDescription: "The request creation date time. The Timestamp type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Read-only.",
+}
+
+var roleScheduleRequestJustificationAttribute = schema.StringAttribute{
+	Optional:            true,
+	PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	MarkdownDescription: "A message provided by users and administrators when they create the request about why it is needed. Depending on the PIM policy of the role (see [unified_role_management_policy](unified_role_management_policy.md)) this might be required. Will also be used when removing the schedule.",
+}
+
+var roleScheduleRequestScheduleInfoAttribute = schema.SingleNestedAttribute{
+	Optional: true,
+	Attributes: map[string]schema.Attribute{ // requestSchedule
+		"expiration": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{ // expirationPattern
+				"duration": schema.StringAttribute{
+					Optional:            true,
+					PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+					MarkdownDescription: "The requested duration of access in ISO 8601 format, e.g. `PT8H` for eight hours or `P180D` for 180 days. Required when `type` is `afterDuration`.",
+				},
+				"end_date_time": schema.StringAttribute{
+					Optional:            true,
+					PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+					MarkdownDescription: "Timestamp of date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. Required when `type` is `afterDateTime`.",
+				},
+				"type": schema.StringAttribute{
+					Optional: true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("noExpiration", "afterDateTime", "afterDuration"),
+					},
+					PlanModifiers:       []planmodifier.String{wpdefaultvaluemodifier.StringDefaultValue("noExpiration"), stringplanmodifier.RequiresReplace()},
+					Computed:            true,
+					MarkdownDescription: "The requestor's desired expiration pattern type. <br/> _Provider_ allowed values are: `noExpiration`, `afterDateTime`, `afterDuration`. The _provider_ default value is `\"noExpiration\"`.",
+				},
+			},
+			PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty(), objectplanmodifier.RequiresReplace()},
+			Computed:            true,
+			MarkdownDescription: "When the schedule expires. Depending on the PIM policy of the role (see [unified_role_management_policy](unified_role_management_policy.md)) schedules without expiration or longer durations might not be allowed. <br/> The _provider_ default value is `{}`.",
+		},
+		"start_date_time": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace(), wpplanmodifier.StringUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "When the eligible or active assignment becomes active. The Timestamp type represents date and time information using ISO 8601 format and is always in UTC time. For example, midnight UTC on Jan 1, 2014 is `2014-01-01T00:00:00Z`. If not set, the current date and time will be used.",
+		},
+	},
+	PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty(), objectplanmodifier.RequiresReplace()},
+	Computed:            true,
+	MarkdownDescription: "The period of the schedule, i.e. when it starts and when it expires. / Also see [Microsoft docs for requestSchedule](https://learn.microsoft.com/en-us/graph/api/resources/requestschedule?view=graph-rest-beta). <br/> The _provider_ default value is `{}`.",
+}
+
+var roleScheduleRequestStatusAttribute = schema.StringAttribute{
+	Computed:            true,
+	PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+	MarkdownDescription: "The status of the request, e.g. `Provisioned`, `PendingApproval` or `Failed`. Read-only.",
+}
+
+var roleScheduleRequestTargetScheduleIdAttribute = schema.StringAttribute{
+	Computed:            true,
+	PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+	MarkdownDescription: "The identifier of the schedule object that's linked to the request. Read-only.",
+}
+
+var roleScheduleRequestTicketInfoAttribute = schema.SingleNestedAttribute{
+	Optional: true,
+	Attributes: map[string]schema.Attribute{ // ticketInfo
+		"ticket_number": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The ticket number.",
+		},
+		"ticket_system": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The description of the ticket system.",
+		},
+	},
+	PlanModifiers:       []planmodifier.Object{wpdefaultvaluemodifier.ObjectDefaultValueEmpty(), objectplanmodifier.RequiresReplace()},
+	Computed:            true,
+	MarkdownDescription: "Ticket details linked to the request including details of the ticket number and ticket system. / Also see [Microsoft docs for ticketInfo](https://learn.microsoft.com/en-us/graph/api/resources/ticketinfo?view=graph-rest-beta). <br/> The _provider_ default value is `{}`.",
+}
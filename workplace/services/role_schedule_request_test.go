@@ -0,0 +1,245 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"sync"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// roleScheduleRequestMock simulates PIM schedule requests: Requests with the action adminAssign create a schedule,
+// requests with the action adminRemove remove the schedule matching all given key attributes again. Requests get
+// processed immediately, i.e. their status is always Provisioned, unless RequireApproval is set (then requests with
+// the action adminAssign stay PendingApproval without creating a schedule).
+type roleScheduleRequestMock struct {
+	Requests        *graphmock.EntitySet
+	RequireApproval bool
+
+	mu        sync.Mutex
+	schedules map[string]map[string]any
+}
+
+func newRoleScheduleRequestMock(s *graphmock.Server, requestsPattern string, schedulesPattern string, keyAttributes ...string) *roleScheduleRequestMock {
+	m := &roleScheduleRequestMock{
+		Requests:  graphmock.NewEntitySet(),
+		schedules: map[string]map[string]any{},
+	}
+
+	s.Handle(requestsPattern, graphmock.HandlerFunc(func(w http.ResponseWriter, r *http.Request, p graphmock.Path) {
+		if r.Method != http.MethodPost || len(p.Rest) != 0 {
+			m.Requests.ServeGraph(w, r, p)
+			return
+		}
+
+		var request map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			graphmock.WriteError(w, http.StatusBadRequest, "BadRequest", err.Error())
+			return
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		request["status"] = "Provisioned"
+		switch request["action"] {
+		case "adminAssign":
+			if m.RequireApproval {
+				request["status"] = "PendingApproval"
+				break
+			}
+			request["id"] = graphmock.NewUuid()
+			schedule := maps.Clone(request)
+			delete(schedule, "action")
+			delete(schedule, "justification")
+			schedule["id"] = graphmock.NewUuid()
+			schedule["status"] = "Provisioned"
+			schedule["memberType"] = "direct"
+			schedule["createdUsing"] = request["id"]
+			schedule["createdDateTime"] = "2024-01-01T00:00:00Z"
+			// like MS Graph, schedules only contain the resulting end of requests with an expiration after a duration
+			if scheduleInfo, ok := request["scheduleInfo"].(map[string]any); ok {
+				scheduleInfo = maps.Clone(scheduleInfo)
+				if expiration, _ := scheduleInfo["expiration"].(map[string]any); expiration["type"] == "afterDuration" {
+					scheduleInfo["expiration"] = map[string]any{"type": "afterDateTime", "endDateTime": "2024-06-29T00:00:00Z", "duration": nil}
+				}
+				if scheduleInfo["startDateTime"] == nil {
+					scheduleInfo["startDateTime"] = "2024-01-01T00:00:00Z"
+				}
+				schedule["scheduleInfo"] = scheduleInfo
+			}
+			m.schedules[schedule["id"].(string)] = schedule
+			request["targetScheduleId"] = schedule["id"]
+		case "adminRemove":
+			found := false
+			for id, schedule := range m.schedules {
+				matches := true
+				for _, a := range keyAttributes {
+					matches = matches && schedule[a] == request[a]
+				}
+				if matches {
+					delete(m.schedules, id)
+					found = true
+				}
+			}
+			if !found {
+				graphmock.WriteError(w, http.StatusNotFound, "RoleAssignmentDoesNotExist", "The role assignment does not exist.")
+				return
+			}
+		default:
+			graphmock.WriteError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("Unexpected action %v", request["action"]))
+			return
+		}
+		request["createdDateTime"] = "2024-01-01T00:00:00Z"
+		if scheduleInfo, ok := request["scheduleInfo"].(map[string]any); ok && scheduleInfo["startDateTime"] == nil {
+			scheduleInfo["startDateTime"] = "2024-01-01T00:00:00Z"
+		}
+		request["id"] = m.Requests.Put(request)
+		graphmock.WriteJson(w, http.StatusCreated, request)
+	}))
+
+	s.Handle(schedulesPattern, graphmock.HandlerFunc(func(w http.ResponseWriter, r *http.Request, p graphmock.Path) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if schedule, ok := m.schedules[p.Rest[0]]; ok && len(p.Rest) == 1 && r.Method == http.MethodGet {
+			graphmock.WriteJson(w, http.StatusOK, schedule)
+			return
+		}
+		graphmock.WriteError(w, http.StatusNotFound, "ResourceNotFound", "Schedule not found.")
+	}))
+
+	return m
+}
+
+// Expire removes all schedules (as MS Graph does when they expire).
+func (m *roleScheduleRequestMock) Expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.schedules)
+}
+
+func (m *roleScheduleRequestMock) ScheduleCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.schedules)
+}
+
+// ActionCount returns how many requests with the given action have been created.
+func (m *roleScheduleRequestMock) ActionCount(action string) int {
+	count := 0
+	for _, id := range m.Requests.Ids() {
+		if m.Requests.Get(id)["action"] == action {
+			count++
+		}
+	}
+	return count
+}
+
+func TestUnifiedRoleEligibilityScheduleRequestResource(t *testing.T) {
+	var m *roleScheduleRequestMock
+	var firstId string
+	name := generictest.ResourceName(&UnifiedRoleEligibilityScheduleRequestResource)
+
+	config := generictest.Config(&UnifiedRoleEligibilityScheduleRequestResource, `
+		principal_id       = "user1"
+		role_definition_id = "fe930be7-5e62-47db-91af-98c3a49a38b1"
+		directory_scope_id = "/administrativeUnits/au1"
+		justification      = "Helpdesk for AU"
+		schedule_info = {
+			expiration = { type = "afterDuration", duration = "P180D" }
+		}
+		ticket_info = { ticket_number = "CHG0815" }
+	`)
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &UnifiedRoleEligibilityScheduleRequestResource,
+		Setup: func(s *graphmock.Server) {
+			m = newRoleScheduleRequestMock(s, "/roleManagement/directory/roleEligibilityScheduleRequests",
+				"/roleManagement/directory/roleEligibilitySchedules", "principalId", "roleDefinitionId", "directoryScopeId")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "id"),
+					resource.TestCheckResourceAttrSet(name, "target_schedule_id"),
+					resource.TestCheckResourceAttr(name, "status", "Provisioned"),
+					resource.TestCheckResourceAttr(name, "schedule_info.start_date_time", "2024-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(name, "schedule_info.expiration.duration", "P180D"),
+					func(s *terraform.State) error {
+						firstId = generictest.Attributes(s)["id"]
+						if n := m.ScheduleCount(); n != 1 {
+							return fmt.Errorf("expected one schedule, got %d", n)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// an expired schedule must be requested again
+				PreConfig: func() {
+					m.Expire()
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					if generictest.Attributes(s)["id"] == firstId {
+						return fmt.Errorf("expected a new request to have been created after the schedule expired")
+					}
+					if n := m.ScheduleCount(); n != 1 {
+						return fmt.Errorf("expected one schedule, got %d", n)
+					}
+					return nil
+				},
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if n := m.ScheduleCount(); n != 0 {
+				return fmt.Errorf("schedules still exist: %d", n)
+			}
+			if n := m.ActionCount("adminRemove"); n != 1 {
+				return fmt.Errorf("expected one adminRemove request, got %d", n)
+			}
+			return nil
+		},
+	})
+}
+
+func TestUnifiedRoleAssignmentScheduleRequestResource(t *testing.T) {
+	var m *roleScheduleRequestMock
+	name := generictest.ResourceName(&UnifiedRoleAssignmentScheduleRequestResource)
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &UnifiedRoleAssignmentScheduleRequestResource,
+		Setup: func(s *graphmock.Server) {
+			m = newRoleScheduleRequestMock(s, "/roleManagement/directory/roleAssignmentScheduleRequests",
+				"/roleManagement/directory/roleAssignmentSchedules", "principalId", "roleDefinitionId", "directoryScopeId")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&UnifiedRoleAssignmentScheduleRequestResource, `
+					principal_id       = "user1"
+					role_definition_id = "fe930be7-5e62-47db-91af-98c3a49a38b1"
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "directory_scope_id", "/"),
+					resource.TestCheckResourceAttr(name, "schedule_info.expiration.type", "noExpiration"),
+				),
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if n := m.ScheduleCount(); n != 0 {
+				return fmt.Errorf("schedules still exist: %d", n)
+			}
+			return nil
+		},
+	})
+}
@@ -1,15 +1,62 @@
 package services
 
 import (
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 var (
-	servicePrincipalResource = generic.GenericResource{
+	ServicePrincipalResource = generic.GenericResource{
 		TypeNameSuffix: "service_principal",
 		SpecificSchema: servicePrincipalResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/servicePrincipals",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ReadOptions: generic.ReadOptions{
+				ExtraRequests: []generic.ReadExtraRequest{
+					{
+						Attribute: "owners",
+					},
+				},
+				DataSource: generic.DataSourceOptions{
+					ExtraFilterAttributes: []string{"account_enabled", "app_id", "service_principal_type"},
+					Plural: generic.PluralOptions{
+						ExtraAttributes: []string{"account_enabled", "app_id", "service_principal_type"},
+					},
+				},
+			},
+			WriteOptions: generic.WriteOptions{
+				SubActions: []generic.WriteSubAction{
+					&generic.WriteSubActionIndividual{
+						WriteSubActionBase: generic.WriteSubActionBase{
+							Attributes: []string{"owners"},
+							UriSuffix:  "owners",
+						},
+						ComparisonKeyAttribute: "id",
+						SetNestedPath:          tftypes.NewAttributePath().WithAttributeName("owners"),
+						IsOdataReference:       true,
+						OdataRefMapTypeToUriPrefix: map[string]string{
+							"": "https://graph.microsoft.com/beta/directoryObjects/", // this will work for users and service principals
+						},
+					},
+				},
+			},
+		},
+	}
+
+	servicePrincipalDataSourceResource = generic.GenericResource{
+		TypeNameSuffix: "service_principal",
+		SpecificSchema: servicePrincipalDataSourceSchema,
 		AccessParams: generic.AccessParams{
 			BaseUri: "/servicePrincipals",
 			ReadOptions: generic.ReadOptions{
@@ -24,13 +71,103 @@ var (
 	}
 
 	ServicePrincipalSingularDataSource = generic.CreateGenericDataSourceSingularFromResource(
-		&servicePrincipalResource)
+		&servicePrincipalDataSourceResource)
 
 	ServicePrincipalPluralDataSource = generic.CreateGenericDataSourcePluralFromResource(
-		&servicePrincipalResource, "")
+		&servicePrincipalDataSourceResource, "")
 )
 
 var servicePrincipalResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // servicePrincipal
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the service principal. Key. Not nullable. Read-only. Supports `$filter` (`eq`, `ne`, `not`, `in`).",
+		},
+		"account_enabled": schema.BoolAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.Bool{wpdefaultvaluemodifier.BoolDefaultValue(true)},
+			Computed:            true,
+			MarkdownDescription: "`true` if the service principal account is enabled; otherwise, `false`. If set to `false`, then no users are able to sign in to this app, even if they're assigned to it. Supports `$filter` (`eq`, `ne`, `not`, `in`). <br/> The _provider_ default value is `true`.",
+		},
+		"app_display_name": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The display name exposed by the associated application.",
+		},
+		"app_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The unique identifier for the associated application (its **appId** property). Alternate key. Supports `$filter` (`eq`, `ne`, `not`, `in`, `startsWith`).",
+		},
+		"app_owner_organization_id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "Contains the tenant ID where the application is registered. This is applicable only to service principals backed by applications. Supports `$filter` (`eq`, `ne`, `NOT`, `ge`, `le`).",
+		},
+		"app_role_assignment_required": schema.BoolAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.Bool{wpdefaultvaluemodifier.BoolDefaultValue(false)},
+			Computed:            true,
+			MarkdownDescription: "Specifies whether users or other service principals need to be granted an app role assignment for this service principal before users can sign in or apps can get tokens. The default value is `false`. Not nullable. <br/> Supports `$filter` (`eq`, `ne`, `NOT`). <br/> The _provider_ default value is `false`.",
+		},
+		"description": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Free text field to provide an internal end-user facing description of the service principal. End-user portals such [MyApps](https://learn.microsoft.com/en-us/azure/active-directory/user-help/my-apps-portal-end-user-access) displays the application description in this field. The maximum allowed size is 1,024 characters. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `startsWith`) and `$search`.",
+		},
+		"display_name": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The display name for the service principal. Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values), `$search`, and `$orderby`.",
+		},
+		"login_url": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Specifies the URL where the service provider redirects the user to Microsoft Entra ID to authenticate. Microsoft Entra ID uses the URL to launch the application from Microsoft 365 or the Microsoft Entra My Apps. When blank, Microsoft Entra ID performs IdP-initiated sign-on for applications configured with [SAML-based single sign-on](https://learn.microsoft.com/en-us/azure/active-directory/manage-apps/what-is-single-sign-on#saml-sso). The user launches the application from Microsoft 365, the Microsoft Entra My Apps, or the Microsoft Entra SSO URL.",
+		},
+		"notes": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Free text field to capture information about the service principal, typically used for operational purposes. Maximum allowed size is 1,024 characters.",
+		},
+		"notification_email_addresses": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Specifies the list of email addresses where Microsoft Entra ID sends a notification when the active certificate is near the expiration date. This is only for the certificates used to sign the SAML token issued for Microsoft Entra Gallery applications. <br/> The _provider_ default value is `[]`.",
+		},
+		"owners": schema.SetNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: groupDirectoryObjectAttributes,
+			},
+			PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "Directory objects that are owners of this servicePrincipal. The owners are a set of nonadmin users or servicePrincipals who are allowed to modify this object. <br/> The _provider_ default value is `[]`.",
+		},
+		"preferred_single_sign_on_mode": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("password", "saml", "notSupported", "oidc"),
+			},
+			MarkdownDescription: "Specifies the single sign-on mode configured for this application. Microsoft Entra ID uses the preferred single sign-on mode to launch the application from Microsoft 365 or the My Apps portal. The supported values are `password`, `saml`, `notSupported`, and `oidc`. <br/> _Provider_ allowed values are: `password`, `saml`, `notSupported`, `oidc`.",
+		},
+		"service_principal_type": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "Identifies if the service principal represents an application or a managed identity. This property is set by Microsoft Entra ID internally. <br/> - For a service principal that represents an [application](./application.md) this is set as `Application`. <br/> - For a service principal that represents a [managed identity](https://learn.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/overview) this is set as `ManagedIdentity`. <br/> - For a service principal that represents an [agent identity](https://learn.microsoft.com/en-us/graph/api/resources/agentidentity?view=graph-rest-beta), this is set to `ServiceIdentity`. <br/> - The `SocialIdp` type is for internal use.",
+		},
+		"tags": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			PlanModifiers:       []planmodifier.Set{wpplanmodifier.SetUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "Custom strings that can be used to categorize and identify the service principal. Not nullable. The value is the union of strings set here and on the associated application entity's **tags** property. <br/> Supports `$filter` (`eq`, `not`, `ge`, `le`, `startsWith`).",
+		},
+	},
+	MarkdownDescription: "Represents an instance of an application in a directory.\n\nAlso see [Microsoft docs for servicePrincipal](https://learn.microsoft.com/en-us/graph/api/resources/serviceprincipal?view=graph-rest-beta).\n\n_Provider_ Note: The service principal will be created for the application with the given `app_id`, which may also be a multi-tenant application of another tenant (e.g. to consent to it). ||| MS Graph: Entra ID",
+}
+
+var servicePrincipalDataSourceSchema = schema.Schema{
 	Attributes: map[string]schema.Attribute{ // servicePrincipal
 		"id": schema.StringAttribute{
 			MarkdownDescription: "The unique identifier for the service principal. Key. Not nullable. Read-only. Supports `$filter` (`eq`, `ne`, `not`, `in`).",
@@ -52,5 +189,5 @@ var servicePrincipalResourceSchema = schema.Schema{
 			MarkdownDescription: "Identifies if the service principal represents an application or a managed identity. This property is set by Microsoft Entra ID internally. <br/> - For a service principal that represents an [application](./application.md) this is set as `Application`. <br/> - For a service principal that represents a [managed identity](https://learn.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/overview) this is set as `ManagedIdentity`. <br/> - For a service principal that represents an [agent identity](https://learn.microsoft.com/en-us/graph/api/resources/agentidentity?view=graph-rest-beta), this is set to `ServiceIdentity`. <br/> - The `SocialIdp` type is for internal use.",
 		},
 	},
-	MarkdownDescription: "Represents an instance of an application in a directory.\n\nusing [delta query](https://learn.microsoft.com/en-us/graph/delta-query-overview) to track incremental additions, deletions, and updates, by providing a [delta](https://learn.microsoft.com/en-us/graph/api/serviceprincipal-delta?view=graph-rest-beta) function.\n\nAlso see [Microsoft docs for servicePrincipal](https://learn.microsoft.com/en-us/graph/api/resources/serviceprincipal?view=graph-rest-beta).\n\n_Provider_ Note: This data source is only provided as a companion to `azuread_service_principal` to allow for OData filtering. It is not planned to add more attributes or even provide a resource for this entity. ||| MS Graph: Entra ID",
+	MarkdownDescription: "Represents an instance of an application in a directory.\n\nusing [delta query](https://learn.microsoft.com/en-us/graph/delta-query-overview) to track incremental additions, deletions, and updates, by providing a [delta](https://learn.microsoft.com/en-us/graph/api/serviceprincipal-delta?view=graph-rest-beta) function.\n\nAlso see [Microsoft docs for servicePrincipal](https://learn.microsoft.com/en-us/graph/api/resources/serviceprincipal?view=graph-rest-beta).\n\n_Provider_ Note: This data source is only provided as a companion to `azuread_service_principal` to allow for OData filtering. It is not planned to add more attributes to it (see the `microsoft365wp_service_principal` resource instead). ||| MS Graph: Entra ID",
 }
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+var (
+	UnifiedRoleAssignmentResource = generic.GenericResource{
+		TypeNameSuffix: "unified_role_assignment",
+		SpecificSchema: unifiedRoleAssignmentResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/roleManagement/directory/roleAssignments",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ReadOptions: generic.ReadOptions{
+				DataSource: generic.DataSourceOptions{
+					ExtraFilterAttributes: []string{"directory_scope_id", "principal_id", "role_definition_id"},
+					Plural: generic.PluralOptions{
+						ExtraAttributes: []string{"app_scope_id", "directory_scope_id", "principal_id", "role_definition_id"},
+					},
+				},
+			},
+		},
+	}
+
+	UnifiedRoleAssignmentSingularDataSource = generic.CreateGenericDataSourceSingularFromResource(
+		&UnifiedRoleAssignmentResource)
+
+	UnifiedRoleAssignmentPluralDataSource = generic.CreateGenericDataSourcePluralFromResource(
+		&UnifiedRoleAssignmentResource, "")
+)
+
+var unifiedRoleAssignmentResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // unifiedRoleAssignment
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the unifiedRoleAssignment. Key, not nullable, Read-only.",
+		},
+		"app_scope_id": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Identifier of the app-specific scope when the assignment scope is app-specific. Either this property or **directoryScopeId** is required. App scopes are scopes that are defined and understood by this application only. Use `/` for tenant-wide app scopes. Use **directoryScopeId** to limit the scope to particular directory objects, for example, administrative units. Supports `$filter` (`eq`, `in`).",
+		},
+		"condition": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Conditions that control when the assignment is applicable, e.g. `@Resource[Microsoft.Directory/applications.owners] Any_of {'11111111-1111-1111-1111-111111111111'}`. Optional.",
+		},
+		"directory_scope_id": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{wpdefaultvaluemodifier.StringDefaultValue("/"), stringplanmodifier.RequiresReplace()},
+			Computed:            true,
+			MarkdownDescription: "Identifier of the directory object representing the scope of the assignment. Either this property or **appScopeId** is required. The scope of an assignment determines the set of resources for which the principal has been granted access. Directory scopes are shared scopes stored in the directory that are understood by multiple applications. Use `/` for tenant-wide scope. Use **appScopeId** to limit the scope to an application only. Supports `$filter` (`eq`, `in`). <br/> _Provider_ Note: To scope the assignment to an [administrative unit](administrative_unit.md), use `/administrativeUnits/{id}`. <br/> The _provider_ default value is `\"/\"`.",
+		},
+		"principal_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Identifier of the principal ([user](user.md), role-assignable [group](group.md) or [service principal](service_principal.md)) to which the assignment is granted. Supports `$filter` (`eq`, `in`).",
+		},
+		"role_definition_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Identifier of the [unified role definition](unified_role_definition.md) the assignment is for. Supports `$filter` (`eq`, `in`).",
+		},
+	},
+	MarkdownDescription: "A role assignment is used to grant access to resources. It represents a role definition assigned to a principal (for example, a user or a role-assignable group) at a particular scope. <br/> Also see [Microsoft docs for unifiedRoleAssignment](https://learn.microsoft.com/en-us/graph/api/resources/unifiedroleassignment?view=graph-rest-beta).\n\n_Provider_ Note: This resource manages active, permanent assignments of directory (Microsoft Entra) roles. Role assignments cannot be updated, any change will replace them. To manage eligible or time-bound assignments using Privileged Identity Management (PIM), use the [unified_role_eligibility_schedule_request](unified_role_eligibility_schedule_request.md) and [unified_role_assignment_schedule_request](unified_role_assignment_schedule_request.md) resources instead. ||| MS Graph: Role management",
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+var (
+	UnifiedRoleAssignmentScheduleRequestResource = generic.GenericResource{
+		TypeNameSuffix: "unified_role_assignment_schedule_request",
+		SpecificSchema: unifiedRoleAssignmentScheduleRequestResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/roleManagement/directory/roleAssignmentScheduleRequests",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ReadOptions: generic.ReadOptions{
+				ExtraRequestsCustom: []generic.ReadExtraRequestCustom{
+					roleScheduleRequestExtraRequestCustom("/roleManagement/directory/roleAssignmentSchedules"),
+				},
+			},
+			TerraformToGraphMiddleware: roleScheduleRequestTerraformToGraphMiddleware,
+			GraphToTerraformMiddleware: roleScheduleRequestGraphToTerraformMiddleware,
+			DeleteReplaceFunc: roleScheduleRequestDeleteReplaceFunc("/roleManagement/directory/roleAssignmentScheduleRequests",
+				"/roleManagement/directory/roleAssignmentSchedules", "target_schedule_id",
+				"principal_id", "role_definition_id", "directory_scope_id", "app_scope_id", "justification"),
+		},
+	}
+)
+
+var unifiedRoleAssignmentScheduleRequestResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // unifiedRoleAssignmentScheduleRequest
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the unifiedRoleAssignmentScheduleRequest. Key, not nullable, Read-only.",
+		},
+		"app_scope_id": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Identifier of the app-specific scope when the assignment is scoped to an app. The scope of an assignment determines the set of resources for which the principal has been granted access. App scopes are scopes that are defined and understood by this application only. Use `/` for tenant-wide app scopes. Use **directoryScopeId** to limit the scope to particular directory objects, for example, administrative units. Supports `$filter` (`eq`, `ne`, and on `null` values).",
+		},
+		"created_date_time": roleScheduleRequestCreatedDateTimeAttribute,
+		"directory_scope_id": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{wpdefaultvaluemodifier.StringDefaultValue("/"), stringplanmodifier.RequiresReplace()},
+			Computed:            true,
+			MarkdownDescription: "Identifier of the directory object representing the scope of the assignment. The scope of an assignment determines the set of resources for which the principal has been granted access. Directory scopes are shared scopes stored in the directory that are understood by multiple applications. Use `/` for tenant-wide scope. Use **appScopeId** to limit the scope to an application only. Supports `$filter` (`eq`, `ne`, and on `null` values). <br/> _Provider_ Note: To scope the assignment to an [administrative unit](administrative_unit.md), use `/administrativeUnits/{id}`. <br/> The _provider_ default value is `\"/\"`.",
+		},
+		"justification": roleScheduleRequestJustificationAttribute,
+		"principal_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Identifier of the principal ([user](user.md) or role-assignable [group](group.md)) that has been granted the assignment. Supports `$filter` (`eq`, `ne`).",
+		},
+		"role_definition_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Identifier of the [unified role definition](unified_role_definition.md) object that is being assigned to the principal. Supports `$filter` (`eq`, `ne`).",
+		},
+		"schedule_info":      roleScheduleRequestScheduleInfoAttribute,
+		"status":             roleScheduleRequestStatusAttribute,
+		"target_schedule_id": roleScheduleRequestTargetScheduleIdAttribute,
+		"ticket_info":        roleScheduleRequestTicketInfoAttribute,
+	},
+	MarkdownDescription: "Represents a request for an active role assignment for a principal through PIM. The role assignment can be permanently active with or without an expiry date, or temporarily active after a user activates an eligible assignment. <br/> Also see [Microsoft docs for unifiedRoleAssignmentScheduleRequest](https://learn.microsoft.com/en-us/graph/api/resources/unifiedroleassignmentschedulerequest?view=graph-rest-beta).\n\n_Provider_ Note: This resource creates a request with the action `adminAssign` (i.e. an administrator assigning the role, self-activation of eligible roles is not supported) and removes the resulting role assignment schedule again using another request with the action `adminRemove` when being destroyed. Requests cannot be updated, any change will replace them. If the role assignment schedule has expired or has been removed outside of Terraform, the resource is considered to be gone and will be created again. ||| MS Graph: Role management",
+}
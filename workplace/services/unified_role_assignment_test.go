@@ -0,0 +1,63 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestUnifiedRoleAssignmentResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&UnifiedRoleAssignmentResource)
+
+	config := func(attributes string) string {
+		return generictest.Config(&UnifiedRoleAssignmentResource, `
+			principal_id       = "user1"
+			role_definition_id = "fe930be7-5e62-47db-91af-98c3a49a38b1"
+		`+attributes)
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &UnifiedRoleAssignmentResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/roleManagement/directory/roleAssignments")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "id"),
+					resource.TestCheckResourceAttr(name, "directory_scope_id", "/"),
+				),
+			},
+			{
+				Config: config(`directory_scope_id = "/administrativeUnits/au1"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "directory_scope_id", "/administrativeUnits/au1"),
+					func(s *terraform.State) error {
+						// scope cannot be updated, so the assignment must have been replaced
+						if ids := es.Ids(); len(ids) != 1 || ids[0] != generictest.Attributes(s)["id"] {
+							return fmt.Errorf("expected assignment to be replaced, got %v", ids)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if ids := es.Ids(); len(ids) != 0 {
+				return fmt.Errorf("entities still exist: %v", ids)
+			}
+			return nil
+		},
+	})
+}
@@ -2,12 +2,14 @@ package services
 
 import (
 	"context"
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
 	"terraform-provider-microsoft365wp/workplace/wpschema/wpvalidator"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -20,7 +22,9 @@ var (
 		TypeNameSuffix: "unified_role_definition",
 		SpecificSchema: unifiedRoleDefinitionResourceSchema,
 		AccessParams: generic.AccessParams{
-			BaseUri: "/roleManagement/directory/roleDefinitions",
+			BaseUri:            "/roleManagement/directory/roleDefinitions",
+			ApiVersions:        []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			BetaOnlyAttributes: []path.Path{path.Root("allowed_principal_types")},
 			ReadOptions: generic.ReadOptions{
 				DataSource: generic.DataSourceOptions{
 					ExtraFilterAttributes: []string{"display_name"},
@@ -63,7 +67,7 @@ var unifiedRoleDefinitionResourceSchema = schema.Schema{
 				wpvalidator.FlagEnumValues("user", "servicePrincipal", "group", "unknownFutureValue"),
 			},
 			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
-			MarkdownDescription: "Types of principals that can be assigned the role. Read-only. This is a multi-valued enumeration that can contain up to three values as a comma-separated string. For example, `user, group`. Supports `$filter` (`eq`). <br/> _Provider_ allowed values are: `user`, `servicePrincipal`, `group`, `unknownFutureValue`.",
+			MarkdownDescription: "Types of principals that can be assigned the role. Read-only. This is a multi-valued enumeration that can contain up to three values as a comma-separated string. For example, `user, group`. Supports `$filter` (`eq`). <br/> _Provider_ allowed values are: `user`, `servicePrincipal`, `group`, `unknownFutureValue`. <br/> _Provider_ Note: Only available in the MS Graph `beta` API.",
 		},
 		"description": schema.StringAttribute{
 			Optional:            true,
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestUnifiedRoleDefinitionResourceApiVersion(t *testing.T) {
+	var requestsBefore int
+	name := generictest.ResourceName(&UnifiedRoleDefinitionResource)
+
+	// the refresh before planning still uses the previous API version
+	preApply := resource.ConfigPlanChecks{
+		PreApply: []plancheck.PlanCheck{
+			generictest.PreApply(func(s *graphmock.Server) {
+				requestsBefore = len(s.Requests())
+			}),
+		},
+	}
+	// all requests of the step must have used the API version and beta only attributes must not have been sent
+	checkRequests := func(apiVersion string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			for _, r := range generictest.Graph().Requests()[requestsBefore:] {
+				if r.ApiVersion != apiVersion {
+					return fmt.Errorf("%s %s has been sent using API version %q instead of %q", r.Method, r.Path, r.ApiVersion, apiVersion)
+				}
+				if apiVersion != "beta" && strings.Contains(string(r.Body), "allowedPrincipalTypes") {
+					return fmt.Errorf("beta only attribute has been sent using API version %q: %s", apiVersion, r.Body)
+				}
+			}
+			return nil
+		}
+	}
+	config := func(displayName string, attributes string) string {
+		return generictest.Config(&UnifiedRoleDefinitionResource, fmt.Sprintf(`
+			display_name = %q
+			is_enabled   = true
+			role_permissions = [
+				{ allowed_resource_actions = ["microsoft.directory/applications/basic/update"] },
+			]
+		`, displayName)+attributes)
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &UnifiedRoleDefinitionResource,
+		Setup: func(s *graphmock.Server) {
+			es := s.AddEntitySet("/roleManagement/directory/roleDefinitions")
+			es.Defaults = map[string]any{"allowedPrincipalTypes": "user", "isBuiltIn": false, "version": "1"}
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      config("Test", `api_version = "v2.0"`),
+				ExpectError: regexp.MustCompile(`(?s)api_version.*v2\.0`),
+			},
+			{
+				ConfigPlanChecks: preApply,
+				Config:           config("Test", `api_version = "v1.0"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "api_version", "v1.0"),
+					checkRequests("v1.0"),
+				),
+			},
+			{
+				ConfigPlanChecks: preApply,
+				Config:           config("Test updated", `api_version = "v1.0"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "display_name", "Test updated"),
+					checkRequests("v1.0"),
+				),
+			},
+			{
+				ConfigPlanChecks: preApply,
+				Config:           config("Test updated", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(name, "api_version"),
+					resource.TestCheckResourceAttr(name, "allowed_principal_types", "user"),
+					checkRequests("beta"),
+				),
+			},
+		},
+	})
+}
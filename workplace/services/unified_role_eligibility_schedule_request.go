@@ -0,0 +1,71 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+var (
+	UnifiedRoleEligibilityScheduleRequestResource = generic.GenericResource{
+		TypeNameSuffix: "unified_role_eligibility_schedule_request",
+		SpecificSchema: unifiedRoleEligibilityScheduleRequestResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/roleManagement/directory/roleEligibilityScheduleRequests",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ReadOptions: generic.ReadOptions{
+				ExtraRequestsCustom: []generic.ReadExtraRequestCustom{
+					roleScheduleRequestExtraRequestCustom("/roleManagement/directory/roleEligibilitySchedules"),
+				},
+			},
+			TerraformToGraphMiddleware: roleScheduleRequestTerraformToGraphMiddleware,
+			GraphToTerraformMiddleware: roleScheduleRequestGraphToTerraformMiddleware,
+			DeleteReplaceFunc: roleScheduleRequestDeleteReplaceFunc("/roleManagement/directory/roleEligibilityScheduleRequests",
+				"/roleManagement/directory/roleEligibilitySchedules", "target_schedule_id",
+				"principal_id", "role_definition_id", "directory_scope_id", "app_scope_id", "justification"),
+		},
+	}
+)
+
+var unifiedRoleEligibilityScheduleRequestResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // unifiedRoleEligibilityScheduleRequest
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the unifiedRoleEligibilityScheduleRequest. Key, not nullable, Read-only.",
+		},
+		"app_scope_id": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Identifier of the app-specific scope when the role eligibility is scoped to an app. The scope of a role eligibility determines the set of resources for which the principal is eligible to access. App scopes are scopes that are defined and understood by this application only. Use `/` for tenant-wide app scopes. Use **directoryScopeId** to limit the scope to particular directory objects, for example, administrative units. Supports `$filter` (`eq`, `ne`, and on `null` values).",
+		},
+		"created_date_time": roleScheduleRequestCreatedDateTimeAttribute,
+		"directory_scope_id": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{wpdefaultvaluemodifier.StringDefaultValue("/"), stringplanmodifier.RequiresReplace()},
+			Computed:            true,
+			MarkdownDescription: "Identifier of the directory object representing the scope of the role eligibility. The scope of a role eligibility determines the set of resources for which the principal has been granted access. Directory scopes are shared scopes stored in the directory that are understood by multiple applications. Use `/` for tenant-wide scope. Use **appScopeId** to limit the scope to an application only. Supports `$filter` (`eq`, `ne`, and on `null` values). <br/> _Provider_ Note: To scope the role eligibility to an [administrative unit](administrative_unit.md), use `/administrativeUnits/{id}`. <br/> The _provider_ default value is `\"/\"`.",
+		},
+		"justification": roleScheduleRequestJustificationAttribute,
+		"principal_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Identifier of the principal ([user](user.md) or role-assignable [group](group.md)) that has been granted the role eligibility. Supports `$filter` (`eq`, `ne`).",
+		},
+		"role_definition_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "Identifier of the [unified role definition](unified_role_definition.md) object that is being assigned to the principal. Supports `$filter` (`eq`, `ne`).",
+		},
+		"schedule_info":      roleScheduleRequestScheduleInfoAttribute,
+		"status":             roleScheduleRequestStatusAttribute,
+		"target_schedule_id": roleScheduleRequestTargetScheduleIdAttribute,
+		"ticket_info":        roleScheduleRequestTicketInfoAttribute,
+	},
+	MarkdownDescription: "Represents a request for a role eligibility for a principal through PIM. The role eligibility can be permanently eligible without an expiry date or temporarily eligible with an expiry date. <br/> Also see [Microsoft docs for unifiedRoleEligibilityScheduleRequest](https://learn.microsoft.com/en-us/graph/api/resources/unifiedroleeligibilityschedulerequest?view=graph-rest-beta).\n\n_Provider_ Note: This resource creates a request with the action `adminAssign` and removes the resulting role eligibility schedule again using another request with the action `adminRemove` when being destroyed. Requests cannot be updated, any change will replace them. If the role eligibility schedule has expired or has been removed outside of Terraform, the resource is considered to be gone and will be created again. ||| MS Graph: Role management",
+}
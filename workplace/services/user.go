@@ -1,16 +1,53 @@
 package services
 
 import (
+	"context"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
 	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpplanmodifier"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	userResource = generic.GenericResource{
+	UserResource = generic.GenericResource{
 		TypeNameSuffix: "user",
 		SpecificSchema: userResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:     "/users",
+			ApiVersions: []msgraph.ApiVersion{msgraph.VersionBeta, msgraph.Version10},
+			ReadOptions: generic.ReadOptions{
+				// most attributes only get returned when being selected explicitly
+				ODataSelect: []string{"id", "accountEnabled", "assignedLicenses", "businessPhones", "city", "companyName",
+					"country", "createdDateTime", "department", "displayName", "employeeId", "givenName", "jobTitle", "mail",
+					"mailNickname", "mobilePhone", "officeLocation", "onPremisesSyncEnabled", "passwordPolicies",
+					"postalCode", "preferredLanguage", "state", "streetAddress", "surname", "usageLocation",
+					"userPrincipalName", "userType"},
+				ExtraRequestsCustom: []generic.ReadExtraRequestCustom{
+					userExtraRequestCustomPasswordProfile,
+				},
+				DataSource: generic.DataSourceOptions{
+					ExtraFilterAttributes: []string{"mail", "user_principal_name", "user_type"},
+					Plural: generic.PluralOptions{
+						ExtraAttributes: []string{"account_enabled", "assigned_licenses", "mail", "user_principal_name", "user_type"},
+					},
+				},
+			},
+			TerraformToGraphMiddleware: userTerraformToGraphMiddleware,
+		},
+	}
+
+	userDataSourceResource = generic.GenericResource{
+		TypeNameSuffix: "user",
+		SpecificSchema: userDataSourceSchema,
 		AccessParams: generic.AccessParams{
 			BaseUri: "/users",
 			ReadOptions: generic.ReadOptions{
@@ -25,13 +62,219 @@ var (
 	}
 
 	UserSingularDataSource = generic.CreateGenericDataSourceSingularFromResource(
-		&userResource)
+		&userDataSourceResource)
 
 	UserPluralDataSource = generic.CreateGenericDataSourcePluralFromResource(
-		&userResource, "")
+		&userDataSourceResource, "")
 )
 
+func userTerraformToGraphMiddleware(ctx context.Context, diags *diag.Diagnostics, params *generic.TerraformToGraphMiddlewareParams) generic.TerraformToGraphMiddlewareReturns {
+	// MS Graph resets the password (and forces the user to change it again if requested) whenever a password profile
+	// gets written, so only do this on create and whenever the version of the write-only password has been changed
+	if params.IsUpdate && params.State != nil {
+		var configVersion, stateVersion types.Int64
+		diags.Append(params.Config.GetAttribute(ctx, path.Root("password_wo_version"), &configVersion)...)
+		diags.Append(params.State.GetAttribute(ctx, path.Root("password_wo_version"), &stateVersion)...)
+		if diags.HasError() {
+			return nil
+		}
+		if configVersion.Equal(stateVersion) {
+			delete(params.RawVal, "passwordProfile")
+		}
+	}
+	return nil
+}
+
+// userExtraRequestCustomPasswordProfile keeps the password profile from the state as it never gets returned by MS Graph.
+func userExtraRequestCustomPasswordProfile(ctx context.Context, diags *diag.Diagnostics, params generic.ReadExtraRequestCustomParams) {
+	if params.ReqState == nil || params.ReqState.Raw.IsNull() {
+		return
+	}
+
+	var passwordProfile types.Object
+	diags.Append(params.ReqState.GetAttribute(ctx, path.Root("password_profile"), &passwordProfile)...)
+	if diags.HasError() || passwordProfile.IsNull() {
+		return
+	}
+
+	var forceChange, forceChangeWithMfa types.Bool
+	diags.Append(params.ReqState.GetAttribute(ctx, path.Root("password_profile").AtName("force_change_password_next_sign_in"), &forceChange)...)
+	diags.Append(params.ReqState.GetAttribute(ctx, path.Root("password_profile").AtName("force_change_password_next_sign_in_with_mfa"), &forceChangeWithMfa)...)
+	if diags.HasError() {
+		return
+	}
+	passwordProfileRaw := map[string]any{}
+	if !forceChange.IsNull() {
+		passwordProfileRaw["forceChangePasswordNextSignIn"] = forceChange.ValueBool()
+	}
+	if !forceChangeWithMfa.IsNull() {
+		passwordProfileRaw["forceChangePasswordNextSignInWithMfa"] = forceChangeWithMfa.ValueBool()
+	}
+	params.RawVal["passwordProfile"] = passwordProfileRaw
+}
+
 var userResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // user
+		"id": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The unique identifier for the user. Should be treated as an opaque identifier. Inherited from [directoryObject](directoryobject.md). Not nullable. Read-only. <br/> Supports `$filter` (`eq`, `ne`, `not`, `in`).",
+		},
+		"account_enabled": schema.BoolAttribute{
+			Required:            true,
+			MarkdownDescription: "`true` if the account is enabled; otherwise, `false`. This property is required when a user is created. <br/> Supports `$filter` (`eq`, `ne`, `not`, and `in`).",
+		},
+		"assigned_licenses": schema.SetNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{ // assignedLicense
+					"disabled_plans": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Computed:            true,
+						MarkdownDescription: "A collection of the unique identifiers for plans that have been disabled. IDs are available in **servicePlans** > **servicePlanId** in the tenant's [subscribedSkus](https://learn.microsoft.com/en-us/graph/api/resources/subscribedsku?view=graph-rest-beta) or **serviceStatus** > **servicePlanId** in the tenant's [companySubscription](https://learn.microsoft.com/en-us/graph/api/resources/subscribedsku?view=graph-rest-beta).",
+					},
+					"sku_id": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The unique identifier for the SKU. Corresponds to the **skuId** from [subscribedSkus](https://learn.microsoft.com/en-us/graph/api/resources/subscribedsku?view=graph-rest-beta) or [companySubscription](https://learn.microsoft.com/en-us/graph/api/resources/companysubscription?view=graph-rest-beta).",
+					},
+				},
+			},
+			PlanModifiers:       []planmodifier.Set{wpplanmodifier.SetUseStateForUnknown()},
+			MarkdownDescription: "The licenses that are assigned to the user, including inherited (group-based) licenses. Not nullable. Read-only. <br/> Supports `$filter` (`eq`, `not`, `/$count eq 0`, `/$count ne 0`). <br/> Represents a license assigned to a user or group. The **assignedLicenses** property of the [user](user.md) or [group](group.md) entitity is a collection of **assignedLicense** objects. Also see [Microsoft docs for assignedLicense](https://learn.microsoft.com/en-us/graph/api/resources/assignedlicense?view=graph-rest-beta). <br>  \n_Provider_ Note: Use `microsoft365wp_user_assigned_license` to assign licenses to the user. <br> ",
+		},
+		"business_phones": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			PlanModifiers:       []planmodifier.Set{wpdefaultvaluemodifier.SetDefaultValueEmpty()},
+			Computed:            true,
+			MarkdownDescription: "The telephone numbers for the user. Only one number can be set for this property. <br/> Supports `$filter` (`eq`, `not`, `ge`, `le`, `startsWith`). <br/> The _provider_ default value is `[]`.",
+		},
+		"city": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The city where the user is located. Maximum length is 128 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"company_name": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The name of the company that the user is associated with. This property can be useful for describing the company that a guest comes from. The maximum length is 64 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"country": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The country or region where the user is located; for example, `US` or `UK`. Maximum length is 128 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"created_date_time": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			MarkdownDescription: "The date and time the user was created, in ISO 8601 format and UTC. The value can't be modified and is automatically populated when the entity is created. Nullable. For on-premises users, the value represents when they were first created in Microsoft Entra ID. Property is `null` for some users created before June 2018 and on-premises users that were synced to Microsoft Entra ID before June 2018. Read-only. <br/> Supports `$filter` (`eq`, `ne`, `not` , `ge`, `le`, `in`).",
+		},
+		"department": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The name of the department in which the user works. Maximum length is 64 characters. <br/> Supports `$filter` (`eq`, `ne`, `not` , `ge`, `le`, `in`, and `eq` on `null` values).",
+		},
+		"display_name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The name displayed in the address book for the user. This value is usually the combination of the user's first name, middle initial, and family name. This property is required when a user is created and it can't be cleared during updates. Maximum length is 256 characters. <br/> Supports `$filter` (`eq`, `ne`, `not` , `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values), `$orderby`, and `$search`.",
+		},
+		"employee_id": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The employee identifier assigned to the user by the organization. The maximum length is 16 characters. <br/> Supports `$filter` (`eq`, `ne`, `not` , `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"given_name": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The given name (first name) of the user. Maximum length is 64 characters. <br/> Supports `$filter` (`eq`, `ne`, `not` , `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"job_title": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The user's job title. Maximum length is 128 characters. <br/> Supports `$filter` (`eq`, `ne`, `not` , `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"mail": schema.StringAttribute{
+			Optional:            true,
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "The SMTP address for the user, for example, `jeff@contoso.com`. Changes to this property update the user's **proxyAddresses** collection to include the value as an SMTP address. This property can't contain accent characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, `endsWith`, and `eq` on `null` values).",
+		},
+		"mail_nickname": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The mail alias for the user. This property must be specified when a user is created. Maximum length is 64 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"mobile_phone": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The primary cellular telephone number for the user. Maximum length is 64 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `startsWith`, and `eq` on `null` values).",
+		},
+		"office_location": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The office location in the user's place of business. Maximum length is 128 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"on_premises_sync_enabled": schema.BoolAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.Bool{wpplanmodifier.BoolUseStateForUnknown()},
+			MarkdownDescription: "`true` if this object is synced from an on-premises directory; `false` if this object was originally synced from an on-premises directory but is no longer synced; `null` if this object has never been synced from an on-premises directory (default). Read-only. <br/> Supports `$filter` (`eq`, `ne`, `not`, `in`, and `eq` on `null` values).",
+		},
+		"password_policies": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Specifies password policies for the user. This value is an enumeration with one possible value being `DisableStrongPassword`, which allows weaker passwords than the default policy to be specified. `DisablePasswordExpiration` can also be specified. The two might be specified together; for example: `DisablePasswordExpiration, DisableStrongPassword`. For more information on the default password policies, see [Microsoft Entra password policies](https://learn.microsoft.com/en-us/entra/identity/authentication/concept-sspr-policy#password-policies-that-only-apply-to-cloud-user-accounts). <br/> Supports `$filter` (`ne`, `not`, and `eq` on `null` values).",
+		},
+		"password_profile": schema.SingleNestedAttribute{
+			Required: true,
+			Attributes: map[string]schema.Attribute{ // passwordProfile
+				"force_change_password_next_sign_in": schema.BoolAttribute{
+					Optional:            true,
+					MarkdownDescription: "`true` if the user must change their password on the next sign-in; otherwise `false`. If not set, default is `false`.",
+				},
+				"force_change_password_next_sign_in_with_mfa": schema.BoolAttribute{
+					Optional:            true,
+					MarkdownDescription: "If `true`, at next sign-in, the user must perform a multifactor authentication (MFA) before being forced to change their password. The behavior is identical to **forceChangePasswordNextSignIn** except that the user is required to first perform a multifactor authentication before password change. After a password change, this property will be automatically reset to `false`. If not set, default is `false`.",
+				},
+				"password_wo": schema.StringAttribute{
+					Optional:            true,
+					WriteOnly:           true,
+					Sensitive:           true,
+					Description:         "password",
+					MarkdownDescription: "The password for the user. This property is required when a user is created. It can be updated, but the user will be required to change the password on the next sign-in. The password must satisfy minimum requirements as specified by the user's **passwordPolicies** property. By default, a strong password is required. <br/> _Provider_ Note: This value will never be persisted to Terraform state (requires Terraform 1.11 or later).",
+				},
+			},
+			MarkdownDescription: "Specifies the password profile for the user. The profile contains the user's password. This property is required when a user is created. The password in the profile must satisfy minimum requirements as specified by the **passwordPolicies** property. By default, a strong password is required. / Also see [Microsoft docs for passwordProfile](https://learn.microsoft.com/en-us/graph/api/resources/passwordprofile?view=graph-rest-beta). <br>  \n_Provider_ Note: As MS Graph never returns the password profile, it only gets written when creating the user and when `password_wo_version` has been changed (which then resets the password). <br> ",
+		},
+		"password_wo_version": generic.WriteOnlyVersionAttribute("password_profile.password_wo"),
+		"postal_code": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The postal code for the user's postal address. The postal code is specific to the user's country or region. In the United States of America, this attribute contains the ZIP code. Maximum length is 40 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"preferred_language": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The preferred language for the user. The preferred language format is based on RFC 4646. The name is a combination of an ISO 639 two-letter lowercase culture code associated with the language, and an ISO 3166 two-letter uppercase subculture code associated with the country or region. Example: `en-US`, or `es-ES`. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values)",
+		},
+		"state": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The state or province in the user's address. Maximum length is 128 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"street_address": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The street address of the user's place of business. Maximum length is 1,024 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"surname": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The user's surname (family name or last name). Maximum length is 64 characters. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"usage_location": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "A two-letter country code (ISO standard 3166). Required for users that are assigned licenses due to legal requirements to check for availability of services in countries. Examples include: `US`, `JP`, and `GB`. Not nullable. <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, and `eq` on `null` values).",
+		},
+		"user_principal_name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The user principal name (UPN) of the user. The UPN is an Internet-style sign-in name for the user based on the Internet standard RFC 822. By convention, this value should map to the user's email name. The general format is alias@domain, where the domain must be present in the tenant's verified domain collection. This property is required when a user is created. The verified domains for the tenant can be accessed from the **verifiedDomains** property of [organization](organization.md). <br/> NOTE: This property can't contain accent characters. Only the following characters are allowed `A - Z`, `a - z`, `0 - 9`, ` ' . - _ ! # ^ ~`. For the complete list of allowed characters, see [username policies](https://learn.microsoft.com/en-us/entra/identity/authentication/concept-sspr-policy#userprincipalname-policies-that-apply-to-all-user-accounts). <br/> Supports `$filter` (`eq`, `ne`, `not`, `ge`, `le`, `in`, `startsWith`, `endsWith`) and `$orderby`.",
+		},
+		"user_type": schema.StringAttribute{
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.OneOf("Member", "Guest")},
+			PlanModifiers:       []planmodifier.String{wpplanmodifier.StringUseStateForUnknown()},
+			Computed:            true,
+			MarkdownDescription: "A string value that can be used to classify user types in your directory. The possible values are `Member` and `Guest`. <br/> Supports `$filter` (`eq`, `ne`, `not`, `in`, and `eq` on `null` values). <br/> _Provider_ allowed values are: `Member`, `Guest`.",
+		},
+	},
+	MarkdownDescription: "Represents a Microsoft Entra user account. <br/> Also see [Microsoft docs for user](https://learn.microsoft.com/en-us/graph/api/resources/user?view=graph-rest-beta).\n\n_Provider_ Note: This resource is meant to manage cloud-only accounts. Accounts synced from an on-premises directory (see `on_premises_sync_enabled`) cannot be modified using MS Graph for most attributes. ||| MS Graph: Entra ID",
+}
+
+var userDataSourceSchema = schema.Schema{
 	Attributes: map[string]schema.Attribute{ // user
 		"id": schema.StringAttribute{
 			MarkdownDescription: "The user identifier.",
@@ -55,5 +298,5 @@ var userResourceSchema = schema.Schema{
 			Optional: true,
 		},
 	},
-	MarkdownDescription: "Represents an Azure Active Directory user object. <br/> Also see [Microsoft docs for user](https://learn.microsoft.com/en-us/graph/api/resources/intune-mam-user?view=graph-rest-beta).\n\n_Provider_ Note: This data source is only provided as a companion to `azuread_user` to allow for OData filtering. It is not planned to add more attributes or even provide a resource for this entity. ||| MS Graph: Entra ID",
+	MarkdownDescription: "Represents an Azure Active Directory user object. <br/> Also see [Microsoft docs for user](https://learn.microsoft.com/en-us/graph/api/resources/intune-mam-user?view=graph-rest-beta).\n\n_Provider_ Note: This data source is only provided as a companion to `azuread_user` to allow for OData filtering. It is not planned to add more attributes to it (see the `microsoft365wp_user` resource instead). ||| MS Graph: Entra ID",
 }
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/generic"
+	"terraform-provider-microsoft365wp/workplace/wpschema/wpdefaultvaluemodifier"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	UserAssignedLicenseResource = generic.GenericResource{
+		TypeNameSuffix: "user_assigned_license",
+		SpecificSchema: userAssignedLicenseResourceSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri: "/users",
+			ParentEntities: generic.ParentEntities{
+				{
+					ParentIdField: path.Root("user_id"),
+				},
+			},
+			UriNoId: true,
+			EntityId: generic.EntityIdOptions{
+				AttrNameGraph: "skuId",
+			},
+			ReadOptions: generic.ReadOptions{
+				ODataSelect: []string{"assignedLicenses"},
+				DataSource: generic.DataSourceOptions{
+					NoFilterSupport: true,
+				},
+			},
+			WriteOptions: generic.WriteOptions{
+				SerializeWrites:   true,
+				SerialWritesDelay: time.Second * 3,
+			},
+			GraphToTerraformMiddleware:                     assignedLicenseGraphToTerraformMiddleware,
+			GraphToTerraformMiddlewareTargetSetRunOnRawVal: true,
+			CreateReplaceFunc:                              assignedLicenseCreateReplaceFunc,
+			DeleteReplaceFunc:                              assignedLicenseDeleteReplaceFunc,
+		},
+	}
+
+	UserAssignedLicenseSingularDataSource = generic.CreateGenericDataSourceSingularFromResource(
+		&UserAssignedLicenseResource)
+
+	UserAssignedLicensePluralDataSource = generic.CreateGenericDataSourcePluralFromResource(
+		&UserAssignedLicenseResource, "")
+)
+
+var userAssignedLicenseResourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{ // assignedLicense
+		"user_id": schema.StringAttribute{
+			Required:      true,
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+		},
+		"disabled_plans": schema.SetAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			PlanModifiers: []planmodifier.Set{
+				wpdefaultvaluemodifier.SetDefaultValueEmpty(),
+				setplanmodifier.RequiresReplace(),
+			},
+			Computed:            true,
+			MarkdownDescription: "A collection of the unique identifiers for plans that have been disabled. IDs are available in **servicePlans** > **servicePlanId** in the tenant's [subscribedSkus](https://learn.microsoft.com/en-us/graph/api/resources/subscribedsku?view=graph-rest-beta) or **serviceStatus** > **servicePlanId** in the tenant's [companySubscription](https://learn.microsoft.com/en-us/graph/api/resources/subscribedsku?view=graph-rest-beta). <br/> The _provider_ default value is `[]`.",
+		},
+		"sku_id": schema.StringAttribute{
+			Required:            true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			MarkdownDescription: "The unique identifier for the SKU. Corresponds to the **skuId** from [subscribedSkus](https://learn.microsoft.com/en-us/graph/api/resources/subscribedsku?view=graph-rest-beta) or [companySubscription](https://learn.microsoft.com/en-us/graph/api/resources/companysubscription?view=graph-rest-beta).",
+		},
+	},
+	MarkdownDescription: "Represents a license assigned to a user or group. The **assignedLicenses** property of the [user](user.md) or [group](group.md) entitity is a collection of **assignedLicense** objects. <br/> Also see [Microsoft docs for assignedLicense](https://learn.microsoft.com/en-us/graph/api/resources/assignedlicense?view=graph-rest-beta).\n\n_Provider_ Note: To import this resource, an ID consisting of `user_id` and `sku_id` being joined by a forward slash (`/`) must be used. Please note that licenses can only be assigned to users with a `usage_location`. ||| MS Graph: Licenses and subscriptions",
+}
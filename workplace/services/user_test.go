@@ -0,0 +1,180 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/generic/generictest"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestUserResourcePasswordProfile(t *testing.T) {
+	var es *graphmock.EntitySet
+	var requestsBefore int
+	name := generictest.ResourceName(&UserResource)
+
+	// returns the password profiles that have been written to MS Graph since the last step
+	writtenPasswordProfiles := func() []any {
+		result := []any{}
+		for _, r := range generictest.Graph().Requests()[requestsBefore:] {
+			if r.Method != http.MethodPost && r.Method != http.MethodPatch {
+				continue
+			}
+			var body map[string]any
+			_ = json.Unmarshal(r.Body, &body)
+			if passwordProfile, ok := body["passwordProfile"]; ok {
+				result = append(result, passwordProfile)
+			}
+		}
+		return result
+	}
+	rememberRequests := func() {
+		requestsBefore = len(generictest.Graph().Requests())
+	}
+
+	config := func(displayName string, password string, version int) string {
+		return generictest.Config(&UserResource, fmt.Sprintf(`
+			account_enabled     = true
+			display_name        = %q
+			mail_nickname       = "jdoe"
+			user_principal_name = "jdoe@contoso.com"
+			usage_location      = "CH"
+			password_profile = {
+				force_change_password_next_sign_in = true
+				password_wo                        = %q
+			}
+			password_wo_version = %d
+		`, displayName, password, version))
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &UserResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/users")
+			es.Defaults = map[string]any{"createdDateTime": "2024-01-01T00:00:00Z", "assignedLicenses": []any{},
+				"userType": "Member", "mail": "jdoe@contoso.com"}
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: rememberRequests,
+				Config:    config("John Doe", "s3cr3t!", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "id"),
+					resource.TestCheckResourceAttr(name, "user_type", "Member"),
+					resource.TestCheckResourceAttr(name, "password_profile.force_change_password_next_sign_in", "true"),
+					resource.TestCheckNoResourceAttr(name, "password_profile.password_wo"),
+					func(s *terraform.State) error {
+						if p := writtenPasswordProfiles(); len(p) != 1 || p[0].(map[string]any)["password"] != "s3cr3t!" {
+							return fmt.Errorf("expected password to be sent to MS Graph on create, got %v", p)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// MS Graph would reset the password if the password profile gets sent again
+				PreConfig: rememberRequests,
+				Config:    config("John Doe updated", "s3cr3t!", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "display_name", "John Doe updated"),
+					resource.TestCheckResourceAttr(name, "password_profile.force_change_password_next_sign_in", "true"),
+					func(s *terraform.State) error {
+						if p := writtenPasswordProfiles(); len(p) != 0 {
+							return fmt.Errorf("expected password profile not to be sent to MS Graph, got %v", p)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: rememberRequests,
+				Config:    config("John Doe updated", "r0tated!", 2),
+				Check: func(s *terraform.State) error {
+					if p := writtenPasswordProfiles(); len(p) != 1 || p[0].(map[string]any)["password"] != "r0tated!" {
+						return fmt.Errorf("expected rotated password to be sent to MS Graph, got %v", p)
+					}
+					return nil
+				},
+			},
+			{
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password_profile", "password_wo_version"},
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if ids := es.Ids(); len(ids) != 0 {
+				return fmt.Errorf("entities still exist: %v", ids)
+			}
+			return nil
+		},
+	})
+}
+
+func TestUserAssignedLicenseResource(t *testing.T) {
+	var es *graphmock.EntitySet
+	name := generictest.ResourceName(&UserAssignedLicenseResource)
+	const userId = "user1"
+
+	skuIds := func() []string {
+		result := []string{}
+		for _, l := range es.Get(userId)["assignedLicenses"].([]any) {
+			result = append(result, l.(map[string]any)["skuId"].(string))
+		}
+		return result
+	}
+
+	generictest.Test(t, generictest.TestCase{
+		Resource: &UserAssignedLicenseResource,
+		Setup: func(s *graphmock.Server) {
+			es = s.AddEntitySet("/users")
+			es.Actions = map[string]graphmock.ActionFunc{
+				"assignLicense": func(e *graphmock.EntitySet, id string, _ *http.Request, body map[string]any) (int, any) {
+					user := e.Get(id)
+					addLicenses, _ := body["addLicenses"].([]any)
+					removeLicenses, _ := body["removeLicenses"].([]any)
+					assignedLicenses := []any{}
+					for _, l := range user["assignedLicenses"].([]any) {
+						if !slices.Contains(removeLicenses, l.(map[string]any)["skuId"]) {
+							assignedLicenses = append(assignedLicenses, l)
+						}
+					}
+					user["assignedLicenses"] = append(assignedLicenses, addLicenses...)
+					e.Put(user)
+					return http.StatusOK, user
+				},
+			}
+			es.Put(map[string]any{"id": userId, "displayName": "John Doe", "usageLocation": "CH", "assignedLicenses": []any{}})
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: generictest.Config(&UserAssignedLicenseResource, fmt.Sprintf(`
+					user_id = %q
+					sku_id  = "sku1"
+				`, userId)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "sku_id", "sku1"),
+					resource.TestCheckResourceAttr(name, "disabled_plans.#", "0"),
+					func(s *terraform.State) error {
+						if ids := fmt.Sprint(skuIds()); ids != "[sku1]" {
+							return fmt.Errorf("expected sku1 to be assigned in MS Graph, got %s", ids)
+						}
+						return nil
+					},
+				),
+			},
+		},
+		CheckDestroy: func(*graphmock.Server) error {
+			if ids := skuIds(); len(ids) != 0 {
+				return fmt.Errorf("licenses still assigned: %v", ids)
+			}
+			return nil
+		},
+	})
+}
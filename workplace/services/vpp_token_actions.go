@@ -0,0 +1,29 @@
+package services
+
+import (
+	"terraform-provider-microsoft365wp/workplace/generic"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+var (
+	VppTokenSyncLicensesAction = generic.GenericAction{
+		TypeNameSuffix: "vpp_token_sync_licenses",
+		SpecificSchema: vppTokenSyncLicensesActionSchema,
+		AccessParams: generic.AccessParams{
+			BaseUri:   "/deviceAppManagement/vppTokens",
+			UriSuffix: "syncLicenses",
+		},
+	}
+)
+
+var vppTokenSyncLicensesActionSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Required:            true,
+			Description:         generic.TerraformOnlyAttribute,
+			MarkdownDescription: "The id of the Apple Volume Purchase Program token.",
+		},
+	},
+	MarkdownDescription: "Restarts the synchronization of the apps and licenses of an Apple Volume Purchase Program token (MS Graph action `syncLicenses`).",
+}
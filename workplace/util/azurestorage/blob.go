@@ -27,7 +27,9 @@ type blockBlobBlockList struct {
 	BlockId []string `xml:"Latest"`
 }
 
-func UploadBlobInBlocks(ctx context.Context, fileName string, sasUri string) (fileMd5Sum string, err error) {
+// UploadBlobInBlocks uploads the file to the blob addressed by sasUri. transport (e.g. configured with a proxy) is used
+// for the requests unless it is nil.
+func UploadBlobInBlocks(ctx context.Context, transport http.RoundTripper, fileName string, sasUri string) (fileMd5Sum string, err error) {
 
 	const kBlobBlockSize = 1024 * 1024 * 4 // 4 MB
 
@@ -50,6 +52,9 @@ func UploadBlobInBlocks(ctx context.Context, fileName string, sasUri string) (fi
 	tflog.Trace(ctx, kLogPref+fmt.Sprintf("file md5 sum: %x", fileMd5SumRaw))
 
 	client := retryablehttp.NewClient()
+	if transport != nil {
+		client.HTTPClient.Transport = transport
+	}
 	retryablehttputil.ConfigureClientRetryLimitsAndBackoff(client)
 	client.CheckRetry = retryablehttp.ErrorPropagatedRetryPolicy
 	client.ErrorHandler = retryablehttp.PassthroughErrorHandler // return http error and response body
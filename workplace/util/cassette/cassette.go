@@ -0,0 +1,75 @@
+// Package cassette records MS Graph requests and responses to a file and replays them later on, so that issues can be
+// reproduced offline and without credentials.
+//
+// A cassette is a JSON lines file with one Interaction per line. Multiple provider processes (e.g. of a single
+// `terraform apply`) append to the same file when recording. Secrets are redacted before an interaction is
+// written. As a side effect, JSON bodies will be normalized, which makes matching the requests independent of
+// attribute order and formatting.
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"terraform-provider-microsoft365wp/workplace/util/redact"
+)
+
+// Interaction is a single request/response pair as stored within a cassette.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	Url    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// key identifies a request when matching it against the recorded interactions. The host is not part of the key, so
+// the cassette can be replayed regardless of the configured environment.
+func (r Request) key() string {
+	return fmt.Sprintf("%s %s\n%s", r.Method, r.Url, r.Body)
+}
+
+type requestBodyKey struct{}
+
+// newRequest converts the HTTP request into its (sanitized) cassette representation.
+func newRequest(req *http.Request, redactor *redact.Redactor) Request {
+	var body []byte
+	if b, ok := req.Context().Value(requestBodyKey{}).([]byte); ok {
+		body = b
+	}
+	return Request{
+		Method: req.Method,
+		Url:    req.URL.RequestURI(),
+		Header: redactor.Header(req.Header),
+		Body:   string(redactor.RequestBody(body)),
+	}
+}
+
+// readRequestBody reads the body of the request and stores it in the request context, as the body will have been
+// consumed by the time the response middleware is called.
+func readRequestBody(req *http.Request) (*http.Request, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %v", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return req.WithContext(context.WithValue(req.Context(), requestBodyKey{}, body)), nil
+}
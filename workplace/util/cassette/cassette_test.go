@@ -0,0 +1,177 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/util/graphmock"
+	"terraform-provider-microsoft365wp/workplace/util/redact"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	cassettePath := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	// record
+	server := graphmock.NewServer()
+	server.AddEntitySet("/applications")
+
+	recorder, err := NewRecorder(cassettePath, redact.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := msgraph.NewClient(msgraph.VersionBeta)
+	c.Endpoint = server.URL
+	c.RequestMiddlewares = &[]msgraph.RequestMiddleware{
+		func(req *http.Request) (*http.Request, error) {
+			req.Header.Set("Authorization", "Bearer secret-token")
+			return req, nil
+		},
+		recorder.RequestMiddleware,
+	}
+	c.ResponseMiddlewares = &[]msgraph.ResponseMiddleware{recorder.ResponseMiddleware}
+
+	body := `{"displayName": "Test", "passwordCredentials": [{"secretText": "s3cr3t"}]}`
+	_, _, _, err = c.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:             []byte(body),
+		Uri:              msgraph.Uri{Entity: "/applications"},
+		ValidStatusCodes: []int{http.StatusCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordedResp, _, _, err := c.Get(ctx, msgraph.GetHttpRequestInput{
+		Uri:              msgraph.Uri{Entity: "/applications"},
+		ValidStatusCodes: []int{http.StatusOK},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordedBody, _ := io.ReadAll(recordedResp.Body)
+	recorder.Close()
+	server.Close()
+
+	content, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-token", "s3cr3t"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette contains secret %q", secret)
+		}
+	}
+
+	// replay (without server)
+	replayer, err := NewReplayer(cassettePath, redact.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = msgraph.NewClient(msgraph.VersionBeta)
+	c.Endpoint = "https://graph.invalid"
+	c.RequestMiddlewares = &[]msgraph.RequestMiddleware{replayer.RequestMiddleware}
+
+	_, status, _, err := c.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:             []byte(body),
+		Uri:              msgraph.Uri{Entity: "/applications"},
+		ValidStatusCodes: []int{http.StatusCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != http.StatusCreated {
+		t.Errorf("expected status %d, got %d", http.StatusCreated, status)
+	}
+
+	for range 2 {
+		resp, _, _, err := c.Get(ctx, msgraph.GetHttpRequestInput{
+			Uri:              msgraph.Uri{Entity: "/applications"},
+			ValidStatusCodes: []int{http.StatusOK},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		replayedBody, _ := io.ReadAll(resp.Body)
+		if string(redact.Default().ResponseBody(nil, recordedBody)) != string(replayedBody) {
+			t.Errorf("replayed body differs, expected %s, got %s", recordedBody, replayedBody)
+		}
+	}
+
+	_, _, _, err = c.Get(ctx, msgraph.GetHttpRequestInput{
+		Uri:              msgraph.Uri{Entity: "/groups"},
+		ValidStatusCodes: []int{http.StatusOK},
+	})
+	if err == nil || !strings.Contains(err.Error(), "does not contain a recorded response") {
+		t.Errorf("expected error for unrecorded request, got %v", err)
+	}
+}
+
+func TestRecordAndReplayKeepsTypes(t *testing.T) {
+	ctx := context.Background()
+	cassettePath := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	server := graphmock.NewServer()
+	server.AddEntitySet("/deviceManagement/deviceConfigurations")
+	defer server.Close()
+
+	recorder, err := NewRecorder(cassettePath, redact.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := msgraph.NewClient(msgraph.VersionBeta)
+	c.Endpoint = server.URL
+	c.RequestMiddlewares = &[]msgraph.RequestMiddleware{recorder.RequestMiddleware}
+	c.ResponseMiddlewares = &[]msgraph.ResponseMiddleware{recorder.ResponseMiddleware}
+
+	body := `{"passwordMinimumLength": 8, "passwordRequired": true, "passwordCredentials": [{"keyId": "1", "secretText": "s3cr3t"}]}`
+	_, _, _, err = c.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:             []byte(body),
+		Uri:              msgraph.Uri{Entity: "/deviceManagement/deviceConfigurations"},
+		ValidStatusCodes: []int{http.StatusCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.Close()
+
+	replayer, err := NewReplayer(cassettePath, redact.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = msgraph.NewClient(msgraph.VersionBeta)
+	c.Endpoint = "https://graph.invalid"
+	c.RequestMiddlewares = &[]msgraph.RequestMiddleware{replayer.RequestMiddleware}
+
+	resp, _, _, err := c.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:             []byte(body),
+		Uri:              msgraph.Uri{Entity: "/deviceManagement/deviceConfigurations"},
+		ValidStatusCodes: []int{http.StatusCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var replayed struct {
+		PasswordMinimumLength int64
+		PasswordRequired      bool
+		PasswordCredentials   []struct {
+			KeyId      string
+			SecretText string
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&replayed); err != nil {
+		t.Fatalf("replayed body cannot be decoded: %v", err)
+	}
+	if replayed.PasswordMinimumLength != 8 || !replayed.PasswordRequired {
+		t.Errorf("replayed non-secret values differ: %+v", replayed)
+	}
+	if len(replayed.PasswordCredentials) != 1 || replayed.PasswordCredentials[0].KeyId != "1" ||
+		replayed.PasswordCredentials[0].SecretText != redact.Redacted {
+		t.Errorf("replayed credentials differ: %+v", replayed.PasswordCredentials)
+	}
+}
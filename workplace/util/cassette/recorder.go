@@ -0,0 +1,77 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"terraform-provider-microsoft365wp/workplace/util/redact"
+)
+
+// Recorder appends all requests sent by a MS Graph client and their responses to a cassette file.
+type Recorder struct {
+	mutex    sync.Mutex
+	file     *os.File
+	redactor *redact.Redactor
+}
+
+func NewRecorder(path string, redactor *redact.Redactor) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening cassette %q for recording: %v", path, err)
+	}
+	return &Recorder{file: file, redactor: redactor}, nil
+}
+
+// RequestMiddleware must be registered (as the last one) with the MS Graph client, so that the request body is
+// available when recording the interaction.
+func (r *Recorder) RequestMiddleware(req *http.Request) (*http.Request, error) {
+	return readRequestBody(req)
+}
+
+// ResponseMiddleware must be registered with the MS Graph client to record the interactions.
+func (r *Recorder) ResponseMiddleware(req *http.Request, resp *http.Response) (*http.Response, error) {
+	if req == nil || resp == nil {
+		return resp, nil
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		var err error
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading response body: %v", err)
+		}
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	interaction := Interaction{
+		Request: newRequest(req, r.redactor),
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.redactor.Header(resp.Header),
+			Body:       string(r.redactor.ResponseBody(req, body)),
+		},
+	}
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling interaction for cassette: %v", err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("writing interaction to cassette: %v", err)
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) Close() error {
+	return r.file.Close()
+}
@@ -0,0 +1,101 @@
+package cassette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"terraform-provider-microsoft365wp/workplace/external/msgraph"
+	"terraform-provider-microsoft365wp/workplace/util/redact"
+)
+
+// Replayer answers the requests of a MS Graph client with the responses recorded in a cassette file instead of
+// sending them.
+//
+// Recorded responses are returned in order for identical requests. Once all responses for a request have been
+// returned, the last one is repeated. This is required as Terraform starts several provider processes (each replaying
+// the cassette from the beginning) during a single run.
+type Replayer struct {
+	mutex        sync.Mutex
+	interactions map[string][]Response
+	counts       map[string]int
+	redactor     *redact.Redactor
+}
+
+// NewReplayer reads the cassette. The redactor must match the one used for recording, as requests are redacted before
+// they are compared with the recorded ones.
+func NewReplayer(path string, redactor *redact.Redactor) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening cassette %q for replaying: %v", path, err)
+	}
+	defer file.Close()
+
+	r := &Replayer{
+		interactions: map[string][]Response{},
+		counts:       map[string]int{},
+		redactor:     redactor,
+	}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("parsing line %d of cassette %q: %v", lineNumber, path, err)
+		}
+		key := interaction.Request.key()
+		r.interactions[key] = append(r.interactions[key], interaction.Response)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading cassette %q: %v", path, err)
+	}
+
+	return r, nil
+}
+
+// RequestMiddleware must be registered (as the last one) with the MS Graph client to replay the responses.
+func (r *Replayer) RequestMiddleware(req *http.Request) (*http.Request, error) {
+	req, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	request := newRequest(req, r.redactor)
+	key := request.key()
+
+	r.mutex.Lock()
+	responses := r.interactions[key]
+	if len(responses) == 0 {
+		r.mutex.Unlock()
+		return nil, fmt.Errorf("cassette does not contain a recorded response for %s %s", request.Method, request.Url)
+	}
+	i := min(r.counts[key], len(responses)-1)
+	r.counts[key]++
+	r.mutex.Unlock()
+
+	recorded := responses[i]
+	resp := &http.Response{
+		Status:        strconv.Itoa(recorded.StatusCode) + " " + http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+	}
+	if resp.Header == nil {
+		resp.Header = http.Header{}
+	}
+	resp.Header.Del("Content-Length")
+
+	return msgraph.WithMiddlewareResponse(req, resp), nil
+}
@@ -0,0 +1,450 @@
+package graphmock
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ActionFunc implements a bound action (e.g. "assign") or any other request below an existing entity. It returns the
+// status and the (optional) body of the response.
+type ActionFunc func(e *EntitySet, id string, r *http.Request, body map[string]any) (int, any)
+
+// EntitySet is an in-memory entity set implementing the common MS Graph semantics for creating, reading, updating and
+// deleting entities (including navigation properties, $ref collections and ETags).
+type EntitySet struct {
+	// IdAttribute is the name of the key attribute (defaults to "id")
+	IdAttribute string
+	// NewId gets called to generate the key of new entities (if it has not been provided by the client)
+	NewId func() string
+	// NewNavigationId gets called to generate the key of entities created within collection-valued navigation
+	// properties (defaults to NewUuid)
+	NewNavigationId func(property string, parentId string, entity map[string]any) string
+	// Defaults contains server side default values that will be added to new entities (if not provided by the client)
+	Defaults map[string]any
+	// NavigationDefaults contains the Defaults for entities created within collection-valued navigation properties
+	// (keyed by navigation property)
+	NavigationDefaults map[string]map[string]any
+	// ETags adds @odata.etag to all entities and honors If-Match on updates and deletes
+	ETags bool
+	// Actions contains handlers for requests below an existing entity (keyed by the first path segment after the
+	// entity id, without any function parameters), requests for other segments will read or write navigation properties (including $ref collections)
+	Actions map[string]ActionFunc
+	// Singleton does not allow to create or delete the entity (also see Server.AddSingleton)
+	Singleton bool
+
+	mu         sync.Mutex
+	items      map[string]map[string]any
+	order      []string
+	navigation map[string]map[string]any
+	versions   map[string]int
+	singleton  map[string]any
+}
+
+func NewEntitySet() *EntitySet {
+	return &EntitySet{
+		items:      map[string]map[string]any{},
+		navigation: map[string]map[string]any{},
+		versions:   map[string]int{},
+	}
+}
+
+// NewUuid returns a random UUID (as MS Graph uses it for most entity ids).
+func NewUuid() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// StoreNavigation returns an ActionFunc that saves the attribute of the request body as navigation property (e.g. to
+// save the assignments posted to the assign action so they can be read from /assignments afterwards).
+func StoreNavigation(bodyAttribute string, navigationProperty string) ActionFunc {
+	return func(e *EntitySet, id string, r *http.Request, body map[string]any) (int, any) {
+		e.SetNavigation(id, navigationProperty, body[bodyAttribute])
+		return http.StatusNoContent, nil
+	}
+}
+
+func (e *EntitySet) idAttribute() string {
+	if e.IdAttribute == "" {
+		return "id"
+	}
+	return e.IdAttribute
+}
+
+// Get returns a copy of the entity (or nil if it does not exist).
+func (e *EntitySet) Get(id string) map[string]any {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.Singleton {
+		return copyMap(e.singleton)
+	}
+	return copyMap(e.items[id])
+}
+
+// Put adds or replaces the entity (e.g. to simulate pre-existing entities or changes made outside of Terraform).
+func (e *EntitySet) Put(entity map[string]any) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.Singleton {
+		e.singleton = copyMap(entity)
+		e.versions[""]++
+		return ""
+	}
+	id, _ := entity[e.idAttribute()].(string)
+	if id == "" {
+		id = e.newId()
+	}
+	e.store(id, copyMap(entity))
+	return id
+}
+
+// Ids returns the ids of all entities in order of their creation.
+func (e *EntitySet) Ids() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return slices.Clone(e.order)
+}
+
+// SetNavigation sets the value of a navigation property of the entity (which will not be returned when reading the
+// entity itself).
+func (e *EntitySet) SetNavigation(id string, property string, value any) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.navigation[id] == nil {
+		e.navigation[id] = map[string]any{}
+	}
+	e.navigation[id][property] = value
+}
+
+// GetNavigation returns the value of a navigation property of the entity.
+func (e *EntitySet) GetNavigation(id string, property string) any {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.navigation[id][property]
+}
+
+func (e *EntitySet) ServeGraph(w http.ResponseWriter, r *http.Request, p Path) {
+
+	var body map[string]any
+	if b, _ := io.ReadAll(r.Body); len(b) > 0 {
+		if err := json.Unmarshal(b, &body); err != nil {
+			WriteError(w, http.StatusBadRequest, "BadRequest", "Invalid JSON: "+err.Error())
+			return
+		}
+	}
+
+	if e.Singleton {
+		e.serveSingleton(w, r, body)
+		return
+	}
+
+	switch {
+	case len(p.Rest) == 0:
+		e.serveCollection(w, r, body)
+	case len(p.Rest) == 1:
+		e.serveEntity(w, r, p.Rest[0], body)
+	default:
+		e.serveBelowEntity(w, r, p.Rest[0], p.Rest[1:], body)
+	}
+}
+
+func (e *EntitySet) serveSingleton(w http.ResponseWriter, r *http.Request, body map[string]any) {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		WriteJson(w, http.StatusOK, e.withETag("", e.singleton))
+	case http.MethodPatch, http.MethodPut:
+		if !e.checkIfMatch(w, r, "") {
+			return
+		}
+		if r.Method == http.MethodPut || e.singleton == nil {
+			e.singleton = map[string]any{}
+		}
+		maps.Copy(e.singleton, body)
+		e.versions[""]++
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		WriteError(w, http.StatusMethodNotAllowed, "BadRequest", "Method not allowed for singleton.")
+	}
+}
+
+func (e *EntitySet) serveCollection(w http.ResponseWriter, r *http.Request, body map[string]any) {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		filter, err := parseFilter(r.URL.Query().Get("$filter"))
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "BadRequest", err.Error())
+			return
+		}
+		values := []any{}
+		for _, id := range e.order {
+			if filter.matches(e.items[id]) {
+				values = append(values, e.expand(r, id, e.withETag(id, e.items[id])))
+			}
+		}
+		if top, err := strconv.Atoi(r.URL.Query().Get("$top")); err == nil && top < len(values) {
+			values = values[:top]
+		}
+		WriteJson(w, http.StatusOK, map[string]any{"value": values})
+	case http.MethodPost:
+		entity := copyMap(e.Defaults)
+		if entity == nil {
+			entity = map[string]any{}
+		}
+		maps.Copy(entity, body)
+		id, _ := entity[e.idAttribute()].(string)
+		if id == "" {
+			id = e.newId()
+		} else if _, exists := e.items[id]; exists {
+			WriteError(w, http.StatusConflict, "Request_BadRequest", fmt.Sprintf("Entity with id '%s' already exists.", id))
+			return
+		}
+		e.store(id, entity)
+		WriteJson(w, http.StatusCreated, e.withETag(id, entity))
+	default:
+		WriteError(w, http.StatusMethodNotAllowed, "BadRequest", "Method not allowed for collection.")
+	}
+}
+
+func (e *EntitySet) serveEntity(w http.ResponseWriter, r *http.Request, id string, body map[string]any) {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entity, exists := e.items[id]
+	if !exists && r.Method != http.MethodPut {
+		WriteError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		WriteJson(w, http.StatusOK, e.expand(r, id, e.withETag(id, entity)))
+	case http.MethodPatch:
+		if !e.checkIfMatch(w, r, id) {
+			return
+		}
+		maps.Copy(entity, body)
+		entity[e.idAttribute()] = id
+		e.versions[id]++
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		if exists && !e.checkIfMatch(w, r, id) {
+			return
+		}
+		entity = copyMap(body)
+		if entity == nil {
+			entity = map[string]any{}
+		}
+		entity[e.idAttribute()] = id
+		e.store(id, entity)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if !e.checkIfMatch(w, r, id) {
+			return
+		}
+		delete(e.items, id)
+		delete(e.navigation, id)
+		delete(e.versions, id)
+		e.order = slices.DeleteFunc(e.order, func(v string) bool { return v == id })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		WriteError(w, http.StatusMethodNotAllowed, "BadRequest", "Method not allowed for entity.")
+	}
+}
+
+func (e *EntitySet) serveBelowEntity(w http.ResponseWriter, r *http.Request, id string, rest []string, body map[string]any) {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, exists := e.items[id]; !exists {
+		WriteError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist.", id))
+		return
+	}
+
+	// functions are addressed including their parameters, e.g. "getValue(key='x')"
+	actionName, _, _ := strings.Cut(rest[0], "(")
+	if action := e.Actions[actionName]; action != nil {
+		e.mu.Unlock()
+		status, resp := action(e, id, r, body)
+		e.mu.Lock()
+		if resp != nil {
+			WriteJson(w, status, resp)
+		} else {
+			w.WriteHeader(status)
+		}
+		return
+	}
+
+	property := rest[0]
+	if e.navigation[id] == nil {
+		e.navigation[id] = map[string]any{}
+	}
+	navigation := e.navigation[id]
+	values, isCollection := navigation[property].([]any)
+
+	switch {
+	case len(rest) == 1 && r.Method == http.MethodGet:
+		switch v := navigation[property].(type) {
+		case nil:
+			WriteJson(w, http.StatusOK, map[string]any{"value": []any{}})
+		case []any:
+			WriteJson(w, http.StatusOK, map[string]any{"value": v})
+		default:
+			WriteJson(w, http.StatusOK, v)
+		}
+
+	case len(rest) == 1 && (r.Method == http.MethodPatch || r.Method == http.MethodPut):
+		navigation[property] = body
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(rest) == 1 && r.Method == http.MethodPost:
+		child := copyMap(e.NavigationDefaults[property])
+		if child == nil {
+			child = map[string]any{}
+		}
+		maps.Copy(child, body)
+		if childId, _ := child["id"].(string); childId == "" {
+			if e.NewNavigationId != nil {
+				child["id"] = e.NewNavigationId(property, id, child)
+			} else {
+				child["id"] = NewUuid()
+			}
+		}
+		navigation[property] = append(values, child)
+		WriteJson(w, http.StatusCreated, child)
+
+	case len(rest) == 2 && rest[1] == "$ref" && r.Method == http.MethodPost:
+		odataId, _ := body["@odata.id"].(string)
+		childId := odataId[strings.LastIndex(odataId, "/")+1:]
+		if childId == "" {
+			WriteError(w, http.StatusBadRequest, "BadRequest", "Missing @odata.id.")
+			return
+		}
+		if findById(values, childId) >= 0 {
+			WriteError(w, http.StatusBadRequest, "Request_BadRequest", "One or more added object references already exist for the following modified properties.")
+			return
+		}
+		navigation[property] = append(values, map[string]any{"id": childId})
+		w.WriteHeader(http.StatusNoContent)
+
+	case isCollection && (len(rest) == 2 || (len(rest) == 3 && rest[2] == "$ref")):
+		i := findById(values, rest[1])
+		if i < 0 {
+			WriteError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("Resource '%s' does not exist.", rest[1]))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			WriteJson(w, http.StatusOK, values[i])
+		case http.MethodPatch:
+			maps.Copy(values[i].(map[string]any), body)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			navigation[property] = slices.Delete(values, i, i+1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			WriteError(w, http.StatusMethodNotAllowed, "BadRequest", "Method not allowed for navigation property.")
+		}
+
+	default:
+		WriteError(w, http.StatusNotFound, "BadRequest", fmt.Sprintf("Resource not found for the segment '%s'.", strings.Join(rest, "/")))
+	}
+}
+
+// expand adds the navigation properties requested by $expand to the entity (ignoring any nested query options).
+func (e *EntitySet) expand(r *http.Request, id string, entity map[string]any) map[string]any {
+	for _, property := range strings.Split(r.URL.Query().Get("$expand"), ",") {
+		property, _, _ = strings.Cut(strings.TrimSpace(property), "(")
+		if property == "" {
+			continue
+		}
+		value := e.navigation[id][property]
+		if value == nil {
+			value = []any{}
+		}
+		entity[property] = value
+	}
+	return entity
+}
+
+func findById(values []any, id string) int {
+	return slices.IndexFunc(values, func(v any) bool {
+		m, ok := v.(map[string]any)
+		return ok && m["id"] == id
+	})
+}
+
+func (e *EntitySet) newId() string {
+	if e.NewId != nil {
+		return e.NewId()
+	}
+	return NewUuid()
+}
+
+func (e *EntitySet) store(id string, entity map[string]any) {
+	entity[e.idAttribute()] = id
+	if _, exists := e.items[id]; !exists {
+		e.order = append(e.order, id)
+	}
+	e.items[id] = entity
+	e.versions[id]++
+}
+
+func (e *EntitySet) etag(id string) string {
+	return fmt.Sprintf(`W/"%d"`, e.versions[id])
+}
+
+func (e *EntitySet) withETag(id string, entity map[string]any) map[string]any {
+	result := copyMap(entity)
+	if result == nil {
+		result = map[string]any{}
+	}
+	if e.ETags {
+		result["@odata.etag"] = e.etag(id)
+	}
+	return result
+}
+
+func (e *EntitySet) checkIfMatch(w http.ResponseWriter, r *http.Request, id string) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if !e.ETags || ifMatch == "" || ifMatch == "*" || ifMatch == e.etag(id) {
+		return true
+	}
+	WriteError(w, http.StatusPreconditionFailed, "PreconditionFailed", "The ETag provided in If-Match does not match the current ETag of the entity.")
+	return false
+}
+
+func copyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	// deep copy using JSON to also decouple nested values
+	b, _ := json.Marshal(m)
+	result := map[string]any{}
+	_ = json.Unmarshal(b, &result)
+	return result
+}
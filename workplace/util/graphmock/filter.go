@@ -0,0 +1,71 @@
+package graphmock
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filter supports the subset of OData $filter that is used by this provider, i.e. equality comparisons of (nested)
+// attributes with strings, numbers and booleans (optionally combined using "and").
+type filter []filterCondition
+
+type filterCondition struct {
+	attribute []string
+	value     any
+}
+
+var filterConditionRegexp = regexp.MustCompile(`^\s*([\w/@.]+)\s+eq\s+('(?:[^']|'')*'|[^\s]+)\s*$`)
+
+func parseFilter(s string) (filter, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	f := filter{}
+	for _, part := range strings.Split(s, " and ") {
+		m := filterConditionRegexp.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("unsupported filter expression: %s", part)
+		}
+		var value any
+		switch {
+		case strings.HasPrefix(m[2], "'"):
+			value = strings.ReplaceAll(m[2][1:len(m[2])-1], "''", "'")
+		case m[2] == "true" || m[2] == "false":
+			value = m[2] == "true"
+		case m[2] == "null":
+			value = nil
+		default:
+			n, err := strconv.ParseFloat(m[2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("unsupported filter value: %s", m[2])
+			}
+			value = n
+		}
+		f = append(f, filterCondition{attribute: strings.Split(m[1], "/"), value: value})
+	}
+	return f, nil
+}
+
+func (f filter) matches(entity map[string]any) bool {
+	for _, c := range f {
+		var current any = entity
+		for _, a := range c.attribute {
+			if m, ok := current.(map[string]any); ok {
+				current = m[a]
+			} else {
+				current = nil
+			}
+		}
+		if s, ok := current.(string); ok {
+			if cs, ok := c.value.(string); !ok || !strings.EqualFold(s, cs) {
+				return false
+			}
+		} else if current != c.value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,252 @@
+// Package graphmock provides an in-process fake of MS Graph (based on httptest) that msgraph.Client can be pointed at
+// using its Endpoint field. Entity sets get registered per URI pattern and can either use the in-memory EntitySet
+// implementation or any custom Handler.
+package graphmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Handler serves all requests targeting an entity set (or singleton) and anything below it.
+type Handler interface {
+	ServeGraph(w http.ResponseWriter, r *http.Request, p Path)
+}
+
+// HandlerFunc is an adapter to use ordinary functions as Handler.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request, p Path)
+
+func (f HandlerFunc) ServeGraph(w http.ResponseWriter, r *http.Request, p Path) {
+	f(w, r, p)
+}
+
+// Path contains the parts of the request path that matched the wildcards of the registered pattern (i.e. usually the
+// ids of parent entities) and the remaining path segments below the entity set (e.g. the entity id and navigation
+// properties or actions).
+type Path struct {
+	Params []string
+	Rest   []string
+}
+
+// Request is a request that has been received by the server (requests contained in JSON batches get recorded
+// individually).
+type Request struct {
+	Method     string
+	ApiVersion string // empty if the request path did not contain an API version
+	Path       string
+	Query      string
+	Header     http.Header
+	Body       []byte
+}
+
+type route struct {
+	segments []string
+	handler  Handler
+}
+
+// Server is a fake MS Graph server. API versions get stripped from the request path before routing, i.e. patterns
+// must not contain them.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   []route
+	requests []Request
+}
+
+func NewServer() *Server {
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Handle registers the handler for the given pattern, e.g. "/deviceManagement/deviceConfigurations" or
+// "/deviceManagement/deviceConfigurations/*/assignments" (where "*" matches any single path segment). The handler of
+// the longest matching pattern will be used.
+func (s *Server) Handle(pattern string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes = append(s.routes, route{segments: splitPath(pattern), handler: handler})
+	slices.SortStableFunc(s.routes, func(a, b route) int { return len(b.segments) - len(a.segments) })
+}
+
+// AddEntitySet registers a new in-memory EntitySet for the given pattern and returns it.
+func (s *Server) AddEntitySet(pattern string) *EntitySet {
+	es := NewEntitySet()
+	s.Handle(pattern, es)
+	return es
+}
+
+// AddSingleton registers a new in-memory EntitySet acting as singleton (i.e. an entity that can only be read and
+// updated but not be created or deleted) for the given pattern and returns it.
+func (s *Server) AddSingleton(pattern string, initial map[string]any) *EntitySet {
+	es := NewEntitySet()
+	es.Singleton = true
+	es.singleton = copyMap(initial)
+	s.Handle(pattern, es)
+	return es
+}
+
+// Reset removes all registered handlers and recorded requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes = nil
+	s.requests = nil
+}
+
+// Requests returns all requests received since the server has been created or reset.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	segments := splitPath(r.URL.Path)
+	apiVersion := ""
+	if len(segments) > 0 && (segments[0] == "beta" || segments[0] == "v1.0") {
+		apiVersion = segments[0]
+		segments = segments[1:]
+	}
+
+	if len(segments) == 1 && segments[0] == "$batch" && r.Method == http.MethodPost {
+		s.serveBatch(w, r, body)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method:     r.Method,
+		ApiVersion: apiVersion,
+		Path:       "/" + strings.Join(segments, "/"),
+		Query:      r.URL.RawQuery,
+		Header:     r.Header.Clone(),
+		Body:       body,
+	})
+	routes := s.routes
+	s.mu.Unlock()
+
+	for _, rt := range routes {
+		if params, ok := matchSegments(rt.segments, segments); ok {
+			rt.handler.ServeGraph(w, r, Path{Params: params, Rest: segments[len(rt.segments):]})
+			return
+		}
+	}
+
+	WriteError(w, http.StatusNotFound, "BadRequest", fmt.Sprintf("Resource not found for the segment '%s'.", r.URL.Path))
+}
+
+type batchRequestItem struct {
+	Id      string            `json:"id"`
+	Method  string            `json:"method"`
+	Url     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+type batchResponseItem struct {
+	Id      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// serveBatch executes all requests of a JSON batch one after another (which also satisfies any dependsOn).
+func (s *Server) serveBatch(w http.ResponseWriter, r *http.Request, body []byte) {
+
+	var batch struct {
+		Requests []batchRequestItem `json:"requests"`
+	}
+	if err := json.Unmarshal(body, &batch); err != nil {
+		WriteError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	apiVersion := splitPath(r.URL.Path)[0]
+	responses := make([]batchResponseItem, 0, len(batch.Requests))
+	for _, item := range batch.Requests {
+		itemReq, err := http.NewRequestWithContext(r.Context(), item.Method, "/"+apiVersion+"/"+strings.TrimLeft(item.Url, "/"), bytes.NewReader(item.Body))
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "BadRequest", err.Error())
+			return
+		}
+		for k, v := range item.Headers {
+			itemReq.Header.Set(k, v)
+		}
+
+		recorder := httptest.NewRecorder()
+		s.serveHTTP(recorder, itemReq)
+
+		resp := batchResponseItem{Id: item.Id, Status: recorder.Code, Headers: map[string]string{}}
+		for k := range recorder.Header() {
+			resp.Headers[k] = recorder.Header().Get(k)
+		}
+		if recorder.Body.Len() > 0 {
+			resp.Body = recorder.Body.Bytes()
+		}
+		responses = append(responses, resp)
+	}
+
+	WriteJson(w, http.StatusOK, map[string]any{"responses": responses})
+}
+
+// WriteJson writes the value as JSON response with the given status.
+func WriteJson(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// WriteError writes an MS Graph error response.
+func WriteError(w http.ResponseWriter, status int, code string, message string) {
+	WriteJson(w, status, map[string]any{
+		"error": map[string]any{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func splitPath(p string) []string {
+	segments := []string{}
+	for _, s := range strings.Split(p, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+func matchSegments(pattern []string, segments []string) ([]string, bool) {
+	if len(segments) < len(pattern) {
+		return nil, false
+	}
+	params := []string{}
+	for i, p := range pattern {
+		if p == "*" {
+			params = append(params, segments[i])
+		} else if !strings.EqualFold(p, segments[i]) {
+			return nil, false
+		}
+	}
+	return params, true
+}
@@ -0,0 +1,186 @@
+// Package redact removes secrets (like access tokens, passwords or pre-shared keys) from HTTP requests and responses
+// before they are logged or otherwise persisted.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"slices"
+	"strings"
+)
+
+// Redacted replaces the values of sensitive headers and JSON string attributes.
+const Redacted = "REDACTED"
+
+// Redactor defines which parts of requests and responses are considered sensitive.
+type Redactor struct {
+	// Headers (case-insensitive) whose values will be redacted.
+	Headers []string
+
+	// JsonPaths are the dotted paths of JSON attributes whose values will be redacted, e.g. `passwordProfile.password`.
+	// Arrays are transparent, i.e. `omaSettings.value` matches the `value` attribute of all items of `omaSettings`. A
+	// path matches at any depth of the body, so that JSON batches and nested entities are covered as well. Only string
+	// values get redacted, so that the JSON remains of the same shape and types (e.g. to be replayed from cassettes).
+	JsonPaths []string
+
+	// ResponseJsonPaths are additional JsonPaths to redact within responses, keyed by a part of the request URL path.
+	// This allows to redact the results of actions and functions like `getOmaSettingPlainTextValue`.
+	ResponseJsonPaths map[string][]string
+}
+
+// Default returns a Redactor for MS Graph covering authorization headers, well-known secret attributes that are not
+// marked as sensitive in the schema (see With) and the results of functions returning secrets in plain text.
+func Default() *Redactor {
+	return &Redactor{
+		Headers: []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie", "X-Ms-Authorization-Auxiliary"},
+		JsonPaths: []string{
+			"omaSettings.value",
+			"passwordProfile.password",
+			"preSharedKey",
+			"productKey",
+			"secretText",
+		},
+		ResponseJsonPaths: map[string][]string{
+			"/getOmaSettingPlainTextValue(": {"value"},
+		},
+	}
+}
+
+// With returns a copy of the Redactor which additionally redacts the JSON paths.
+func (r *Redactor) With(jsonPaths ...string) *Redactor {
+	result := *r
+	result.JsonPaths = append(slices.Clone(r.JsonPaths), jsonPaths...)
+	return &result
+}
+
+// Header returns a copy of the header with sensitive values redacted.
+func (r *Redactor) Header(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+	result := header.Clone()
+	for _, h := range r.Headers {
+		if result.Get(h) != "" {
+			result.Set(h, Redacted)
+		}
+	}
+	return result
+}
+
+// RequestBody returns a copy of the request body with sensitive JSON attributes redacted.
+func (r *Redactor) RequestBody(body []byte) []byte {
+	return r.body(body, r.JsonPaths)
+}
+
+// ResponseBody returns a copy of the body of a response to the request with sensitive JSON attributes redacted.
+func (r *Redactor) ResponseBody(req *http.Request, body []byte) []byte {
+	jsonPaths := r.JsonPaths
+	if req != nil && req.URL != nil {
+		for urlPart, paths := range r.ResponseJsonPaths {
+			if strings.Contains(req.URL.Path, urlPart) {
+				jsonPaths = append(slices.Clone(jsonPaths), paths...)
+			}
+		}
+	}
+	return r.body(body, jsonPaths)
+}
+
+// body redacts sensitive attributes of JSON bodies. As a side effect, JSON bodies will be normalized, i.e. attributes
+// will be sorted and insignificant whitespace will be removed. Other bodies are returned unchanged.
+func (r *Redactor) body(body []byte, jsonPaths []string) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	splitPaths := make([][]string, len(jsonPaths))
+	for i, p := range jsonPaths {
+		splitPaths[i] = strings.Split(p, ".")
+	}
+	result, err := json.Marshal(r.json(v, nil, splitPaths))
+	if err != nil {
+		return body
+	}
+	return result
+}
+
+func (r *Redactor) json(v any, path []string, jsonPaths [][]string) any {
+	switch typed := v.(type) {
+	case map[string]any:
+		for k, v := range typed {
+			typed[k] = r.json(v, append(slices.Clip(path), k), jsonPaths)
+		}
+	case []any:
+		for i, v := range typed {
+			typed[i] = r.json(v, path, jsonPaths)
+		}
+	case string:
+		if len(path) > 0 && isSensitive(path, jsonPaths) {
+			return Redacted
+		}
+	}
+	return v
+}
+
+func isSensitive(path []string, jsonPaths [][]string) bool {
+	for _, p := range jsonPaths {
+		if len(p) <= len(path) && slices.Equal(p, path[len(path)-len(p):]) {
+			return true
+		}
+	}
+	return false
+}
+
+// DumpRequest returns the request line and headers as well as the body of the request, both redacted. The body of
+// the request remains readable.
+func (r *Redactor) DumpRequest(req *http.Request) ([]byte, []byte, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	redactedReq := req.Clone(req.Context())
+	redactedReq.Header = r.Header(req.Header)
+	redactedReq.Body = io.NopCloser(bytes.NewReader(body))
+	head, err := httputil.DumpRequestOut(redactedReq, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return head, r.RequestBody(body), nil
+}
+
+// DumpResponse returns the status line and headers as well as the body of the response, both redacted. The body of
+// the response remains readable.
+func (r *Redactor) DumpResponse(resp *http.Response) ([]byte, []byte, error) {
+	body, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	redactedResp := *resp
+	redactedResp.Header = r.Header(resp.Header)
+	redactedResp.Body = nil
+	head, err := httputil.DumpResponse(&redactedResp, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return head, r.ResponseBody(resp.Request, body), nil
+}
+
+// readBody reads the body and replaces it with a fresh reader of the same content.
+func readBody(b *io.ReadCloser) ([]byte, error) {
+	if *b == nil || *b == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(*b)
+	if err != nil {
+		return nil, fmt.Errorf("reading body: %v", err)
+	}
+	(*b).Close()
+	*b = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
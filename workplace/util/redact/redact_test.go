@@ -0,0 +1,62 @@
+package redact
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDumpRequest(t *testing.T) {
+	body := `{"displayName":"Test","passwordProfile":{"password":"s3cr3t"},"omaSettings":[{"omaUri":"./Foo","value":"bar"}],"wiFi":{"key":"k3y"}}`
+	req, _ := http.NewRequest(http.MethodPost, "https://graph.microsoft.com/beta/users", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer t0ken")
+	req.Header.Set("X-Custom", "custom")
+
+	r := Default().With("wiFi.key")
+	r.Headers = append(r.Headers, "X-Custom")
+	head, redactedBody, err := r.DumpRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"t0ken", "custom", "s3cr3t", "bar", "k3y"} {
+		if bytes.Contains(head, []byte(secret)) || bytes.Contains(redactedBody, []byte(secret)) {
+			t.Errorf("dump contains secret %q:\n%s%s", secret, head, redactedBody)
+		}
+	}
+	for _, kept := range []string{`"displayName":"Test"`, `"omaUri":"./Foo"`} {
+		if !bytes.Contains(redactedBody, []byte(kept)) {
+			t.Errorf("redacted body does not contain %s: %s", kept, redactedBody)
+		}
+	}
+
+	if b, _ := io.ReadAll(req.Body); string(b) != body {
+		t.Errorf("request body has been modified: %s", b)
+	}
+	if req.Header.Get("Authorization") != "Bearer t0ken" {
+		t.Errorf("request header has been modified")
+	}
+}
+
+func TestResponseBodyOfFunction(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://graph.microsoft.com/beta/deviceManagement/deviceConfigurations/1/getOmaSettingPlainTextValue(secretReferenceValueId='2')", nil)
+	body := []byte(`{"value":"plain"}`)
+
+	if redacted := Default().ResponseBody(req, body); bytes.Contains(redacted, []byte("plain")) {
+		t.Errorf("function result has not been redacted: %s", redacted)
+	}
+	if redacted := Default().RequestBody(body); !bytes.Contains(redacted, []byte("plain")) {
+		t.Errorf("request body has been redacted: %s", redacted)
+	}
+}
+
+func TestRedactOnlyStrings(t *testing.T) {
+	body := []byte(`{"passwordMinimumLength":8,"passwordRequired":true,"requestedAccessTokenVersion":2,"passwordCredentials":[{"keyId":"1","secretText":"s3cr3t"}],"omaSettings":[{"value":4},{"value":"bar"}]}`)
+
+	expected := `{"omaSettings":[{"value":4},{"value":"REDACTED"}],"passwordCredentials":[{"keyId":"1","secretText":"REDACTED"}],"passwordMinimumLength":8,"passwordRequired":true,"requestedAccessTokenVersion":2}`
+	if redacted := Default().ResponseBody(nil, body); string(redacted) != expected {
+		t.Errorf("unexpected redacted body:\n%s\nexpected:\n%s", redacted, expected)
+	}
+}
@@ -1,7 +1,12 @@
 package retryablehttputil
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"net/http"
+	"net/url"
+	"os"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -25,3 +30,66 @@ func ConfigureClientRetryLimitsAndBackoff(retryableClient *retryablehttp.Client)
 		return backoffTime
 	}
 }
+
+// TransportOptions configure the base transport of all HTTP clients of the provider (i.e. the one for MS Graph as well
+// as the one for uploads to Azure Storage).
+type TransportOptions struct {
+	// ProxyUrl is the URL of the HTTP proxy to use, the proxy is taken from the environment (HTTPS_PROXY etc.) if empty
+	ProxyUrl string
+
+	// CaBundlePath is the path of a PEM file with additional CA certificates to trust (besides the system ones)
+	CaBundlePath string
+
+	// ClientCertificatePath and ClientKeyPath are the paths of the PEM files of the certificate (and its key) to
+	// authenticate with using mutual TLS
+	ClientCertificatePath string
+	ClientKeyPath         string
+}
+
+// NewTransport returns a transport configured according to options (based on http.DefaultTransport).
+func NewTransport(options TransportOptions) (*http.Transport, error) {
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if options.ProxyUrl != "" {
+		proxyUrl, err := url.Parse(options.ProxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	if options.CaBundlePath != "" || options.ClientCertificatePath != "" || options.ClientKeyPath != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if options.CaBundlePath != "" {
+			pem, err := os.ReadFile(options.CaBundlePath)
+			if err != nil {
+				return nil, fmt.Errorf("reading CA bundle: %w", err)
+			}
+			rootCAs, err := x509.SystemCertPool()
+			if err != nil || rootCAs == nil {
+				rootCAs = x509.NewCertPool()
+			}
+			if !rootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("CA bundle %s does not contain any PEM encoded certificate", options.CaBundlePath)
+			}
+			tlsConfig.RootCAs = rootCAs
+		}
+
+		if options.ClientCertificatePath != "" || options.ClientKeyPath != "" {
+			if options.ClientCertificatePath == "" || options.ClientKeyPath == "" {
+				return nil, fmt.Errorf("both the client certificate and its key must be specified for mutual TLS")
+			}
+			certificate, err := tls.LoadX509KeyPair(options.ClientCertificatePath, options.ClientKeyPath)
+			if err != nil {
+				return nil, fmt.Errorf("loading client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
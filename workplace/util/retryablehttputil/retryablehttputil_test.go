@@ -0,0 +1,118 @@
+package retryablehttputil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writePem(t *testing.T, name string, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewTransport(t *testing.T) {
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+	caBundlePath := writePem(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	// self-signed client certificate
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCertificatePath := writePem(t, "client.pem", "CERTIFICATE", certDer)
+	clientKeyPath := writePem(t, "client-key.pem", "EC PRIVATE KEY", keyDer)
+
+	tests := []struct {
+		name       string
+		options    TransportOptions
+		wantStatus int // 0 if the request is expected to fail
+	}{
+		{"untrusted", TransportOptions{}, 0},
+		{"ca bundle", TransportOptions{CaBundlePath: caBundlePath}, http.StatusUnauthorized},
+		{"mutual tls", TransportOptions{CaBundlePath: caBundlePath, ClientCertificatePath: clientCertificatePath,
+			ClientKeyPath: clientKeyPath}, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := NewTransport(tt.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if tt.wantStatus == 0 {
+				if err == nil {
+					resp.Body.Close()
+					t.Errorf("expected request to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+		})
+	}
+
+	transport, err := NewTransport(TransportOptions{ProxyUrl: "http://proxy.example:3128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://graph.microsoft.com/v1.0/me", nil)
+	if proxyUrl, err := transport.Proxy(req); err != nil || proxyUrl.String() != "http://proxy.example:3128" {
+		t.Errorf("unexpected proxy %v (%v)", proxyUrl, err)
+	}
+
+	for _, options := range []TransportOptions{
+		{ProxyUrl: "://invalid"},
+		{CaBundlePath: filepath.Join(t.TempDir(), "missing.pem")},
+		{ClientCertificatePath: clientCertificatePath},
+	} {
+		if _, err := NewTransport(options); err == nil {
+			t.Errorf("expected an error for options %+v", options)
+		}
+	}
+}
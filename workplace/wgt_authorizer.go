@@ -13,41 +13,79 @@ import (
 	"net/http"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"golang.org/x/oauth2"
 )
 
+// WgtAuthorizerOptions configures how WgtAuthorizer runs tools/wpGetToken.
+type WgtAuthorizerOptions struct {
+	// Path of the tool, defaults to wpGetToken (looked up using PATH)
+	Path string
+
+	// Args are passed to the tool on every invocation
+	Args []string
+
+	// AuxTenantIds lists additional tenants to obtain tokens for, the tool gets invoked with `--tenant <id>` for each of
+	// them
+	AuxTenantIds []string
+}
+
 // NewWgtAuthorizer returns an Authorizer which authenticates using tools/wpGetToken
-func NewWgtAuthorizer(ctx context.Context) (auth.Authorizer, error) {
-	// Cache access tokens internally to avoid unnecessary `az` invocations
-	return auth.NewCachedAuthorizer(&WgtAuthorizer{})
+func NewWgtAuthorizer(ctx context.Context, options WgtAuthorizerOptions) (auth.Authorizer, error) {
+	if options.Path == "" {
+		options.Path = "wpGetToken"
+	}
+	// Cache access tokens internally to avoid unnecessary `wpGetToken` invocations (until they expire)
+	return auth.NewCachedAuthorizer(&WgtAuthorizer{conf: options})
 }
 
 var _ auth.Authorizer = &WgtAuthorizer{}
 
 // WgtAuthorizer is an Authorizer which supports tools/wpGetToken
 type WgtAuthorizer struct {
+	conf WgtAuthorizerOptions
 }
 
 // Token returns an access token using wpGetToken as an authentication mechanism.
 func (a *WgtAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return a.token(a.conf.Args...)
+}
+
+// AuxiliaryTokens returns additional tokens for auxiliary tenant IDs, for use in multi-tenant scenarios
+func (a *WgtAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	tokens := make([]*oauth2.Token, 0, len(a.conf.AuxTenantIds))
+	for _, tenantId := range a.conf.AuxTenantIds {
+		argsWithTenant := append(a.conf.Args[:len(a.conf.Args):len(a.conf.Args)], "--tenant", tenantId)
+		token, err := a.token(argsWithTenant...)
+		if err != nil {
+			return nil, fmt.Errorf("obtaining token for auxiliary tenant %s: %w", tenantId, err)
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func (a *WgtAuthorizer) token(arg ...string) (*oauth2.Token, error) {
 	var tokenResult wgtTokenResult
-	if err := jsonUnmarshalWgtCmd(&tokenResult); err != nil {
+	if err := jsonUnmarshalWgtCmd(a.conf.Path, &tokenResult, arg...); err != nil {
 		return nil, err
 	}
 
+	// without an expiry the cached authorizer would never refresh the token
+	var expiry time.Time
+	if tokenResult.ExpiresOn > 0 {
+		expiry = time.Unix(tokenResult.ExpiresOn, 0)
+	}
+
 	return &oauth2.Token{
 		AccessToken: tokenResult.AccessToken,
 		TokenType:   tokenResult.TokenType,
+		Expiry:      expiry,
 	}, nil
 }
 
-// AuxiliaryTokens returns additional tokens for auxiliary tenant IDs, for use in multi-tenant scenarios
-func (a *WgtAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
-	panic("WgtAuthorizer does not support method AuxiliaryTokens()")
-}
-
 type wgtTokenResult struct {
 	TokenType   string `json:"token_type"`
 	Scope       string `json:"scope"`
@@ -55,17 +93,17 @@ type wgtTokenResult struct {
 	ExpiresOn   int64  `json:"expires_on"`
 }
 
-// jsonUnmarshalWgtCmd executes wpGetToken and unmarshalls the JSON output.
-func jsonUnmarshalWgtCmd(i interface{}, arg ...string) error {
+// jsonUnmarshalWgtCmd executes wpGetToken (or the tool at toolPath) and unmarshalls the JSON output.
+func jsonUnmarshalWgtCmd(toolPath string, i interface{}, arg ...string) error {
 	var stderr bytes.Buffer
 	var stdout bytes.Buffer
 
-	cmd := exec.Command("wpGetToken", arg...)
+	cmd := exec.Command(toolPath, arg...)
 	cmd.Stderr = &stderr
 	cmd.Stdout = &stdout
 
 	if err := cmd.Start(); err != nil {
-		err := fmt.Errorf("launching wpGetToken: %+v", err)
+		err := fmt.Errorf("launching %s: %+v", toolPath, err)
 		if stdErrStr := stderr.String(); stdErrStr != "" {
 			err = fmt.Errorf("%s: %s", err, strings.TrimSpace(stdErrStr))
 		}
@@ -73,7 +111,7 @@ func jsonUnmarshalWgtCmd(i interface{}, arg ...string) error {
 	}
 
 	if err := cmd.Wait(); err != nil {
-		err := fmt.Errorf("running wpGetToken: %+v", err)
+		err := fmt.Errorf("running %s: %+v", toolPath, err)
 		if stdErrStr := stderr.String(); stdErrStr != "" {
 			err = fmt.Errorf("%s: %s", err, strings.TrimSpace(stdErrStr))
 		}
@@ -81,7 +119,7 @@ func jsonUnmarshalWgtCmd(i interface{}, arg ...string) error {
 	}
 
 	if err := json.Unmarshal(stdout.Bytes(), &i); err != nil {
-		return fmt.Errorf("unmarshaling the output of wpGetToken: %v", err)
+		return fmt.Errorf("unmarshaling the output of %s: %v", toolPath, err)
 	}
 
 	return nil
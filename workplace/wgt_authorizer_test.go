@@ -0,0 +1,58 @@
+package workplace
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestWgtAuthorizer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake wpGetToken is a shell script")
+	}
+
+	// fake wpGetToken returning its arguments as token
+	toolPath := filepath.Join(t.TempDir(), "wpGetToken")
+	script := "#!/bin/sh\nprintf '{\"token_type\":\"Bearer\",\"access_token\":\"%s\",\"expires_on\":1893553445}' \"$*\"\n"
+	if err := os.WriteFile(toolPath, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	authorizer, err := NewWgtAuthorizer(ctx, WgtAuthorizerOptions{
+		Path:         toolPath,
+		Args:         []string{"--profile", "test"},
+		AuxTenantIds: []string{"tenant1", "tenant2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := authorizer.Token(ctx, &http.Request{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "--profile test" {
+		t.Errorf("unexpected access token %q", token.AccessToken)
+	}
+	if want := time.Unix(1893553445, 0); !token.Expiry.Equal(want) {
+		t.Errorf("expected expiry %s, got %s", want, token.Expiry)
+	}
+
+	auxTokens, err := authorizer.AuxiliaryTokens(ctx, &http.Request{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(auxTokens) != 2 || auxTokens[0].AccessToken != "--profile test --tenant tenant1" || auxTokens[1].AccessToken != "--profile test --tenant tenant2" {
+		t.Errorf("unexpected auxiliary tokens %v", auxTokens)
+	}
+
+	authorizer, _ = NewWgtAuthorizer(ctx, WgtAuthorizerOptions{Path: filepath.Join(t.TempDir(), "missing")})
+	if _, err := authorizer.Token(ctx, &http.Request{}); err == nil {
+		t.Errorf("expected an error for a missing tool")
+	}
+}
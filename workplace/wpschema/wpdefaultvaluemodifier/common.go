@@ -33,6 +33,14 @@ import (
 
 type defaultValueAttributePlanModifier struct{}
 
+// DefaultValuePlanModifier is implemented by all plan modifiers of this package. As they only depend on the config
+// value, the default value of an attribute can be determined by running them for a null config value.
+type DefaultValuePlanModifier interface {
+	IsDefaultValuePlanModifier()
+}
+
+func (attributePlanModifier defaultValueAttributePlanModifier) IsDefaultValuePlanModifier() {}
+
 func (attributePlanModifier defaultValueAttributePlanModifier) Description(_ context.Context) string {
 	return "If the value of the attribute is missing, then the value is semantically the same as if the value was present with the default value hard-coded in the provider."
 }
@@ -0,0 +1,44 @@
+package wpvalidator
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+)
+
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that the value is a positive duration (like "30s" or "2h45m").
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v durationValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a positive duration consisting of numbers and units (`s`, `m` or `h`), e.g. `30s` or `2h45m`"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+
+	configValue := request.ConfigValue
+	if configValue.IsNull() || configValue.IsUnknown() {
+		return
+	}
+
+	if d, err := time.ParseDuration(configValue.ValueString()); err != nil || d <= 0 {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			configValue.String(),
+		))
+	}
+}
+
+// Duration checks that the String held in the attribute is a positive duration as accepted by time.ParseDuration.
+func Duration() validator.String {
+	return durationValidator{}
+}
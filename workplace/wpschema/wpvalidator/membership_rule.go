@@ -0,0 +1,122 @@
+package wpvalidator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+)
+
+var _ validator.String = membershipRuleValidator{}
+
+// operators (lower case) supported by dynamic membership rules, see
+// https://learn.microsoft.com/en-us/entra/identity/users/groups-dynamic-membership#supported-expression-operators
+var membershipRuleOperators = []string{
+	"eq", "ne", "startswith", "notstartswith", "contains", "notcontains", "match", "notmatch", "in", "notin",
+	"le", "ge", "lt", "gt", "any", "all", "and", "or", "not", "memberof",
+}
+
+var membershipRuleOperatorRegexp = regexp.MustCompile(`(?:^|[\s(\]])-([A-Za-z]+)`)
+
+var membershipRuleDirectReportsRegexp = regexp.MustCompile(`(?i)^direct\s+reports\s+for\s+"[0-9a-f-]+"$`)
+
+// membershipRuleValidator validates the basic syntax of a dynamic membership rule (like
+// `(user.department -eq "Sales") -and (user.accountEnabled -eq true)`), i.e. that strings are terminated, parentheses
+// and brackets are balanced and only supported operators are used. The rule gets validated completely by MS Graph only.
+type membershipRuleValidator struct{}
+
+func (v membershipRuleValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v membershipRuleValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a syntactically valid dynamic membership rule, e.g. `(user.department -eq \"Sales\")`"
+}
+
+func (v membershipRuleValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+
+	configValue := request.ConfigValue
+	if configValue.IsNull() || configValue.IsUnknown() {
+		return
+	}
+
+	if err := checkMembershipRule(configValue.ValueString()); err != nil {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			fmt.Sprintf("%s (%s)", v.Description(ctx), err.Error()),
+			configValue.String(),
+		))
+	}
+}
+
+func checkMembershipRule(rule string) error {
+
+	rule = strings.TrimSpace(rule)
+	if rule == "" {
+		return fmt.Errorf("rule is empty")
+	}
+	if membershipRuleDirectReportsRegexp.MatchString(rule) {
+		return nil
+	}
+
+	// blank out string literals (which may contain anything) while checking that they are terminated, quotes within
+	// strings are escaped using a backtick (e.g. "Sales`"s")
+	var outside strings.Builder
+	var open []rune
+	inString, escaped := false, false
+	for _, c := range rule {
+		switch {
+		case inString && escaped:
+			escaped = false
+		case inString && c == '`':
+			escaped = true
+		case inString && c == '"':
+			inString = false
+			outside.WriteRune(c)
+		case inString:
+		case c == '"':
+			inString = true
+			outside.WriteRune(c)
+		case c == '(' || c == '[':
+			open = append(open, c)
+			outside.WriteRune(c)
+		case c == ')' || c == ']':
+			expected := map[rune]rune{')': '(', ']': '['}[c]
+			if len(open) == 0 || open[len(open)-1] != expected {
+				return fmt.Errorf("unexpected %q", c)
+			}
+			open = open[:len(open)-1]
+			outside.WriteRune(c)
+		default:
+			outside.WriteRune(c)
+		}
+	}
+	if inString {
+		return fmt.Errorf("unterminated string")
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("missing closing parenthesis or bracket")
+	}
+
+	matches := membershipRuleOperatorRegexp.FindAllStringSubmatch(outside.String(), -1)
+	if len(matches) == 0 {
+		return fmt.Errorf("no operator found")
+	}
+	for _, m := range matches {
+		if !slices.Contains(membershipRuleOperators, strings.ToLower(m[1])) {
+			return fmt.Errorf("unsupported operator -%s", m[1])
+		}
+	}
+	return nil
+}
+
+// MembershipRule checks that the String held in the attribute is a syntactically valid dynamic membership rule of
+// groups or administrative units.
+func MembershipRule() validator.String {
+	return membershipRuleValidator{}
+}
@@ -0,0 +1,37 @@
+package wpvalidator
+
+import (
+	"testing"
+)
+
+func TestCheckMembershipRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr bool
+	}{
+		{`user.department -eq "Sales"`, false},
+		{`(user.department -eq "Sales") -and (user.accountEnabled -eq true)`, false},
+		{`user.department -eq "Sales` + "`" + `"s"`, false},
+		{`user.department -eq "C:\Temp\"`, false},
+		{`user.department -in ["Sales", "Marketing"]`, false},
+		{`user.displayName -eq "-foo (bar]"`, false},
+		{`device.devicePhysicalIds -any (_ -contains "[ZTDId]")`, false},
+		{`user.memberOf -any (group.objectId -in ['11111111-1111-1111-1111-111111111111'])`, false},
+		{`Direct Reports for "11111111-1111-1111-1111-111111111111"`, false},
+		{"", true},
+		{`   `, true},
+		{`user.department -eq "Sales`, true},
+		{`user.department -eq "Sales` + "`" + `"`, true},
+		{`(user.department -eq "Sales"`, true},
+		{`user.department -eq "Sales")`, true},
+		{`user.department -in ["Sales")`, true},
+		{`user.department`, true},
+		{`user.department -equals "Sales"`, true},
+	}
+	for _, tt := range tests {
+		err := checkMembershipRule(tt.rule)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkMembershipRule(%q): got error %v, want error %t", tt.rule, err, tt.wantErr)
+		}
+	}
+}